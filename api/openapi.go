@@ -0,0 +1,12 @@
+// Package api contains the OpenAPI document of the
+// transaction parser REST API
+package api
+
+import (
+	_ "embed"
+)
+
+// OpenAPI is the OpenAPI 3 document of the /v1 API
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
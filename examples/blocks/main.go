@@ -22,12 +22,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"paulwizviz/go-eth-app/internal/config"
 	"paulwizviz/go-eth-app/internal/jrpc"
 )
 
 func main() {
-	url := "https://ethereum-rpc.publicnode.com"
-	client := jrpc.NewDefaultClient(url)
+	conf, err := config.Load(os.Args[0], os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := jrpc.NewClient(conf.Node.Timeout, conf.Node.URL)
 
 	number, err := client.BlockNumber(context.TODO(), 1)
 	if err != nil {
@@ -6,12 +6,17 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"paulwizviz/go-eth-app/internal/config"
 	"paulwizviz/go-eth-app/internal/contract"
 	"paulwizviz/go-eth-app/internal/jrpc"
 )
 
 func main() {
-	client := jrpc.NewDefaultClient("http://localhost:8545")
+	conf, err := config.LoadNetwork(config.Local, os.Args[0], os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := jrpc.NewClient(conf.Node.Timeout, conf.Node.URL)
 
 	pwd, err := os.Getwd()
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"log"
 	"math/big"
 	"os"
+	"paulwizviz/go-eth-app/internal/config"
 	"paulwizviz/go-eth-app/internal/contract"
 	"paulwizviz/go-eth-app/internal/jrpc"
 
@@ -17,7 +18,11 @@ import (
 
 func main() {
 
-	client := jrpc.NewDefaultClient("http://localhost:8545")
+	conf, err := config.LoadNetwork(config.Local, os.Args[0], os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := jrpc.NewClient(conf.Node.Timeout, conf.Node.URL)
 
 	// Get random dev account
 	devAcct, err := getDevRandomAcct(client)
@@ -4,12 +4,18 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"paulwizviz/go-eth-app/internal/config"
 	"paulwizviz/go-eth-app/internal/jrpc"
 )
 
 func main() {
 
-	client := jrpc.NewDefaultClient("http://localhost:8545")
+	conf, err := config.LoadNetwork(config.Local, os.Args[0], os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := jrpc.NewClient(conf.Node.Timeout, conf.Node.URL)
 
 	number, err := client.BlockNumber(context.TODO(), 1)
 	if err != nil {
@@ -2,54 +2,498 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
+	"os"
 	"os/signal"
+	"path/filepath"
+	"paulwizviz/go-eth-app/internal/auth"
+	"paulwizviz/go-eth-app/internal/config"
 	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/gql"
+	"paulwizviz/go-eth-app/internal/grpcserver"
 	rest "paulwizviz/go-eth-app/internal/http"
+	"paulwizviz/go-eth-app/internal/metrics"
+	"paulwizviz/go-eth-app/internal/sink"
+	"paulwizviz/go-eth-app/internal/store"
+	"paulwizviz/go-eth-app/internal/tracing"
+	"paulwizviz/go-eth-app/internal/webhook"
+	"strings"
+	"sync"
 	"syscall"
-	"time"
 )
 
-const EthUrl = "https://ethereum-rpc.publicnode.com"
+// Environment variables holding the authentication secrets
+const (
+	AdminKeyEnv     = "TXPARSER_ADMIN_KEY"
+	JWTSecretEnv    = "TXPARSER_JWT_SECRET"
+	NATSUserEnv     = "TXPARSER_NATS_USER"
+	NATSPasswordEnv = "TXPARSER_NATS_PASSWORD"
+	NATSTokenEnv    = "TXPARSER_NATS_TOKEN"
+)
 
 func main() {
+	// A failure that lets the deferred cleanups run sets the
+	// exit code
+	code := 0
+	defer func() {
+		if code != 0 {
+			os.Exit(code)
+		}
+	}()
+
+	// "index" runs the range indexing job of every chain, saves
+	// the snapshots and exits instead of serving
+	name, args := os.Args[0], os.Args[1:]
+	index := len(args) > 0 && args[0] == "index"
+	if index {
+		name, args = name+" index", args[1:]
+	}
+	conf, err := config.Load(name, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if index && (!conf.Index.Enabled() || conf.Storage.Snapshot == "") {
+		log.Fatal("index needs a range, e.g. -index-last, and -snapshot to keep the blocks indexed")
+	}
+
+	logger := newLogger(conf.Log)
+	// Route the standard logger through the same handler
+	slog.SetDefault(logger)
+
 	ctx := context.Background()
 	notify, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
+	// Ingestion outlives the servers so that their last requests
+	// are answered, and stops before the snapshots are taken
+	ingest, stopIngest := context.WithCancel(ctx)
+	defer stopIngest()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    conf.Tracing.Exporter,
+		Endpoint:    conf.Tracing.Endpoint,
+		Insecure:    conf.Tracing.Insecure,
+		SampleRatio: conf.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	// Create a channel from Ethereum network
-	// pass the channel to parser
-	ch := eth.ReadNetwork(ctx, EthUrl)
-	parser := eth.NewDefaultParser(ch)
+	var fileSink *sink.FileSink
+	if conf.Sinks.File != "" {
+		fileSink, err = sink.NewFileSink(conf.Sinks.File)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer fileSink.Close()
+	}
+
+	// Every chain has its own parser. The first is the primary
+	// chain, served outside /chains and by GraphQL, gRPC and
+	// webhooks.
+	networks := conf.Networks()
+	multi := len(networks) > 1
+	chains := eth.Chains{}
+	var parser eth.Parser
+	for _, n := range networks {
+		p, closers := startChain(ingest, notify, conf, n, multi, fileSink, logger)
+		for _, c := range closers {
+			defer c.Close()
+		}
+		chains[n.ChainID] = p
+		if parser == nil {
+			parser = p
+		}
+	}
+
+	if index {
+		if err := indexChains(notify, conf, chains, multi); err != nil {
+			log.Println(err)
+			code = 1
+		}
+		shutCtx, cancel := context.WithTimeout(ctx, conf.HTTP.ShutdownTimeout)
+		defer cancel()
+		stopIngest()
+		saveSnapshots(shutCtx, conf, chains, multi)
+		if err := shutdownTracing(shutCtx); err != nil {
+			log.Println(err)
+		}
+		return
+	}
+	// The jobs stop on interrupt and resume from their progress
+	// files on the next run
+	if conf.Index.Enabled() {
+		for id, p := range chains {
+			go func() {
+				if err := indexRange(notify, conf, id, p, multi); err != nil && !errors.Is(err, context.Canceled) {
+					logger.Error("range indexing", "chain", id, "err", err)
+				}
+			}()
+		}
+	}
+
+	if multi {
+		log.Printf("GraphQL, gRPC and webhooks serve the primary chain %d", networks[0].ChainID)
+	}
+
+	webhookCfg := webhook.DefaultConfig
+	webhookCfg.MaxAttempts = conf.Webhooks.MaxAttempts
+	webhookCfg.Timeout = conf.Webhooks.Timeout
+	webhookCfg.Concurrency = conf.Webhooks.Concurrency
+	webhookCfg.MaxConcurrency = conf.Webhooks.MaxConcurrency
+	webhookCfg.MaxEndpoints = conf.Webhooks.MaxEndpoints
+	webhookCfg.Buffer = conf.Webhooks.Buffer
+	webhookCfg.AllowPrivate = conf.Webhooks.AllowPrivate
+	webhookCfg.Logger = logger
+	webhooks := webhook.NewDispatcher(parser, webhookCfg)
+	defer webhooks.Close()
+
+	// Authentication is off unless enabled
+	var restAuth *rest.Auth
+	if conf.Auth.Enabled {
+		var verifier *auth.JWTVerifier
+		if secret := os.Getenv(JWTSecretEnv); secret != "" {
+			verifier = auth.NewJWTVerifier([]byte(secret), conf.Auth.JWTIssuer, conf.Auth.JWTAudience, auth.Limits{
+				RateLimit:        conf.Auth.RateLimit,
+				Burst:            conf.Auth.Burst,
+				MaxSubscriptions: conf.Auth.MaxSubscriptions,
+			})
+		}
+		// Keys live as long as the process unless kept in a file
+		keyStorage := store.NewInMemoryStorage()
+		if conf.Auth.KeysFile != "" {
+			keyStorage, err = store.NewFileStorage(conf.Auth.KeysFile)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		keys := auth.NewKeyStore(keyStorage)
+		restAuth = rest.NewAuth(keys, verifier, os.Getenv(AdminKeyEnv))
+		if restAuth.AdminSecret == "" && verifier == nil {
+			log.Printf("Neither %s nor %s is set, no client can authenticate", AdminKeyEnv, JWTSecretEnv)
+		}
+	}
+	cors := rest.CORS{AllowedOrigins: conf.HTTP.CORSOrigins}
+	health := rest.Health{Chains: chains, MaxLag: conf.HTTP.ReadyMaxLag}
+	subscribeOpts := rest.DefaultSubscribeOptions
+	subscribeOpts.Buffer = conf.Subscriptions.Buffer
 
 	// Inject parser to REST server
 	rest := &rest.RestServer{
-		Parser: parser,
+		Parser:     parser,
+		Webhooks:   webhooks,
+		Auth:       restAuth,
+		Logger:     logger,
+		TrustProxy: conf.HTTP.TrustProxy,
+		Context:    notify,
+
+		SubscribeOptions: subscribeOpts,
+	}
+
+	// Setup REST server. The unversioned routes are kept
+	// for existing clients.
+	rest.RegisterV1(http.DefaultServeMux)
+	rest.RegisterChains(http.DefaultServeMux, chains)
+	rest.RegisterParser(http.DefaultServeMux, "")
+	rest.RegisterWebhooks(http.DefaultServeMux, "")
+
+	gqlCfg := gql.DefaultConfig
+	gqlCfg.Logger = logger
+	gqlCfg.SubscribeOptions.Buffer = conf.Subscriptions.Buffer
+	if restAuth != nil {
+		gqlCfg.Quotas = restAuth.Quotas
 	}
+	graphql, err := gql.NewServer(parser, gqlCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	http.Handle("/graphql", rest.Protect(graphql.ServeHTTP))
+	http.Handle("/v1/graphql", rest.Protect(graphql.ServeHTTP))
 
-	// Setup REST server
-	http.HandleFunc("GET /", rest.GetCurrentBlock)
-	http.HandleFunc("GET /addresses", rest.GetAddresses)
-	http.HandleFunc("GET /addresses/{address}", rest.GetTransactions)
-	http.HandleFunc("GET /addresses/{address}/subscribe", rest.Subscribe)
+	// Probes and metrics are left unauthenticated for
+	// orchestrators and scrapers
+	http.HandleFunc("GET /healthz", health.Healthz)
+	http.HandleFunc("GET /readyz", health.Readyz)
+	http.Handle("GET /metrics", metrics.Handler())
 
 	server := &http.Server{
-		Addr:        "0.0.0.0:8080",
+		Addr:        conf.HTTP.Addr,
+		Handler:     cors.Handler(tracing.Handler(metrics.InstrumentHandler(http.DefaultServeMux))),
 		BaseContext: func(net.Listener) context.Context { return ctx },
 	}
 
-	log.Println("Server is listening on port 8080...")
+	log.Printf("Server is listening on %s...", conf.HTTP.Addr)
 	go server.ListenAndServe()
 
+	// gRPC shares the REST clients and their quotas
+	grpcCfg := grpcserver.Config{Logger: logger}
+	if restAuth != nil {
+		grpcCfg.Auth, grpcCfg.Quotas = restAuth, restAuth.Quotas
+	}
+	grpcServer := grpcserver.NewServer(notify, parser, grpcCfg)
+	if conf.GRPC.Addr != "" {
+		lis, err := net.Listen("tcp", conf.GRPC.Addr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("gRPC server is listening on %s...", conf.GRPC.Addr)
+		go grpcServer.Serve(lis)
+	}
+
 	<-notify.Done()
 
 	fmt.Println("")
 	log.Printf("Shutting down server...")
-	shutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	shutCtx, cancel := context.WithTimeout(ctx, conf.HTTP.ShutdownTimeout)
 	defer cancel()
 	server.Shutdown(shutCtx)
+
+	// Subscription streams only end when clients cancel them
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutCtx.Done():
+		grpcServer.Stop()
+	}
+
+	stopIngest()
+	saveSnapshots(shutCtx, conf, chains, multi)
+	if err := shutdownTracing(shutCtx); err != nil {
+		log.Println(err)
+	}
 	log.Println("Bye!")
 }
+
+// startChain reads the network of a chain into a new parser until
+// ctx is done. It returns the resources to close on shutdown.
+func startChain(ctx, notify context.Context, conf config.Config, n config.Chain, multi bool, fileSink *sink.FileSink, logger *slog.Logger) (eth.Parser, []io.Closer) {
+	logger = logger.With("network", n.Name)
+	cfg := eth.ParserConfig{ChainID: n.ChainID, Logger: logger}
+	var closers []io.Closer
+	if conf.Storage.Snapshot != "" {
+		path := chainFile(conf.Storage.Snapshot, n.ChainID, multi)
+		f, err := os.Open(path)
+		switch {
+		case err == nil:
+			closers = append(closers, f)
+			cfg.Snapshot = f
+			log.Printf("Loading snapshot %s", path)
+		case errors.Is(err, os.ErrNotExist):
+			log.Printf("No snapshot at %s, starting empty", path)
+		default:
+			log.Fatal(err)
+		}
+	}
+
+	checkpoint := func(name string) string {
+		return filepath.Join(conf.Sinks.CheckpointDir, chainFile(name, n.ChainID, multi))
+	}
+	if fileSink != nil {
+		cfg.Sinks = append(cfg.Sinks, newRelay(notify, fileSink, checkpoint("file.checkpoint"), logger))
+	}
+	if conf.Sinks.NATS != "" {
+		subject := conf.Sinks.NATSSubject
+		if multi {
+			subject = fmt.Sprintf("%s.%d", subject, n.ChainID)
+		}
+		s := sink.NewNATSSink(conf.Sinks.NATS, subject, natsOptions(conf.Sinks))
+		closers = append(closers, s)
+		cfg.Sinks = append(cfg.Sinks, newRelay(notify, s, checkpoint("nats.checkpoint"), logger))
+	}
+
+	if conf.Storage.Watchlist != "" {
+		path := chainFile(conf.Storage.Watchlist, n.ChainID, multi)
+		watchlist, err := eth.NewWatchlist(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Indexing the %d addresses watched in %s", watchlist.Len(), path)
+		cfg.Watchlist = watchlist
+	}
+
+	// Create a channel from the network and pass it
+	// to the parser
+	log.Printf("Reading %s (chain %d) from %s", n.Name, n.ChainID, n.URL)
+	netCfg := eth.NetworkConfig{
+		ChainID:          n.ChainID,
+		Finality:         n.Finality,
+		Confirmations:    n.Confirmations,
+		Receipts:         n.Receipts,
+		Traces:           n.Traces,
+		PollInterval:     n.PollInterval,
+		Timeout:          n.Timeout,
+		MaxBlocksPerPoll: n.MaxBlocksPerPoll,
+		Logger:           logger,
+	}
+	ch := eth.ReadNetworkWithConfig(ctx, n.URL, netCfg)
+	cfg.Blocks = eth.NewBlockReader(n.URL, netCfg)
+	if n.Mempool != config.MempoolNone {
+		url := n.MempoolURL
+		if url == "" {
+			url = n.URL
+		}
+		pending, err := eth.ReadMempool(ctx, url, eth.MempoolConfig{
+			Feed:    n.Mempool,
+			Timeout: n.Timeout,
+			ChainID: n.ChainID,
+			Logger:  logger,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Reading pending transactions of %s from %s", n.Name, url)
+		cfg.Pending = pending
+	}
+	parser, err := eth.NewParser(ch, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return parser, closers
+}
+
+// indexChains runs the range indexing job of every chain and
+// returns once all are done or ctx is cancelled
+func indexChains(ctx context.Context, conf config.Config, chains eth.Chains, multi bool) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(chains.IDs()))
+	for i, id := range chains.IDs() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := indexRange(ctx, conf, id, chains[id], multi); err != nil {
+				errs[i] = fmt.Errorf("chain %d: %w", id, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// indexRange runs the range indexing job of a chain until it is
+// done or ctx is cancelled
+func indexRange(ctx context.Context, conf config.Config, chainID int64, parser eth.Parser, multi bool) error {
+	indexer, ok := parser.(eth.Indexer)
+	if !ok {
+		return errors.New("the parser does not index ranges")
+	}
+	rangeCfg := eth.RangeConfig{
+		From:    conf.Index.From,
+		To:      conf.Index.To,
+		Last:    conf.Index.Last,
+		Workers: conf.Index.Workers,
+	}
+	if conf.Index.Progress != "" {
+		rangeCfg.Progress = chainFile(conf.Index.Progress, chainID, multi)
+	}
+	return indexer.IndexRange(ctx, rangeCfg)
+}
+
+// chainFile adds the chain ID to a file name when several
+// chains are ingested, e.g. snapshot.8453.gz
+func chainFile(path string, chainID int64, multi bool) string {
+	if !multi {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.%d%s", strings.TrimSuffix(path, ext), chainID, ext)
+}
+
+func newRelay(ctx context.Context, s sink.Sink, checkpoint string, logger *slog.Logger) *sink.Relay {
+	r, err := sink.NewRelay(ctx, s, sink.NewFileCheckpoint(checkpoint))
+	if err != nil {
+		log.Fatal(err)
+	}
+	r.Logger = logger
+	return r
+}
+
+func natsOptions(conf config.Sinks) sink.NATSOptions {
+	opts := sink.NATSOptions{
+		Timeout:  conf.NATSTimeout,
+		User:     os.Getenv(NATSUserEnv),
+		Password: os.Getenv(NATSPasswordEnv),
+		Token:    os.Getenv(NATSTokenEnv),
+	}
+	if !conf.NATSTLS {
+		return opts
+	}
+	opts.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	if conf.NATSCA != "" {
+		pem, err := os.ReadFile(conf.NATSCA)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts.TLS.RootCAs = x509.NewCertPool()
+		if !opts.TLS.RootCAs.AppendCertsFromPEM(pem) {
+			log.Fatalf("No certificate in %s", conf.NATSCA)
+		}
+	}
+	return opts
+}
+
+func newLogger(conf config.Log) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: conf.SlogLevel()}
+	if conf.Format == config.LogJSON {
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts))
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, opts))
+}
+
+// saveSnapshots waits for every chain to stop writing, once its
+// ingestion is stopped, and saves its snapshot if one is set
+func saveSnapshots(ctx context.Context, conf config.Config, chains eth.Chains, multi bool) {
+	for id, p := range chains {
+		if d, ok := p.(eth.Drainer); ok {
+			select {
+			case <-d.Done():
+			case <-ctx.Done():
+				log.Printf("Chain %d is still writing, not saving its snapshot", id)
+				continue
+			}
+		}
+		if conf.Storage.Snapshot != "" {
+			if err := saveSnapshot(chainFile(conf.Storage.Snapshot, id, multi), p); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// saveSnapshot writes the snapshot to a temporary file
+// first so an interrupted write never clobbers the
+// previous snapshot.
+func saveSnapshot(path string, parser eth.Parser) error {
+	s, ok := parser.(eth.Snapshotter)
+	if !ok {
+		return nil
+	}
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := s.ExportSnapshot(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	log.Printf("Saved snapshot %s", path)
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"errors"
+	"paulwizviz/go-eth-app/internal/store"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestKeyStore(t *testing.T) {
+	s := NewKeyStore(store.NewInMemoryStorage())
+
+	key, secret, err := s.Issue("frontend", "", Limits{RateLimit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Role != RoleUser {
+		t.Errorf("expected role %s; got %s", RoleUser, key.Role)
+	}
+
+	got, err := s.Authenticate(secret)
+	if err != nil || got.ID != key.ID || got.Limits.RateLimit != 5 {
+		t.Fatalf("expected key %s; got %+v %v", key.ID, got, err)
+	}
+	if _, err := s.Authenticate(secret + "0"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("expected ErrInvalidKey; got %v", err)
+	}
+	if _, _, err := s.Issue("ops", "root", Limits{}); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("expected ErrInvalidRole; got %v", err)
+	}
+
+	if _, err := s.Revoke(key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Authenticate(secret); !errors.Is(err, ErrKeyRevoked) {
+		t.Errorf("expected ErrKeyRevoked; got %v", err)
+	}
+	if _, err := s.Revoke("unknown"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound; got %v", err)
+	}
+
+	keys, err := s.Keys()
+	if err != nil || len(keys) != 1 || !keys[0].Revoked() {
+		t.Errorf("expected one revoked key; got %+v %v", keys, err)
+	}
+}
+
+func TestJWTVerifier(t *testing.T) {
+	secret := []byte("secret")
+	v := NewJWTVerifier(secret, "issuer", "", Limits{RateLimit: 1, MaxSubscriptions: 2})
+
+	sign := func(claims jwt.MapClaims, key []byte) string {
+		t.Helper()
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	p, err := v.Verify(sign(jwt.MapClaims{"sub": "alice", "iss": "issuer", "exp": exp, "maxSubscriptions": 10}, secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != "jwt:alice" || p.IsAdmin() || p.Limits.RateLimit != 1 || p.Limits.MaxSubscriptions != 10 {
+		t.Errorf("unexpected principal %+v", p)
+	}
+
+	tests := map[string]string{
+		"wrong key":      sign(jwt.MapClaims{"sub": "alice", "iss": "issuer", "exp": exp}, []byte("other")),
+		"wrong issuer":   sign(jwt.MapClaims{"sub": "alice", "iss": "other", "exp": exp}, secret),
+		"expired":        sign(jwt.MapClaims{"sub": "alice", "iss": "issuer", "exp": time.Now().Add(-time.Minute).Unix()}, secret),
+		"missing expiry": sign(jwt.MapClaims{"sub": "alice", "iss": "issuer"}, secret),
+		"missing sub":    sign(jwt.MapClaims{"iss": "issuer", "exp": exp}, secret),
+	}
+	for name, token := range tests {
+		if _, err := v.Verify(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken; got %v", name, err)
+		}
+	}
+}
+
+func TestQuotas(t *testing.T) {
+	q := NewQuotas()
+	p := Principal{ID: "a", Limits: Limits{RateLimit: 1, Burst: 2, MaxSubscriptions: 1}}
+
+	for i := range 2 {
+		if ok, _ := q.Allow(p); !ok {
+			t.Fatalf("request %d within burst rejected", i)
+		}
+	}
+	if ok, wait := q.Allow(p); ok || wait <= 0 {
+		t.Errorf("expected request above burst to be rejected with a wait; got %v %v", ok, wait)
+	}
+
+	if !q.Acquire(p) {
+		t.Fatal("first subscription rejected")
+	}
+	if q.Acquire(p) {
+		t.Error("expected second subscription to be rejected")
+	}
+	q.Release(p)
+	if !q.Acquire(p) {
+		t.Error("expected subscription after release to be accepted")
+	}
+}
+
+func TestQuotasIdle(t *testing.T) {
+	now := time.Now()
+	q := NewQuotas()
+	q.now = func() time.Time { return now }
+	busy := Principal{ID: "busy", Limits: Limits{RateLimit: 1, Burst: 2}}
+	idle := Principal{ID: "idle", Limits: Limits{RateLimit: 1, Burst: 2}}
+
+	q.Allow(busy)
+	q.Allow(idle)
+	// The idle limiter refills its burst in 2s, the busy one
+	// keeps requesting
+	for end := now.Add(sweepInterval); now.Before(end); {
+		now = now.Add(time.Second)
+		q.Allow(busy)
+	}
+	if _, found := q.limiters[idle.ID]; found {
+		t.Error("expected the idle limiter dropped")
+	}
+	if _, found := q.limiters[busy.ID]; !found {
+		t.Error("expected the busy limiter kept")
+	}
+
+	// A dropped limiter is recreated with a full burst
+	for i := range 2 {
+		if ok, _ := q.Allow(idle); !ok {
+			t.Errorf("request %d of a returning principal rejected", i)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package auth authenticates API clients with API keys or JWTs
+// and enforces per-client quotas
+package auth
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims are the claims read from a JWT. Role and the limits
+// are optional; unset limits fall back to the verifier
+// defaults.
+type Claims struct {
+	jwt.RegisteredClaims
+	Role             string   `json:"role,omitempty"`
+	RateLimit        *float64 `json:"rateLimit,omitempty"`
+	Burst            *int     `json:"burst,omitempty"`
+	MaxSubscriptions *int     `json:"maxSubscriptions,omitempty"`
+}
+
+// JWTVerifier verifies HS256 signed tokens
+type JWTVerifier struct {
+	secret   []byte
+	issuer   string
+	audience string
+	defaults Limits
+}
+
+// NewJWTVerifier instantiate a verifier. Issuer and audience are
+// only checked when set.
+func NewJWTVerifier(secret []byte, issuer, audience string, defaults Limits) *JWTVerifier {
+	return &JWTVerifier{
+		secret:   secret,
+		issuer:   issuer,
+		audience: audience,
+		defaults: defaults,
+	}
+}
+
+// Verify validates a token and returns its principal. Tokens
+// must carry a subject and an expiry.
+func (v *JWTVerifier) Verify(token string) (Principal, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if v.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		opts = append(opts, jwt.WithAudience(v.audience))
+	}
+
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return v.secret, nil
+	}, opts...)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w-%v", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return Principal{}, fmt.Errorf("%w-missing subject", ErrInvalidToken)
+	}
+
+	p := Principal{
+		ID:     "jwt:" + claims.Subject,
+		Role:   RoleUser,
+		Limits: v.defaults,
+	}
+	if claims.Role == RoleAdmin {
+		p.Role = RoleAdmin
+	}
+	if claims.RateLimit != nil {
+		p.Limits.RateLimit = *claims.RateLimit
+	}
+	if claims.Burst != nil {
+		p.Limits.Burst = *claims.Burst
+	}
+	if claims.MaxSubscriptions != nil {
+		p.Limits.MaxSubscriptions = *claims.MaxSubscriptions
+	}
+	return p, nil
+}
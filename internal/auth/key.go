@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"paulwizviz/go-eth-app/internal/store"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KeyPrefix starts every API key secret
+const KeyPrefix = "txp_"
+
+// storagePrefix namespaces keys in the storage
+const storagePrefix = "apikey/"
+
+var (
+	ErrKeyNotFound    = errors.New("api key not found")
+	ErrInvalidKey     = errors.New("invalid api key")
+	ErrKeyRevoked     = errors.New("api key revoked")
+	ErrInvalidRole    = errors.New("invalid role")
+	ErrGenerateKey    = errors.New("unable to generate api key")
+	ErrStoreKey       = errors.New("unable to store api key")
+	ErrCorruptedKey   = errors.New("corrupted api key record")
+	ErrInvalidLimits  = errors.New("invalid limits")
+	ErrMissingKeyName = errors.New("api key name required")
+)
+
+// Key is an issued API key. The secret is only returned when the
+// key is issued; a hash of it is stored.
+type Key struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	Limits    Limits     `json:"limits"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Revoked reports whether the key was revoked
+func (k Key) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Principal returns the principal authenticated by the key
+func (k Key) Principal() Principal {
+	return Principal{ID: k.ID, Role: k.Role, Limits: k.Limits}
+}
+
+type keyRecord struct {
+	Key
+	Hash string `json:"hash"`
+}
+
+// KeyStore issues, revokes and verifies API keys. Keys are
+// persisted in a store.Storage, one record per key.
+type KeyStore struct {
+	storage store.Storage
+	// mu serialises read-modify-write of records
+	mu sync.Mutex
+}
+
+// NewKeyStore instantiate a key store backed by storage
+func NewKeyStore(storage store.Storage) *KeyStore {
+	return &KeyStore{storage: storage}
+}
+
+// Issue creates a key and returns it with its secret. Role
+// defaults to RoleUser.
+func (s *KeyStore) Issue(name, role string, limits Limits) (Key, string, error) {
+	if name == "" {
+		return Key{}, "", ErrMissingKeyName
+	}
+	if role == "" {
+		role = RoleUser
+	}
+	if role != RoleUser && role != RoleAdmin {
+		return Key{}, "", fmt.Errorf("%w-%s", ErrInvalidRole, role)
+	}
+	if limits.RateLimit < 0 || limits.Burst < 0 || limits.MaxSubscriptions < 0 {
+		return Key{}, "", ErrInvalidLimits
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return Key{}, "", fmt.Errorf("%w-%v", ErrGenerateKey, err)
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return Key{}, "", fmt.Errorf("%w-%v", ErrGenerateKey, err)
+	}
+	secret := KeyPrefix + id.String() + "_" + hex.EncodeToString(b)
+
+	key := Key{
+		ID:        id.String(),
+		Name:      name,
+		Role:      role,
+		Limits:    limits,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.put(keyRecord{Key: key, Hash: hashSecret(secret)}); err != nil {
+		return Key{}, "", err
+	}
+	return key, secret, nil
+}
+
+// Revoke revokes a key. Revoking a revoked key is a no-op.
+func (s *KeyStore) Revoke(id string) (Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, err := s.get(id)
+	if err != nil {
+		return Key{}, err
+	}
+	if rec.RevokedAt == nil {
+		now := time.Now().UTC()
+		rec.RevokedAt = &now
+		if err := s.put(rec); err != nil {
+			return Key{}, err
+		}
+	}
+	return rec.Key, nil
+}
+
+// Get returns a key
+func (s *KeyStore) Get(id string) (Key, error) {
+	rec, err := s.get(id)
+	if err != nil {
+		return Key{}, err
+	}
+	return rec.Key, nil
+}
+
+// Keys returns every key, revoked or not, oldest first
+func (s *KeyStore) Keys() ([]Key, error) {
+	keys := []Key{}
+	for _, k := range s.storage.Keys() {
+		id, found := strings.CutPrefix(k, storagePrefix)
+		if !found {
+			continue
+		}
+		rec, err := s.get(id)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, rec.Key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+	return keys, nil
+}
+
+// Authenticate verifies a secret and returns its key
+func (s *KeyStore) Authenticate(secret string) (Key, error) {
+	rest, found := strings.CutPrefix(secret, KeyPrefix)
+	if !found {
+		return Key{}, ErrInvalidKey
+	}
+	id, _, found := strings.Cut(rest, "_")
+	if !found {
+		return Key{}, ErrInvalidKey
+	}
+	rec, err := s.get(id)
+	if errors.Is(err, ErrKeyNotFound) {
+		return Key{}, ErrInvalidKey
+	}
+	if err != nil {
+		return Key{}, err
+	}
+	if subtle.ConstantTimeCompare([]byte(rec.Hash), []byte(hashSecret(secret))) != 1 {
+		return Key{}, ErrInvalidKey
+	}
+	if rec.Revoked() {
+		return Key{}, ErrKeyRevoked
+	}
+	return rec.Key, nil
+}
+
+func (s *KeyStore) get(id string) (keyRecord, error) {
+	v, err := s.storage.Get(storagePrefix + id)
+	if errors.Is(err, store.ErrKeyNotFound) || (err == nil && len(v) == 0) {
+		return keyRecord{}, fmt.Errorf("%w-%s", ErrKeyNotFound, id)
+	}
+	if err != nil {
+		return keyRecord{}, err
+	}
+	var rec keyRecord
+	if err := json.Unmarshal(v[len(v)-1], &rec); err != nil {
+		return keyRecord{}, fmt.Errorf("%w-%v", ErrCorruptedKey, err)
+	}
+	return rec, nil
+}
+
+func (s *KeyStore) put(rec keyRecord) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("%w-%v", ErrStoreKey, err)
+	}
+	if err := s.storage.Set(storagePrefix+rec.ID, [][]byte{b}); err != nil {
+		return fmt.Errorf("%w-%v", ErrStoreKey, err)
+	}
+	return nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
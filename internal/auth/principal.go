@@ -0,0 +1,46 @@
+package auth
+
+import "context"
+
+// Roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Limits are the quotas of a client. Zero values are unlimited.
+type Limits struct {
+	// RateLimit is the sustained number of requests per second
+	RateLimit float64 `json:"rateLimit,omitempty"`
+	// Burst is the number of requests allowed above the rate
+	Burst int `json:"burst,omitempty"`
+	// MaxSubscriptions is the number of concurrent streaming
+	// subscriptions
+	MaxSubscriptions int `json:"maxSubscriptions,omitempty"`
+}
+
+// Principal is an authenticated client
+type Principal struct {
+	// ID is the key ID or the JWT subject
+	ID     string
+	Role   string
+	Limits Limits
+}
+
+// IsAdmin reports whether the principal may manage keys
+func (p Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+type principalKey struct{}
+
+// NewContext returns a context carrying the principal
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal of an authenticated request
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
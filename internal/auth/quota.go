@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// sweepInterval is how often the limiters left idle are dropped
+const sweepInterval = time.Minute
+
+// Quotas tracks the request rate and open subscriptions of
+// every principal
+type Quotas struct {
+	mu       sync.Mutex
+	limiters map[string]*limiter
+	subs     map[string]int
+	swept    time.Time
+	now      func() time.Time
+}
+
+type limiter struct {
+	limits Limits
+	*rate.Limiter
+	last time.Time // latest request
+}
+
+// idle reports whether the limiter has refilled its burst since
+// the latest request, so a new limiter would allow the same
+func (l *limiter) idle(now time.Time) bool {
+	refill := time.Duration(float64(l.Burst()) / float64(l.Limit()) * float64(time.Second))
+	return now.Sub(l.last) >= refill
+}
+
+// NewQuotas instantiate an empty quota tracker
+func NewQuotas() *Quotas {
+	return &Quotas{
+		limiters: map[string]*limiter{},
+		subs:     map[string]int{},
+		now:      time.Now,
+	}
+}
+
+// sweep drops the idle limiters at most once every sweepInterval
+func (q *Quotas) sweep(now time.Time) {
+	if now.Sub(q.swept) < sweepInterval {
+		return
+	}
+	q.swept = now
+	for id, l := range q.limiters {
+		if l.idle(now) {
+			delete(q.limiters, id)
+		}
+	}
+}
+
+// Allow consumes a request of the principal. If the rate limit
+// is exceeded it returns false and the time to wait before the
+// next request is allowed.
+func (q *Quotas) Allow(p Principal) (bool, time.Duration) {
+	if p.Limits.RateLimit <= 0 {
+		return true, 0
+	}
+
+	now := q.now()
+	q.mu.Lock()
+	q.sweep(now)
+	l, found := q.limiters[p.ID]
+	// A key's limits are fixed but a token's may change
+	if !found || l.limits != p.Limits {
+		burst := p.Limits.Burst
+		if burst < 1 {
+			burst = int(math.Ceil(p.Limits.RateLimit))
+		}
+		l = &limiter{limits: p.Limits, Limiter: rate.NewLimiter(rate.Limit(p.Limits.RateLimit), burst)}
+		q.limiters[p.ID] = l
+	}
+	l.last = now
+	q.mu.Unlock()
+
+	r := l.ReserveN(now, 1)
+	if !r.OK() {
+		return false, time.Second
+	}
+	if d := r.DelayFrom(now); d > 0 {
+		r.CancelAt(now)
+		return false, d
+	}
+	return true, 0
+}
+
+// Acquire opens a subscription for the principal, returning
+// false if its cap is reached. Every successful Acquire must be
+// matched by a Release.
+func (q *Quotas) Acquire(p Principal) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if max := p.Limits.MaxSubscriptions; max > 0 && q.subs[p.ID] >= max {
+		return false
+	}
+	q.subs[p.ID]++
+	return true
+}
+
+// Release closes a subscription of the principal
+func (q *Quotas) Release(p Principal) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.subs[p.ID]--
+	if q.subs[p.ID] <= 0 {
+		delete(q.subs, p.ID)
+	}
+}
+
+// Subscriptions returns the number of open subscriptions of the
+// principal
+func (q *Quotas) Subscriptions(id string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.subs[id]
+}
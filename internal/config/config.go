@@ -0,0 +1,473 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"paulwizviz/go-eth-app/internal/tracing"
+	"slices"
+	"time"
+)
+
+// EnvPrefix prefixes the environment variable of every setting
+const EnvPrefix = "TXPARSER_"
+
+// Network profiles
+const (
+	Mainnet  = "mainnet"
+	Sepolia  = "sepolia"
+	Arbitrum = "arbitrum"
+	Optimism = "optimism"
+	Base     = "base"
+	Polygon  = "polygon"
+	Local    = "local"
+)
+
+// Mempool feeds of pending transactions
+const (
+	MempoolNone      = "none"
+	MempoolTxpool    = "txpool"
+	MempoolWebSocket = "websocket"
+)
+
+// Finality rules, the head a node reports under each block tag
+const (
+	FinalityLatest    = "latest"
+	FinalitySafe      = "safe"
+	FinalityFinalized = "finalized"
+)
+
+// Storage backends
+const (
+	StorageMemory = "memory"
+)
+
+// Log formats
+const (
+	LogText = "text"
+	LogJSON = "json"
+)
+
+var (
+	// ErrInvalidConfig is returned when a setting fails validation
+	ErrInvalidConfig = errors.New("invalid configuration")
+	// ErrUnknownNetwork is returned for a network without a profile
+	ErrUnknownNetwork = errors.New("unknown network")
+)
+
+// Config is the configuration of the parser service. Each
+// setting is named by its yaml and toml key, its environment
+// variable (prefixed with EnvPrefix) and its flag.
+type Config struct {
+	Network       string        `yaml:"network" toml:"network" env:"NETWORK" flag:"network" usage:"network profile: mainnet, sepolia, arbitrum, optimism, base, polygon or local"`
+	Node          Node          `yaml:"node" toml:"node"`
+	Chains        []Chain       `yaml:"chains" toml:"chains"`
+	HTTP          HTTP          `yaml:"http" toml:"http"`
+	GRPC          GRPC          `yaml:"grpc" toml:"grpc"`
+	Storage       Storage       `yaml:"storage" toml:"storage"`
+	Index         Index         `yaml:"index" toml:"index"`
+	Subscriptions Subscriptions `yaml:"subscriptions" toml:"subscriptions"`
+	Webhooks      Webhooks      `yaml:"webhooks" toml:"webhooks"`
+	Sinks         Sinks         `yaml:"sinks" toml:"sinks"`
+	Auth          Auth          `yaml:"auth" toml:"auth"`
+	Log           Log           `yaml:"log" toml:"log"`
+	Tracing       Tracing       `yaml:"tracing" toml:"tracing"`
+}
+
+// Node is the Ethereum node the service reads from
+type Node struct {
+	URL          string        `yaml:"url" toml:"url" env:"NODE_URL" flag:"node-url" usage:"JSON-RPC URL of the Ethereum node"`
+	ChainID      int64         `yaml:"chainId" toml:"chainId" env:"CHAIN_ID" flag:"chain-id" usage:"chain ID of the network"`
+	PollInterval time.Duration `yaml:"pollInterval" toml:"pollInterval" env:"POLL_INTERVAL" flag:"poll-interval" usage:"interval between polls for the latest block"`
+	Timeout      time.Duration `yaml:"timeout" toml:"timeout" env:"NODE_TIMEOUT" flag:"node-timeout" usage:"timeout of a JSON-RPC request"`
+	// MaxBlocksPerPoll caps the blocks read on a poll to catch
+	// up with the head
+	MaxBlocksPerPoll int64 `yaml:"maxBlocksPerPoll" toml:"maxBlocksPerPoll" env:"MAX_BLOCKS_PER_POLL" flag:"max-blocks-per-poll" usage:"blocks read at most on a poll to catch up with the head; 64 if zero"`
+	// Finality is the block tag followed, and Confirmations the
+	// number of blocks kept behind it
+	Finality      string `yaml:"finality" toml:"finality" env:"FINALITY" flag:"finality" usage:"head followed: latest, safe or finalized"`
+	Confirmations int64  `yaml:"confirmations" toml:"confirmations" env:"CONFIRMATIONS" flag:"confirmations" usage:"blocks kept behind the followed head"`
+	// Receipts attaches status, gas used, fee and logs to every
+	// transaction
+	Receipts bool `yaml:"receipts" toml:"receipts" env:"RECEIPTS" flag:"receipts" usage:"fetch the receipt of every transaction"`
+	// Traces indexes the value moved by contract calls, with
+	// debug_traceBlockByNumber
+	Traces bool `yaml:"traces" toml:"traces" env:"TRACES" flag:"traces" usage:"trace blocks for internal transfers"`
+	// Mempool is the pending transaction feed, read from
+	// MempoolURL or URL if empty
+	Mempool    string `yaml:"mempool" toml:"mempool" env:"MEMPOOL" flag:"mempool" usage:"pending transaction feed: none, txpool or websocket"`
+	MempoolURL string `yaml:"mempoolUrl" toml:"mempoolUrl" env:"MEMPOOL_URL" flag:"mempool-url" usage:"node URL of the pending transaction feed, ws(s) for websocket; defaults to node-url for txpool"`
+}
+
+// Chain is one of several networks ingested at once. The node
+// settings it leaves out are taken from the profile of Network.
+// Chains are only read from the configuration file.
+type Chain struct {
+	// Name defaults to Network
+	Name    string `yaml:"name" toml:"name"`
+	Network string `yaml:"network" toml:"network"`
+	Node    `yaml:",inline"`
+}
+
+// HTTP is the REST, GraphQL and metrics server
+type HTTP struct {
+	Addr            string        `yaml:"addr" toml:"addr" env:"HTTP_ADDR" flag:"http-addr" usage:"listen address of the HTTP server"`
+	CORSOrigins     []string      `yaml:"corsOrigins" toml:"corsOrigins" env:"CORS_ORIGINS" flag:"cors-origins" usage:"comma separated origins allowed by CORS, * for any; none by default"`
+	ReadyMaxLag     int64         `yaml:"readyMaxLag" toml:"readyMaxLag" env:"READY_MAX_LAG" flag:"ready-max-lag" usage:"blocks ingestion may lag the chain head before /readyz fails"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" toml:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" usage:"time given to open requests on shutdown"`
+	TrustProxy      bool          `yaml:"trustProxy" toml:"trustProxy" env:"HTTP_TRUST_PROXY" flag:"trust-proxy" usage:"build links from the X-Forwarded-Proto and X-Forwarded-Host headers set by a reverse proxy"`
+}
+
+// GRPC is the gRPC server
+type GRPC struct {
+	Addr string `yaml:"addr" toml:"addr" env:"GRPC_ADDR" flag:"grpc-addr" usage:"listen address of the gRPC server, empty to disable"`
+}
+
+// Storage selects where transactions are kept
+type Storage struct {
+	Backend  string `yaml:"backend" toml:"backend" env:"STORAGE" flag:"storage" usage:"transaction storage backend: memory"`
+	Snapshot string `yaml:"snapshot" toml:"snapshot" env:"SNAPSHOT" flag:"snapshot" usage:"path of a parser snapshot to load on startup and save on shutdown"`
+	// Watchlist, if set, is the file of the addresses indexed;
+	// every address is indexed otherwise
+	Watchlist string `yaml:"watchlist" toml:"watchlist" env:"WATCHLIST" flag:"watchlist" usage:"path of the watchlist file; when set only watched addresses are indexed"`
+}
+
+// Index is a historical range indexing job run alongside
+// ingestion, enabled by From, To or Last
+type Index struct {
+	From     int64  `yaml:"from" toml:"from" env:"INDEX_FROM" flag:"index-from" usage:"first block of a range indexing job"`
+	To       int64  `yaml:"to" toml:"to" env:"INDEX_TO" flag:"index-to" usage:"last block of a range indexing job, the latest processed if zero"`
+	Last     int64  `yaml:"last" toml:"last" env:"INDEX_LAST" flag:"index-last" usage:"index the last blocks up to index-to instead of from index-from"`
+	Workers  int    `yaml:"workers" toml:"workers" env:"INDEX_WORKERS" flag:"index-workers" usage:"blocks read in parallel by a range indexing job"`
+	Progress string `yaml:"progress" toml:"progress" env:"INDEX_PROGRESS" flag:"index-progress" usage:"file the progress of a range indexing job is kept in to resume it"`
+}
+
+// Enabled reports whether a range indexing job is configured
+func (i Index) Enabled() bool {
+	return i.From > 0 || i.To > 0 || i.Last > 0
+}
+
+// Subscriptions sizes the streaming subscriptions
+type Subscriptions struct {
+	Buffer int `yaml:"buffer" toml:"buffer" env:"SUBSCRIBE_BUFFER" flag:"subscribe-buffer" usage:"transactions buffered per SSE, WebSocket and GraphQL subscription"`
+}
+
+// Webhooks controls webhook delivery
+type Webhooks struct {
+	MaxAttempts    int           `yaml:"maxAttempts" toml:"maxAttempts" env:"WEBHOOK_MAX_ATTEMPTS" flag:"webhook-max-attempts" usage:"attempts before a delivery is dead-lettered"`
+	Timeout        time.Duration `yaml:"timeout" toml:"timeout" env:"WEBHOOK_TIMEOUT" flag:"webhook-timeout" usage:"timeout of a webhook POST"`
+	Concurrency    int           `yaml:"concurrency" toml:"concurrency" env:"WEBHOOK_CONCURRENCY" flag:"webhook-concurrency" usage:"default in-flight deliveries per endpoint"`
+	MaxConcurrency int           `yaml:"maxConcurrency" toml:"maxConcurrency" env:"WEBHOOK_MAX_CONCURRENCY" flag:"webhook-max-concurrency" usage:"in-flight deliveries an endpoint may ask for"`
+	MaxEndpoints   int           `yaml:"maxEndpoints" toml:"maxEndpoints" env:"WEBHOOK_MAX_ENDPOINTS" flag:"webhook-max-endpoints" usage:"webhook endpoints per client"`
+	Buffer         int           `yaml:"buffer" toml:"buffer" env:"WEBHOOK_BUFFER" flag:"webhook-buffer" usage:"transactions queued per endpoint"`
+	AllowPrivate   bool          `yaml:"allowPrivate" toml:"allowPrivate" env:"WEBHOOK_ALLOW_PRIVATE" flag:"webhook-allow-private" usage:"deliver webhooks to loopback and private network addresses"`
+}
+
+// Sinks are the destinations of parsed blocks
+type Sinks struct {
+	File          string        `yaml:"file" toml:"file" env:"SINK_FILE" flag:"sink-file" usage:"path of an append log receiving parsed blocks"`
+	NATS          string        `yaml:"nats" toml:"nats" env:"SINK_NATS" flag:"sink-nats" usage:"address of a NATS server whose JetStream receives parsed blocks"`
+	NATSSubject   string        `yaml:"natsSubject" toml:"natsSubject" env:"SINK_NATS_SUBJECT" flag:"sink-nats-subject" usage:"subject prefix for NATS records"`
+	NATSTimeout   time.Duration `yaml:"natsTimeout" toml:"natsTimeout" env:"SINK_NATS_TIMEOUT" flag:"sink-nats-timeout" usage:"timeout of a NATS write"`
+	NATSTLS       bool          `yaml:"natsTLS" toml:"natsTLS" env:"SINK_NATS_TLS" flag:"sink-nats-tls" usage:"connect to NATS over TLS; the credentials are read from TXPARSER_NATS_USER, TXPARSER_NATS_PASSWORD or TXPARSER_NATS_TOKEN"`
+	NATSCA        string        `yaml:"natsCA" toml:"natsCA" env:"SINK_NATS_CA" flag:"sink-nats-ca" usage:"PEM file of the CAs trusted for the NATS server, defaults to the system roots"`
+	CheckpointDir string        `yaml:"checkpointDir" toml:"checkpointDir" env:"SINK_CHECKPOINT_DIR" flag:"sink-checkpoint-dir" usage:"directory of the sink checkpoints"`
+}
+
+// Auth controls client authentication. The secrets are only
+// read from the environment.
+type Auth struct {
+	Enabled          bool    `yaml:"enabled" toml:"enabled" env:"AUTH" flag:"auth" usage:"require an API key or JWT; the secrets are read from TXPARSER_ADMIN_KEY and TXPARSER_JWT_SECRET"`
+	JWTIssuer        string  `yaml:"jwtIssuer" toml:"jwtIssuer" env:"AUTH_JWT_ISSUER" flag:"auth-jwt-issuer" usage:"required JWT issuer"`
+	JWTAudience      string  `yaml:"jwtAudience" toml:"jwtAudience" env:"AUTH_JWT_AUDIENCE" flag:"auth-jwt-audience" usage:"required JWT audience"`
+	RateLimit        float64 `yaml:"rateLimit" toml:"rateLimit" env:"AUTH_RATE_LIMIT" flag:"auth-rate-limit" usage:"default requests per second of a JWT client"`
+	Burst            int     `yaml:"burst" toml:"burst" env:"AUTH_BURST" flag:"auth-burst" usage:"default request burst of a JWT client"`
+	MaxSubscriptions int     `yaml:"maxSubscriptions" toml:"maxSubscriptions" env:"AUTH_MAX_SUBSCRIPTIONS" flag:"auth-max-subscriptions" usage:"default concurrent subscriptions of a JWT client"`
+	KeysFile         string  `yaml:"keysFile" toml:"keysFile" env:"AUTH_KEYS_FILE" flag:"auth-keys-file" usage:"path of the file keeping the issued API keys; keys are lost on restart if empty"`
+}
+
+// Log controls the service logs
+type Log struct {
+	Level  string `yaml:"level" toml:"level" env:"LOG_LEVEL" flag:"log-level" usage:"minimum log level: debug, info, warn or error"`
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT" flag:"log-format" usage:"log format: text or json"`
+}
+
+// Tracing controls span export
+type Tracing struct {
+	Exporter    string  `yaml:"exporter" toml:"exporter" env:"TRACE_EXPORTER" flag:"trace-exporter" usage:"span exporter: none, stdout or otlp"`
+	Endpoint    string  `yaml:"endpoint" toml:"endpoint" env:"TRACE_ENDPOINT" flag:"trace-endpoint" usage:"host:port of the OTLP gRPC collector, defaults to OTEL_EXPORTER_OTLP_ENDPOINT"`
+	Insecure    bool    `yaml:"insecure" toml:"insecure" env:"TRACE_INSECURE" flag:"trace-insecure" usage:"connect to the OTLP collector without TLS"`
+	SampleRatio float64 `yaml:"sampleRatio" toml:"sampleRatio" env:"TRACE_SAMPLE_RATIO" flag:"trace-sample-ratio" usage:"fraction of traces sampled"`
+}
+
+// Profiles are the node settings of the named networks
+var Profiles = map[string]Node{
+	Mainnet: {
+		URL:          "https://ethereum-rpc.publicnode.com",
+		ChainID:      1,
+		PollInterval: 5 * time.Second,
+		Timeout:      60 * time.Second,
+		Finality:     FinalityLatest,
+		Mempool:      MempoolNone,
+	},
+	Sepolia: {
+		URL:          "https://ethereum-sepolia-rpc.publicnode.com",
+		ChainID:      11155111,
+		PollInterval: 5 * time.Second,
+		Timeout:      60 * time.Second,
+		Finality:     FinalityLatest,
+		Mempool:      MempoolNone,
+	},
+	Arbitrum: {
+		URL:          "https://arbitrum-one-rpc.publicnode.com",
+		ChainID:      42161,
+		PollInterval: time.Second,
+		Timeout:      30 * time.Second,
+		Finality:     FinalityLatest,
+		Mempool:      MempoolNone,
+	},
+	Optimism: {
+		URL:          "https://optimism-rpc.publicnode.com",
+		ChainID:      10,
+		PollInterval: 2 * time.Second,
+		Timeout:      30 * time.Second,
+		Finality:     FinalityLatest,
+		Mempool:      MempoolNone,
+	},
+	Base: {
+		URL:          "https://base-rpc.publicnode.com",
+		ChainID:      8453,
+		PollInterval: 2 * time.Second,
+		Timeout:      30 * time.Second,
+		Finality:     FinalityLatest,
+		Mempool:      MempoolNone,
+	},
+	Polygon: {
+		URL:           "https://polygon-bor-rpc.publicnode.com",
+		ChainID:       137,
+		PollInterval:  2 * time.Second,
+		Timeout:       30 * time.Second,
+		Finality:      FinalityLatest,
+		Confirmations: 32,
+		Mempool:       MempoolNone,
+	},
+	Local: {
+		URL:          "http://localhost:8545",
+		ChainID:      1337,
+		PollInterval: time.Second,
+		Timeout:      10 * time.Second,
+		Finality:     FinalityLatest,
+		Mempool:      MempoolNone,
+	},
+}
+
+// Default returns the configuration used when nothing is set
+func Default() Config {
+	return Config{
+		Network: Mainnet,
+		Node:    Profiles[Mainnet],
+		HTTP: HTTP{
+			Addr:            "0.0.0.0:8080",
+			ReadyMaxLag:     10,
+			ShutdownTimeout: 5 * time.Second,
+		},
+		GRPC:          GRPC{Addr: "0.0.0.0:9090"},
+		Storage:       Storage{Backend: StorageMemory},
+		Index:         Index{Workers: 4},
+		Subscriptions: Subscriptions{Buffer: 64},
+		Webhooks: Webhooks{
+			MaxAttempts:    5,
+			Timeout:        10 * time.Second,
+			Concurrency:    4,
+			MaxConcurrency: 32,
+			MaxEndpoints:   100,
+			Buffer:         1024,
+		},
+		Sinks: Sinks{
+			NATSSubject:   "eth",
+			NATSTimeout:   10 * time.Second,
+			CheckpointDir: ".",
+		},
+		Auth: Auth{
+			RateLimit:        10,
+			Burst:            20,
+			MaxSubscriptions: 5,
+		},
+		Log:     Log{Level: "info", Format: LogText},
+		Tracing: Tracing{Exporter: tracing.ExporterNone, SampleRatio: 1},
+	}
+}
+
+// Networks returns the chains to ingest: Chains if set,
+// otherwise the single chain of Network and Node
+func (c Config) Networks() []Chain {
+	if len(c.Chains) > 0 {
+		return c.Chains
+	}
+	return []Chain{{Name: c.Network, Network: c.Network, Node: c.Node}}
+}
+
+// resolve fills the settings ch leaves out from its profile
+func (ch Chain) resolve() (Chain, error) {
+	if ch.Network == "" {
+		if ch.Name == "" {
+			ch.Name = fmt.Sprintf("chain-%d", ch.ChainID)
+		}
+		if ch.Finality == "" {
+			ch.Finality = FinalityLatest
+		}
+		if ch.Mempool == "" {
+			ch.Mempool = MempoolNone
+		}
+		return ch, nil
+	}
+	base, ok := Profiles[ch.Network]
+	if !ok {
+		return Chain{}, fmt.Errorf("%w-%q", ErrUnknownNetwork, ch.Network)
+	}
+	if ch.Name == "" {
+		ch.Name = ch.Network
+	}
+	if ch.URL != "" {
+		base.URL = ch.URL
+	}
+	if ch.ChainID != 0 {
+		base.ChainID = ch.ChainID
+	}
+	if ch.PollInterval != 0 {
+		base.PollInterval = ch.PollInterval
+	}
+	if ch.Timeout != 0 {
+		base.Timeout = ch.Timeout
+	}
+	if ch.MaxBlocksPerPoll != 0 {
+		base.MaxBlocksPerPoll = ch.MaxBlocksPerPoll
+	}
+	if ch.Finality != "" {
+		base.Finality = ch.Finality
+	}
+	if ch.Confirmations != 0 {
+		base.Confirmations = ch.Confirmations
+	}
+	if ch.Receipts {
+		base.Receipts = true
+	}
+	if ch.Traces {
+		base.Traces = true
+	}
+	if ch.Mempool != "" {
+		base.Mempool = ch.Mempool
+	}
+	if ch.MempoolURL != "" {
+		base.MempoolURL = ch.MempoolURL
+	}
+	ch.Node = base
+	return ch, nil
+}
+
+// SlogLevel returns the log level, info if it is invalid
+func (l Log) SlogLevel() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(l.Level)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// Validate reports every invalid setting
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if _, ok := Profiles[c.Network]; !ok {
+		errs = append(errs, fmt.Errorf("%w %q", ErrUnknownNetwork, c.Network))
+	}
+	errs = append(errs, c.Node.validate("node")...)
+	ids := map[int64]bool{}
+	names := map[string]bool{}
+	for i, ch := range c.Chains {
+		key := fmt.Sprintf("chains[%d]", i)
+		errs = append(errs, ch.Node.validate(key)...)
+		check(!ids[ch.ChainID], "%s.chainId %d is not unique", key, ch.ChainID)
+		check(!names[ch.Name], "%s.name %q is not unique", key, ch.Name)
+		ids[ch.ChainID] = true
+		names[ch.Name] = true
+	}
+
+	check(validAddr(c.HTTP.Addr), "http.addr %q is not a host:port", c.HTTP.Addr)
+	check(c.HTTP.ReadyMaxLag >= 0, "http.readyMaxLag must not be negative")
+	check(c.HTTP.ShutdownTimeout > 0, "http.shutdownTimeout must be positive")
+	check(c.GRPC.Addr == "" || validAddr(c.GRPC.Addr), "grpc.addr %q is not a host:port", c.GRPC.Addr)
+
+	check(c.Storage.Backend == StorageMemory, "storage.backend %q is not supported", c.Storage.Backend)
+	check(c.Subscriptions.Buffer > 0, "subscriptions.buffer must be positive")
+	check(c.Index.From >= 0 && c.Index.To >= 0 && c.Index.Last >= 0, "index.from, index.to and index.last must not be negative")
+	check(c.Index.To == 0 || c.Index.Last > 0 || c.Index.From <= c.Index.To, "index.from must not be after index.to")
+	check(c.Index.To == 0 || c.Index.From > 0 || c.Index.Last > 0, "index.to needs index.from or index.last")
+	check(c.Index.Workers > 0, "index.workers must be positive")
+
+	check(c.Webhooks.MaxAttempts > 0, "webhooks.maxAttempts must be positive")
+	check(c.Webhooks.Timeout > 0, "webhooks.timeout must be positive")
+	check(c.Webhooks.Concurrency > 0, "webhooks.concurrency must be positive")
+	check(c.Webhooks.MaxConcurrency >= c.Webhooks.Concurrency, "webhooks.maxConcurrency must not be below webhooks.concurrency")
+	check(c.Webhooks.MaxEndpoints > 0, "webhooks.maxEndpoints must be positive")
+	check(c.Webhooks.Buffer > 0, "webhooks.buffer must be positive")
+
+	check(c.Sinks.NATS == "" || validAddr(c.Sinks.NATS), "sinks.nats %q is not a host:port", c.Sinks.NATS)
+	check(c.Sinks.NATS == "" || c.Sinks.NATSSubject != "", "sinks.natsSubject must be set with sinks.nats")
+	check(c.Sinks.NATSTimeout > 0, "sinks.natsTimeout must be positive")
+	check(c.Sinks.NATSCA == "" || c.Sinks.NATSTLS, "sinks.natsCA requires sinks.natsTLS")
+
+	check(c.Auth.RateLimit > 0, "auth.rateLimit must be positive")
+	check(c.Auth.Burst > 0, "auth.burst must be positive")
+	check(c.Auth.MaxSubscriptions >= 0, "auth.maxSubscriptions must not be negative")
+
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "log.level %q is not debug, info, warn or error", c.Log.Level)
+	check(c.Log.Format == LogText || c.Log.Format == LogJSON, "log.format %q is not text or json", c.Log.Format)
+
+	exporters := []string{tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP}
+	check(slices.Contains(exporters, c.Tracing.Exporter), "tracing.exporter %q is not none, stdout or otlp", c.Tracing.Exporter)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sampleRatio must be between 0 and 1")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w-%v", ErrInvalidConfig, errors.Join(errs...))
+	}
+	return nil
+}
+
+func (n Node) validate(key string) []error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	u, err := url.Parse(n.URL)
+	check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", "%s.url %q is not an http(s) URL", key, n.URL)
+	check(n.ChainID > 0, "%s.chainId must be positive", key)
+	check(n.PollInterval > 0, "%s.pollInterval must be positive", key)
+	check(n.Timeout > 0, "%s.timeout must be positive", key)
+	check(n.MaxBlocksPerPoll >= 0, "%s.maxBlocksPerPoll must not be negative", key)
+	finality := []string{FinalityLatest, FinalitySafe, FinalityFinalized}
+	check(slices.Contains(finality, n.Finality), "%s.finality %q is not latest, safe or finalized", key, n.Finality)
+	check(n.Confirmations >= 0, "%s.confirmations must not be negative", key)
+	mempools := []string{MempoolNone, MempoolTxpool, MempoolWebSocket}
+	check(slices.Contains(mempools, n.Mempool), "%s.mempool %q is not none, txpool or websocket", key, n.Mempool)
+	if n.Mempool == MempoolWebSocket {
+		u, err := url.Parse(n.MempoolURL)
+		check(err == nil && (u.Scheme == "ws" || u.Scheme == "wss") && u.Host != "", "%s.mempoolUrl %q is not a ws(s) URL", key, n.MempoolURL)
+	}
+	return errs
+}
+
+func validAddr(addr string) bool {
+	_, port, err := net.SplitHostPort(addr)
+	return err == nil && port != ""
+}
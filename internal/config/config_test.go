@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(k string) (string, bool) {
+		v, ok := vars[k]
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := load(Mainnet, "test", nil, env(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Node != Profiles[Mainnet] || cfg.HTTP.Addr != "0.0.0.0:8080" {
+		t.Errorf("expected mainnet defaults; got %+v", cfg)
+	}
+	if len(cfg.HTTP.CORSOrigins) != 0 {
+		t.Errorf("expected no CORS origins by default; got %v", cfg.HTTP.CORSOrigins)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, "txparser.yaml", `
+network: sepolia
+node:
+  pollInterval: 12s
+http:
+  addr: 127.0.0.1:8000
+  corsOrigins: [https://a.example, https://b.example]
+grpc:
+  addr: 127.0.0.1:9000
+log:
+  level: warn
+`)
+	cfg, err := load(Mainnet, "test", []string{"-config", path, "-log-level", "debug"}, env(map[string]string{
+		"TXPARSER_GRPC_ADDR": "127.0.0.1:9001",
+		"TXPARSER_LOG_LEVEL": "error",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The profile fills what the file leaves out
+	if cfg.Node.URL != Profiles[Sepolia].URL || cfg.Node.ChainID != 11155111 {
+		t.Errorf("expected the sepolia node; got %+v", cfg.Node)
+	}
+	if cfg.Node.PollInterval != 12*time.Second || cfg.HTTP.Addr != "127.0.0.1:8000" || len(cfg.HTTP.CORSOrigins) != 2 {
+		t.Errorf("expected the file settings; got %+v %+v", cfg.Node, cfg.HTTP)
+	}
+	if cfg.GRPC.Addr != "127.0.0.1:9001" {
+		t.Errorf("expected the environment over the file; got %s", cfg.GRPC.Addr)
+	}
+	if cfg.Log.Level != "debug" {
+		t.Errorf("expected the flag over the environment; got %s", cfg.Log.Level)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := writeFile(t, "txparser.toml", `
+network = "local"
+
+[webhooks]
+timeout = "3s"
+`)
+	cfg, err := load(Mainnet, "test", nil, env(map[string]string{ConfigEnv: path, "TXPARSER_CORS_ORIGINS": "https://a.example, https://b.example"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Node.URL != "http://localhost:8545" || cfg.Webhooks.Timeout != 3*time.Second {
+		t.Errorf("expected the local profile and file settings; got %+v %+v", cfg.Node, cfg.Webhooks)
+	}
+	if len(cfg.HTTP.CORSOrigins) != 2 || cfg.HTTP.CORSOrigins[1] != "https://b.example" {
+		t.Errorf("expected the origins from the environment; got %v", cfg.HTTP.CORSOrigins)
+	}
+}
+
+func TestLoadChains(t *testing.T) {
+	path := writeFile(t, "txparser.yaml", `
+chains:
+  - network: mainnet
+    confirmations: 12
+  - network: base
+    pollInterval: 500ms
+  - name: devnet
+    url: http://localhost:9545
+    chainId: 31337
+    pollInterval: 1s
+    timeout: 5s
+    finality: finalized
+`)
+	cfg, err := load(Mainnet, "test", []string{"-config", path}, env(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	chains := cfg.Networks()
+	if len(chains) != 3 {
+		t.Fatalf("expected 3 chains; got %d", len(chains))
+	}
+	if chains[0].Name != Mainnet || chains[0].ChainID != 1 || chains[0].Confirmations != 12 || chains[0].URL != Profiles[Mainnet].URL {
+		t.Errorf("expected mainnet with 12 confirmations; got %+v", chains[0])
+	}
+	if chains[1].ChainID != 8453 || chains[1].PollInterval != 500*time.Millisecond || chains[1].Finality != FinalityLatest {
+		t.Errorf("expected base with its own poll interval; got %+v", chains[1])
+	}
+	if chains[2].Name != "devnet" || chains[2].ChainID != 31337 || chains[2].Finality != FinalityFinalized {
+		t.Errorf("expected the custom chain; got %+v", chains[2])
+	}
+
+	single := Default().Networks()
+	if len(single) != 1 || single[0].Name != Mainnet || single[0].Node != Profiles[Mainnet] {
+		t.Errorf("expected the single network; got %+v", single)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		fileName string
+		file     string
+		want     error
+	}{
+		{name: "network", args: []string{"-network", "goerli"}, want: ErrUnknownNetwork},
+		{name: "value", args: []string{"-poll-interval", "often"}, want: ErrParseValue},
+		{name: "invalid", args: []string{"-node-url", "localhost", "-subscribe-buffer", "0"}, want: ErrInvalidConfig},
+		{name: "unknown key", fileName: "txparser.yml", file: "http:\n  adress: :8080\n", want: ErrReadFile},
+		{name: "format", fileName: "txparser.json", file: "{}", want: ErrUnknownFormat},
+		{name: "chain network", fileName: "txparser.yaml", file: "chains:\n  - network: goerli\n", want: ErrUnknownNetwork},
+		{name: "duplicate chain", fileName: "txparser.yaml", file: "chains:\n  - network: base\n  - network: base\n    name: base2\n", want: ErrInvalidConfig},
+		{name: "finality", args: []string{"-finality", "final"}, want: ErrInvalidConfig},
+		{name: "index to only", args: []string{"-index-to", "100"}, want: ErrInvalidConfig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.fileName != "" {
+				args = append(args, "-config", writeFile(t, tt.fileName, tt.file))
+			}
+			if _, err := load(Mainnet, "test", args, env(nil)); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v; got %v", tt.want, err)
+			}
+		})
+	}
+}
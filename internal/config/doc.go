@@ -0,0 +1,3 @@
+// Package config loads the typed configuration of the parser
+// service and its clients from files, the environment and flags
+package config
@@ -0,0 +1,274 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigEnv names the configuration file when -config is not given
+const ConfigEnv = EnvPrefix + "CONFIG"
+
+var (
+	// ErrReadFile is returned when the configuration file cannot be read
+	ErrReadFile = errors.New("read configuration file")
+	// ErrUnknownFormat is returned for a file that is not .yaml, .yml or .toml
+	ErrUnknownFormat = errors.New("unknown configuration format")
+	// ErrParseValue is returned for an environment variable or flag
+	// that does not parse as its setting
+	ErrParseValue = errors.New("parse configuration value")
+)
+
+// Load builds the configuration from, in increasing precedence,
+// Default, the network profile, the file named by -config or
+// TXPARSER_CONFIG, TXPARSER_* environment variables and the
+// flags in args. The result is validated.
+func Load(name string, args []string) (Config, error) {
+	return load(Mainnet, name, args, os.LookupEnv)
+}
+
+// LoadNetwork is Load with network as the default profile, for
+// tools that target a development chain
+func LoadNetwork(network, name string, args []string) (Config, error) {
+	return load(network, name, args, os.LookupEnv)
+}
+
+func load(network, name string, args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	cfg := Default()
+	cfg.Network = network
+	settings := fields(&cfg)
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	path := fs.String("config", "", "path of a YAML or TOML configuration file, or "+ConfigEnv)
+	flags := map[string]*rawValue{}
+	for _, s := range settings {
+		v := &rawValue{raw: s.String(), bool: s.kind() == reflect.Bool}
+		flags[s.flag] = v
+		fs.Var(v, s.flag, s.usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if *path == "" {
+		*path, _ = lookupEnv(ConfigEnv)
+	}
+
+	var data []byte
+	var file Config
+	if *path != "" {
+		var err error
+		if data, err = os.ReadFile(*path); err != nil {
+			return Config{}, fmt.Errorf("%w-%v", ErrReadFile, err)
+		}
+		if err := decode(*path, data, &file); err != nil {
+			return Config{}, err
+		}
+	}
+
+	// The network picks the profile the other sources override
+	if file.Network != "" {
+		network = file.Network
+	}
+	if v, ok := lookupEnv(EnvPrefix + "NETWORK"); ok {
+		network = v
+	}
+	if flags["network"].set {
+		network = flags["network"].raw
+	}
+	node, ok := Profiles[network]
+	if !ok {
+		return Config{}, fmt.Errorf("%w-%q", ErrUnknownNetwork, network)
+	}
+	cfg.Network = network
+	cfg.Node = node
+
+	// Decoding onto the profile keeps the settings the file
+	// leaves out
+	if data != nil {
+		if err := decode(*path, data, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+	for i, ch := range cfg.Chains {
+		resolved, err := ch.resolve()
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.Chains[i] = resolved
+	}
+	for _, s := range settings {
+		if v, ok := lookupEnv(EnvPrefix + s.env); ok {
+			if err := s.Set(v); err != nil {
+				return Config{}, fmt.Errorf("%w-%s%s: %v", ErrParseValue, EnvPrefix, s.env, err)
+			}
+		}
+	}
+	for _, s := range settings {
+		if v := flags[s.flag]; v.set {
+			if err := s.Set(v.raw); err != nil {
+				return Config{}, fmt.Errorf("%w-%s: %v", ErrParseValue, s.flag, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// decode parses a YAML or TOML file onto cfg, rejecting unknown keys
+func decode(path string, b []byte, cfg *Config) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("%w-%v", ErrReadFile, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(b), cfg)
+		if err != nil {
+			return fmt.Errorf("%w-%v", ErrReadFile, err)
+		}
+		if keys := md.Undecoded(); len(keys) > 0 {
+			return fmt.Errorf("%w-unknown keys %v", ErrReadFile, keys)
+		}
+	default:
+		return fmt.Errorf("%w-%s", ErrUnknownFormat, path)
+	}
+	return nil
+}
+
+// setting is a leaf field of Config
+type setting struct {
+	v     reflect.Value
+	env   string
+	flag  string
+	usage string
+}
+
+// fields returns the settings of cfg in declaration order
+func fields(cfg *Config) []setting {
+	var out []setting
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Duration(0)) {
+				walk(v.Field(i))
+				continue
+			}
+			// Lists of sections, such as Chains, are file only
+			if f.Tag.Get("flag") == "" {
+				continue
+			}
+			out = append(out, setting{
+				v:     v.Field(i),
+				env:   f.Tag.Get("env"),
+				flag:  f.Tag.Get("flag"),
+				usage: f.Tag.Get("usage"),
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem())
+	return out
+}
+
+func (s setting) kind() reflect.Kind {
+	return s.v.Kind()
+}
+
+// String formats the current value as Set accepts it
+func (s setting) String() string {
+	switch v := s.v.Interface().(type) {
+	case time.Duration:
+		return v.String()
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Set parses raw into the setting
+func (s setting) Set(raw string) error {
+	if s.v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		s.v.SetInt(int64(d))
+		return nil
+	}
+	switch s.kind() {
+	case reflect.String:
+		s.v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		s.v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		s.v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		s.v.SetFloat(f)
+	case reflect.Slice:
+		var list []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		s.v.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("unsupported setting type %s", s.v.Type())
+	}
+	return nil
+}
+
+// rawValue records a flag so it can be applied after the
+// file and environment
+type rawValue struct {
+	raw  string
+	bool bool
+	set  bool
+}
+
+func (v *rawValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.raw
+}
+
+func (v *rawValue) Set(raw string) error {
+	v.raw = raw
+	v.set = true
+	return nil
+}
+
+func (v *rawValue) IsBoolFlag() bool {
+	return v.bool
+}
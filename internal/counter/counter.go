@@ -34,3 +34,28 @@ func (t *Counter) Get(topic string) int64 {
 	}
 	return t.counts[topic]
 }
+
+// Set overwrites the count for a given topic
+func (t *Counter) Set(topic string, count int64) {
+	t.Lock()
+	t.counts[topic] = count
+	t.Unlock()
+}
+
+// Counts returns a copy of all counts
+func (t *Counter) Counts() map[string]int64 {
+	t.RLock()
+	defer t.RUnlock()
+	counts := make(map[string]int64, len(t.counts))
+	for k, v := range t.counts {
+		counts[k] = v
+	}
+	return counts
+}
+
+// Len returns the number of topics counted
+func (t *Counter) Len() int {
+	t.RLock()
+	defer t.RUnlock()
+	return len(t.counts)
+}
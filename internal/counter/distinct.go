@@ -0,0 +1,124 @@
+package counter
+
+import (
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sync"
+)
+
+var ErrInvalidState = errors.New("invalid counter state")
+
+// DefaultPrecision keeps 1024 registers, a kilobyte, for a
+// standard error of about 3%
+const DefaultPrecision = 10
+
+const (
+	minPrecision = 4
+	maxPrecision = 16
+)
+
+// Distinct approximates the number of distinct keys added, with
+// HyperLogLog. It takes 2^precision bytes whatever the number of
+// keys, for a standard error of 1.04/sqrt(2^precision).
+type Distinct struct {
+	mu        sync.Mutex
+	precision uint8
+	registers []uint8
+}
+
+// NewDistinct creates a sketch of the given precision, clamped
+// between 4 and 16
+func NewDistinct(precision uint8) *Distinct {
+	p := min(max(precision, minPrecision), maxPrecision)
+	return &Distinct{precision: p, registers: make([]uint8, 1<<p)}
+}
+
+// Add adds key to the sketch
+func (d *Distinct) Add(key string) {
+	h := hash(key)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i := h >> (64 - d.precision)
+	// The guard bit bounds the rank when the remaining bits
+	// are zero
+	rank := uint8(bits.LeadingZeros64(h<<d.precision|1<<(d.precision-1))) + 1
+	if rank > d.registers[i] {
+		d.registers[i] = rank
+	}
+}
+
+// Count returns the approximate number of distinct keys added
+func (d *Distinct) Count() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	m := float64(len(d.registers))
+	var sum float64
+	zeros := 0
+	for _, r := range d.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha(len(d.registers)) * m * m / sum
+	// Linear counting is more accurate for few keys
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int64(math.Round(estimate))
+}
+
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
+
+// hash returns the 64 bit FNV-1a hash of key, mixed so that every
+// bit depends on every byte. It is stable across restarts so
+// snapshots keep counting the same keys.
+func hash(key string) uint64 {
+	f := fnv.New64a()
+	f.Write([]byte(key))
+	h := f.Sum64()
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+type distinctState struct {
+	Precision uint8  `json:"precision"`
+	Registers []byte `json:"registers"`
+}
+
+func (d *Distinct) MarshalJSON() ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return json.Marshal(distinctState{Precision: d.precision, Registers: d.registers})
+}
+
+func (d *Distinct) UnmarshalJSON(b []byte) error {
+	var s distinctState
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s.Precision < minPrecision || s.Precision > maxPrecision || len(s.Registers) != 1<<s.Precision {
+		return ErrInvalidState
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.precision, d.registers = s.Precision, s.Registers
+	return nil
+}
@@ -0,0 +1,44 @@
+package counter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestDistinct(t *testing.T) {
+	testcases := []int{0, 10, 1000, 100000}
+	for _, n := range testcases {
+		d := NewDistinct(DefaultPrecision)
+		for i := range n {
+			d.Add(fmt.Sprintf("0x%040x", i))
+			d.Add(fmt.Sprintf("0x%040x", i))
+		}
+		got := d.Count()
+		if math.Abs(float64(got-int64(n))) > 0.1*float64(n) {
+			t.Errorf("expected about %d distinct keys; got %d", n, got)
+		}
+	}
+}
+
+func TestDistinctJSON(t *testing.T) {
+	d := NewDistinct(DefaultPrecision)
+	for i := range 100 {
+		d.Add(fmt.Sprint(i))
+	}
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := NewDistinct(minPrecision)
+	if err := json.Unmarshal(b, restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Count() != d.Count() {
+		t.Errorf("expected %d; got %d", d.Count(), restored.Count())
+	}
+	if err := json.Unmarshal([]byte(`{"precision":1}`), restored); err == nil {
+		t.Error("expected an error restoring an invalid state")
+	}
+}
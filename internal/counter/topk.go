@@ -0,0 +1,159 @@
+package counter
+
+import (
+	"cmp"
+	"container/heap"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultTopKCapacity is the number of keys TopK tracks unless
+// told otherwise
+const DefaultTopKCapacity = 1000
+
+// Item is a key tracked by TopK. Count overestimates the events
+// of the key by at most Error.
+type Item struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+	Error int64  `json:"error"`
+}
+
+// TopK tracks the keys with the most events in a fixed number of
+// slots, with the space saving algorithm: a new key takes the
+// slot of the key with the fewest events, inheriting its count.
+// Keys with more events than the total over the capacity are
+// always tracked.
+type TopK struct {
+	mu       sync.Mutex
+	capacity int
+	slots    map[string]*topSlot
+	heap     topHeap
+}
+
+type topSlot struct {
+	Item
+	index int // in the heap
+}
+
+// topHeap is a min-heap of slots by count
+type topHeap []*topSlot
+
+func (h topHeap) Len() int           { return len(h) }
+func (h topHeap) Less(i, j int) bool { return h[i].Count < h[j].Count }
+func (h topHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *topHeap) Push(x any) {
+	s := x.(*topSlot)
+	s.index = len(*h)
+	*h = append(*h, s)
+}
+func (h *topHeap) Pop() any {
+	old := *h
+	s := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return s
+}
+
+// NewTopK creates a TopK tracking up to capacity keys
+func NewTopK(capacity int) *TopK {
+	if capacity <= 0 {
+		capacity = DefaultTopKCapacity
+	}
+	return &TopK{capacity: capacity, slots: map[string]*topSlot{}}
+}
+
+// Add counts n events of key
+func (t *TopK) Add(key string, n int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s, ok := t.slots[key]; ok {
+		s.Count += n
+		heap.Fix(&t.heap, s.index)
+		return
+	}
+	if len(t.heap) < t.capacity {
+		s := &topSlot{Item: Item{Key: key, Count: n}}
+		t.slots[key] = s
+		heap.Push(&t.heap, s)
+		return
+	}
+	s := t.heap[0]
+	delete(t.slots, s.Key)
+	s.Item = Item{Key: key, Count: s.Count + n, Error: s.Count}
+	t.slots[key] = s
+	heap.Fix(&t.heap, 0)
+}
+
+// Top returns up to n keys with the most events, most first
+func (t *TopK) Top(n int) []Item {
+	t.mu.Lock()
+	items := make([]Item, 0, len(t.heap))
+	for _, s := range t.heap {
+		items = append(items, s.Item)
+	}
+	t.mu.Unlock()
+	slices.SortFunc(items, func(x, y Item) int {
+		if c := cmp.Compare(y.Count, x.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(x.Key, y.Key)
+	})
+	return items[:min(max(n, 0), len(items))]
+}
+
+// Len returns the number of keys tracked
+func (t *TopK) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.heap)
+}
+
+type topKState struct {
+	Capacity int    `json:"capacity"`
+	Items    []Item `json:"items"`
+}
+
+func (t *TopK) MarshalJSON() ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := topKState{Capacity: t.capacity, Items: make([]Item, 0, len(t.heap))}
+	for _, slot := range t.heap {
+		s.Items = append(s.Items, slot.Item)
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON restores the keys of a snapshot. The capacity of t
+// is kept, dropping the keys with the fewest events if the
+// snapshot tracked more.
+func (t *TopK) UnmarshalJSON(b []byte) error {
+	var s topKState
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.capacity <= 0 {
+		t.capacity = s.Capacity
+		if t.capacity <= 0 {
+			t.capacity = DefaultTopKCapacity
+		}
+	}
+	slices.SortFunc(s.Items, func(x, y Item) int { return cmp.Compare(y.Count, x.Count) })
+	t.slots = map[string]*topSlot{}
+	t.heap = nil
+	for _, item := range s.Items {
+		if _, ok := t.slots[item.Key]; ok || len(t.heap) == t.capacity {
+			continue
+		}
+		slot := &topSlot{Item: item}
+		t.slots[item.Key] = slot
+		heap.Push(&t.heap, slot)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package counter
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	// 40 events in 4 slots track every key of more than 10
+	top := NewTopK(4)
+	for i := range 10 {
+		top.Add("hot", 2)
+		top.Add("warm", 1)
+		top.Add(fmt.Sprintf("cold%d", i), 1)
+	}
+
+	items := top.Top(2)
+	if len(items) != 2 || items[0].Key != "hot" || items[0].Count != 20 || items[0].Error != 0 {
+		t.Fatalf("expected hot first with an exact count; got %+v", items)
+	}
+	if items[1].Key != "warm" || items[1].Count < 10 || items[1].Count-items[1].Error > 10 {
+		t.Errorf("expected warm second, overestimated by at most its error; got %+v", items[1])
+	}
+	if top.Len() != 4 {
+		t.Errorf("expected 4 keys tracked; got %d", top.Len())
+	}
+
+	b, err := json.Marshal(top)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := NewTopK(2)
+	if err := json.Unmarshal(b, restored); err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.Top(5); len(got) != 2 || got[0] != items[0] || got[1] != items[1] {
+		t.Errorf("expected the top 2 restored; got %+v", got)
+	}
+}
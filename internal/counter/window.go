@@ -0,0 +1,87 @@
+package counter
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WindowSpec is a sliding window of Size, counted in Buckets
+// buckets of equal width. Counts expire a bucket at a time.
+type WindowSpec struct {
+	Size    time.Duration `json:"size"`
+	Buckets int           `json:"buckets"`
+}
+
+// Last hour, day and week windows
+var (
+	WindowHour = WindowSpec{Size: time.Hour, Buckets: 12}
+	WindowDay  = WindowSpec{Size: 24 * time.Hour, Buckets: 24}
+	WindowWeek = WindowSpec{Size: 7 * 24 * time.Hour, Buckets: 28}
+)
+
+// Window counts the events of the last Size. It is not safe for
+// concurrent use.
+type Window struct {
+	width   time.Duration
+	head    int64 // bucket of the latest event
+	buckets []int64
+}
+
+// NewWindow creates a window of spec
+func NewWindow(spec WindowSpec) *Window {
+	n := max(spec.Buckets, 1)
+	return &Window{
+		width:   max(spec.Size/time.Duration(n), 1),
+		buckets: make([]int64, n),
+	}
+}
+
+// Add counts n events at the given time. Events older than the
+// window are ignored.
+func (w *Window) Add(at time.Time, n int64) {
+	b := at.UnixNano() / int64(w.width)
+	size := int64(len(w.buckets))
+	if b <= w.head-size {
+		return
+	}
+	if b > w.head {
+		for k := max(w.head+1, b-size+1); k <= b; k++ {
+			w.buckets[k%size] = 0
+		}
+		w.head = b
+	}
+	w.buckets[b%size] += n
+}
+
+// Count returns the events of the window ending at now
+func (w *Window) Count(now time.Time) int64 {
+	b := now.UnixNano() / int64(w.width)
+	size := int64(len(w.buckets))
+	var sum int64
+	for k := max(w.head, b) - size + 1; k <= min(w.head, b); k++ {
+		sum += w.buckets[k%size]
+	}
+	return sum
+}
+
+type windowState struct {
+	Width   time.Duration `json:"width"`
+	Head    int64         `json:"head"`
+	Buckets []int64       `json:"buckets"`
+}
+
+func (w *Window) MarshalJSON() ([]byte, error) {
+	return json.Marshal(windowState{Width: w.width, Head: w.head, Buckets: w.buckets})
+}
+
+func (w *Window) UnmarshalJSON(b []byte) error {
+	var s windowState
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s.Width <= 0 || len(s.Buckets) == 0 {
+		return ErrInvalidState
+	}
+	w.width, w.head, w.buckets = s.Width, s.Head, s.Buckets
+	return nil
+}
@@ -0,0 +1,50 @@
+package counter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	w := NewWindow(WindowHour)
+	w.Add(start, 1)
+	w.Add(start.Add(30*time.Minute), 2)
+	w.Add(start.Add(-2*time.Hour), 5)
+
+	testcases := []struct {
+		now  time.Time
+		want int64
+	}{
+		{start.Add(30 * time.Minute), 3},
+		{start.Add(59 * time.Minute), 3},
+		{start.Add(61 * time.Minute), 2},
+		{start.Add(2 * time.Hour), 0},
+	}
+	for i, tc := range testcases {
+		if got := w.Count(tc.now); got != tc.want {
+			t.Errorf("case %d: expected %d; got %d", i, tc.want, got)
+		}
+	}
+
+	w.Add(start.Add(3*time.Hour), 1)
+	if got := w.Count(start.Add(3 * time.Hour)); got != 1 {
+		t.Errorf("expected the expired buckets cleared; got %d", got)
+	}
+
+	b, err := json.Marshal(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored Window
+	if err := json.Unmarshal(b, &restored); err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.Count(start.Add(3 * time.Hour)); got != 1 {
+		t.Errorf("expected the window restored; got %d", got)
+	}
+	if err := json.Unmarshal([]byte(`{"width":0}`), &restored); err == nil {
+		t.Error("expected an invalid state error")
+	}
+}
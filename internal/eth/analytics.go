@@ -0,0 +1,527 @@
+package eth
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"paulwizviz/go-eth-app/internal/counter"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTopCounterparties is the number of counterparties in the
+// stats of an address unless asked otherwise
+const DefaultTopCounterparties = 10
+
+// DefaultMaxAnalyzed is the number of addresses with analytics
+// unless told otherwise
+const DefaultMaxAnalyzed = 100000
+
+const (
+	// IntervalHour buckets activity by the hour, over the last
+	// week of activity
+	IntervalHour = "hour"
+	// IntervalDay buckets activity by the day, over the last
+	// year of activity
+	IntervalDay = "day"
+)
+
+const (
+	hourBuckets = 7 * 24
+	dayBuckets  = 366
+	// maxCounterparties bounds the counterparties of an address
+	// counted exactly
+	maxCounterparties = 256
+)
+
+// Counterparty is an address an address transacted with
+type Counterparty struct {
+	Address      string `json:"address"`
+	Transactions int64  `json:"transactions"`
+	// In is the wei received from the counterparty
+	In string `json:"in"`
+	// Out is the wei sent to the counterparty
+	Out string `json:"out"`
+}
+
+// ActivityBucket is the activity of an address over an hour or a
+// day
+type ActivityBucket struct {
+	Start        time.Time `json:"start"`
+	Transactions int64     `json:"transactions"`
+	In           string    `json:"in"`
+	Out          string    `json:"out"`
+}
+
+// RecentActivity is the number of transactions of an address in
+// the last hour, day and week, by block time
+type RecentActivity struct {
+	LastHour int64 `json:"lastHour"`
+	LastDay  int64 `json:"lastDay"`
+	LastWeek int64 `json:"lastWeek"`
+}
+
+// ActiveAddress is an address among the most active. Transactions
+// overestimates its transactions by at most Error.
+type ActiveAddress struct {
+	Address      string `json:"address"`
+	Transactions int64  `json:"transactions"`
+	Error        int64  `json:"error"`
+}
+
+// AddressStats are the analytics of an address, over the
+// transactions indexed under it. Amounts are hex quantities in
+// wei.
+type AddressStats struct {
+	Address      string `json:"address"`
+	Transactions int64  `json:"transactions"`
+	// In and Out are the value of the transactions received and
+	// sent, with the internal transfers when blocks are traced.
+	// Failed transactions move no value.
+	In  string `json:"in"`
+	Out string `json:"out"`
+	// Net is In minus Out
+	Net string `json:"net"`
+	// Fees are the fees of the transactions sent, when receipts
+	// are fetched
+	Fees       string         `json:"fees"`
+	FirstBlock int64          `json:"firstBlock"`
+	LastBlock  int64          `json:"lastBlock"`
+	Recent     RecentActivity `json:"recent"`
+	// UniqueCounterparties is the approximate number of addresses
+	// transacted with
+	UniqueCounterparties int64 `json:"uniqueCounterparties"`
+	// Counterparties are those with the most transactions. Past
+	// 256 counterparties, the one with the fewest transactions
+	// makes room for a new one.
+	Counterparties []Counterparty   `json:"counterparties"`
+	Interval       string           `json:"interval"`
+	Activity       []ActivityBucket `json:"activity"`
+}
+
+// Analyzer is implemented by parsers that keep analytics of the
+// addresses they index
+type Analyzer interface {
+	// AddressStats returns the stats of address with its top
+	// counterparties and its activity bucketed by interval,
+	// false if no transaction of address is indexed
+	AddressStats(address string, interval string, top int) (AddressStats, bool)
+	// TopAddresses returns up to n of the addresses with the
+	// most transactions, most first
+	TopAddresses(n int) []ActiveAddress
+}
+
+// flow is value moved to and from an address
+type flow struct {
+	Transactions int64    `json:"transactions"`
+	In           *big.Int `json:"in,omitempty"`
+	Out          *big.Int `json:"out,omitempty"`
+}
+
+func (f *flow) add(in, out *big.Int) {
+	f.In = addWei(f.In, in)
+	f.Out = addWei(f.Out, out)
+}
+
+// addressAnalytics are the running analytics of an address
+type addressAnalytics struct {
+	flow
+	Fees           *big.Int         `json:"fees,omitempty"`
+	FirstBlock     int64            `json:"firstBlock"`
+	LastBlock      int64            `json:"lastBlock"`
+	Counterparties map[string]*flow `json:"counterparties,omitempty"`
+	// Unique counts every counterparty once some were dropped to
+	// bound Counterparties; until then they are all kept
+	Unique *counter.Distinct `json:"unique,omitempty"`
+	// Recent counts the transactions of the last hour, day and
+	// week
+	Recent []*counter.Window `json:"recent,omitempty"`
+	// Hours and Days are keyed by the unix time they start at
+	Hours map[int64]*flow `json:"hours,omitempty"`
+	Days  map[int64]*flow `json:"days,omitempty"`
+}
+
+// analytics are updated as transactions are indexed, so querying
+// them never reads the stored transactions. Only the first max
+// addresses indexed get analytics, which bounds them without a
+// watchlist; every address counts in active.
+type analytics struct {
+	mu    sync.RWMutex
+	max   int
+	addrs map[string]*addressAnalytics
+	// active counts the addresses with the most transactions
+	active *counter.TopK
+}
+
+// recentWindows are the windows of RecentActivity
+var recentWindows = []counter.WindowSpec{counter.WindowHour, counter.WindowDay, counter.WindowWeek}
+
+func newAnalytics(max int) *analytics {
+	if max <= 0 {
+		max = DefaultMaxAnalyzed
+	}
+	return &analytics{
+		max:    max,
+		addrs:  map[string]*addressAnalytics{},
+		active: counter.NewTopK(counter.DefaultTopKCapacity),
+	}
+}
+
+// get returns the analytics of addr, nil if there is no room for
+// them
+func (a *analytics) get(addr string) *addressAnalytics {
+	s, ok := a.addrs[addr]
+	if !ok {
+		if len(a.addrs) >= a.max {
+			return nil
+		}
+		s = &addressAnalytics{FirstBlock: -1, LastBlock: -1}
+		a.addrs[addr] = s
+	}
+	return s
+}
+
+// recordTxn adds tx, produced at the given time, to the analytics
+// of address. It must be called once per transaction and address.
+func (a *analytics) recordTxn(address string, tx Transaction, at time.Time) {
+	addr := strings.ToLower(address)
+	sent := strings.EqualFold(tx.From, addr)
+	received := strings.EqualFold(tx.To, addr)
+	var in, out *big.Int
+	if tx.Receipt == nil || tx.Receipt.Status != ReceiptStatusFailure {
+		value := hexWei(tx.Value)
+		if received {
+			in = value
+		}
+		if sent {
+			out = value
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.active.Add(addr, 1)
+	s := a.get(addr)
+	if s == nil {
+		return
+	}
+	s.Transactions++
+	s.addRecent(at)
+	s.add(in, out)
+	if sent && tx.Receipt != nil {
+		s.Fees = addWei(s.Fees, hexWei(tx.Receipt.Fee))
+	}
+	if n, err := strconv.ParseInt(strings.TrimPrefix(tx.Block, "0x"), 16, 64); err == nil {
+		if s.FirstBlock < 0 || n < s.FirstBlock {
+			s.FirstBlock = n
+		}
+		if n > s.LastBlock {
+			s.LastBlock = n
+		}
+	}
+
+	other := strings.ToLower(tx.From)
+	if sent {
+		other = strings.ToLower(tx.To)
+	}
+	if other != "" && other != addr {
+		c := s.counterparty(other)
+		c.Transactions++
+		c.add(in, out)
+		if s.Unique != nil {
+			s.Unique.Add(other)
+		}
+	}
+
+	if at.IsZero() {
+		return
+	}
+	if s.Hours == nil {
+		s.Hours = map[int64]*flow{}
+		s.Days = map[int64]*flow{}
+	}
+	for _, b := range []struct {
+		buckets map[int64]*flow
+		width   time.Duration
+		keep    int
+	}{
+		{s.Hours, time.Hour, hourBuckets},
+		{s.Days, 24 * time.Hour, dayBuckets},
+	} {
+		if f := bucket(b.buckets, at, b.width, b.keep); f != nil {
+			f.Transactions++
+			f.add(in, out)
+		}
+	}
+}
+
+// addRecent counts a transaction at the given time, now if zero,
+// in the recent windows
+func (s *addressAnalytics) addRecent(at time.Time) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if len(s.Recent) != len(recentWindows) {
+		s.Recent = make([]*counter.Window, len(recentWindows))
+		for i, spec := range recentWindows {
+			s.Recent[i] = counter.NewWindow(spec)
+		}
+	}
+	for _, w := range s.Recent {
+		w.Add(at, 1)
+	}
+}
+
+// recent returns the transactions of the recent windows ending
+// at now
+func (s *addressAnalytics) recent(now time.Time) RecentActivity {
+	if len(s.Recent) != len(recentWindows) {
+		return RecentActivity{}
+	}
+	return RecentActivity{
+		LastHour: s.Recent[0].Count(now),
+		LastDay:  s.Recent[1].Count(now),
+		LastWeek: s.Recent[2].Count(now),
+	}
+}
+
+// counterparty returns the flow with addr, making room for it by
+// dropping the counterparty with the fewest transactions if needed
+func (s *addressAnalytics) counterparty(addr string) *flow {
+	if c, ok := s.Counterparties[addr]; ok {
+		return c
+	}
+	if s.Counterparties == nil {
+		s.Counterparties = map[string]*flow{}
+	}
+	if len(s.Counterparties) >= maxCounterparties {
+		// The counterparties dropped are only counted by the
+		// sketch, so it starts with all those seen so far
+		if s.Unique == nil {
+			s.Unique = counter.NewDistinct(counter.DefaultPrecision)
+			for k := range s.Counterparties {
+				s.Unique.Add(k)
+			}
+		}
+		var fewest string
+		for k, c := range s.Counterparties {
+			if fewest == "" || c.Transactions < s.Counterparties[fewest].Transactions {
+				fewest = k
+			}
+		}
+		delete(s.Counterparties, fewest)
+	}
+	c := &flow{}
+	s.Counterparties[addr] = c
+	return c
+}
+
+// recordInternal adds the value an internal transfer moved to the
+// analytics of address
+func (a *analytics) recordInternal(address string, t InternalTransfer) {
+	if t.Failed {
+		return
+	}
+	addr := strings.ToLower(address)
+	var in, out *big.Int
+	if strings.EqualFold(t.To, addr) {
+		in = hexWei(t.Value)
+	}
+	if strings.EqualFold(t.From, addr) {
+		out = hexWei(t.Value)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if s := a.get(addr); s != nil {
+		s.add(in, out)
+	}
+}
+
+// bucket returns the bucket of width at falls in, dropping the
+// buckets more than keep widths older than the latest. It returns
+// nil if at falls before them.
+func bucket(buckets map[int64]*flow, at time.Time, width time.Duration, keep int) *flow {
+	start := at.Truncate(width).Unix()
+	latest := start
+	for k := range buckets {
+		latest = max(latest, k)
+	}
+	cutoff := latest - int64(keep-1)*int64(width/time.Second)
+	if start < cutoff {
+		return nil
+	}
+	for k := range buckets {
+		if k < cutoff {
+			delete(buckets, k)
+		}
+	}
+	f, ok := buckets[start]
+	if !ok {
+		f = &flow{}
+		buckets[start] = f
+	}
+	return f
+}
+
+// stats returns the stats of address, false if none is recorded
+func (a *analytics) stats(address string, interval string, top int) (AddressStats, bool) {
+	addr := strings.ToLower(address)
+	if top <= 0 {
+		top = DefaultTopCounterparties
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	s, ok := a.addrs[addr]
+	if !ok {
+		return AddressStats{}, false
+	}
+	stats := AddressStats{
+		Address:        addr,
+		Transactions:   s.Transactions,
+		In:             weiString(s.In),
+		Out:            weiString(s.Out),
+		Net:            weiString(new(big.Int).Sub(orZero(s.In), orZero(s.Out))),
+		Fees:           weiString(s.Fees),
+		FirstBlock:     s.FirstBlock,
+		LastBlock:      s.LastBlock,
+		Recent:         s.recent(time.Now()),
+		Counterparties: []Counterparty{},
+		Interval:       interval,
+		Activity:       []ActivityBucket{},
+	}
+
+	stats.UniqueCounterparties = int64(len(s.Counterparties))
+	if s.Unique != nil {
+		stats.UniqueCounterparties = s.Unique.Count()
+	}
+	for k, c := range s.Counterparties {
+		stats.Counterparties = append(stats.Counterparties, Counterparty{
+			Address:      k,
+			Transactions: c.Transactions,
+			In:           weiString(c.In),
+			Out:          weiString(c.Out),
+		})
+	}
+	slices.SortFunc(stats.Counterparties, func(x, y Counterparty) int {
+		if n := cmp.Compare(y.Transactions, x.Transactions); n != 0 {
+			return n
+		}
+		return strings.Compare(x.Address, y.Address)
+	})
+	stats.Counterparties = stats.Counterparties[:min(top, len(stats.Counterparties))]
+
+	buckets := s.Days
+	if interval == IntervalHour {
+		buckets = s.Hours
+	}
+	for k, f := range buckets {
+		stats.Activity = append(stats.Activity, ActivityBucket{
+			Start:        time.Unix(k, 0).UTC(),
+			Transactions: f.Transactions,
+			In:           weiString(f.In),
+			Out:          weiString(f.Out),
+		})
+	}
+	slices.SortFunc(stats.Activity, func(x, y ActivityBucket) int {
+		return x.Start.Compare(y.Start)
+	})
+	return stats, true
+}
+
+// export returns the encoded analytics of every address
+func (a *analytics) export() (map[string]json.RawMessage, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	addrs := make(map[string]json.RawMessage, len(a.addrs))
+	for k, s := range a.addrs {
+		b, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+		addrs[k] = b
+	}
+	return addrs, nil
+}
+
+// counters returns the counters of a, by name in snapshots
+func (a *analytics) counters() map[string]any {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return map[string]any{"active": a.active}
+}
+
+// restoreCounters replaces the counters of a with those of b
+func (a *analytics) restoreCounters(b *analytics) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.active = b.active
+}
+
+// restore replaces the analytics of the addresses in addrs, as
+// many as there is room for
+func (a *analytics) restore(addrs map[string]*addressAnalytics) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for k, s := range addrs {
+		if _, ok := a.addrs[k]; ok || len(a.addrs) < a.max {
+			a.addrs[k] = s
+		}
+	}
+}
+
+// topAddresses returns up to n of the most active addresses
+func (a *analytics) topAddresses(n int) []ActiveAddress {
+	a.mu.RLock()
+	items := a.active.Top(n)
+	a.mu.RUnlock()
+	addrs := make([]ActiveAddress, len(items))
+	for i, item := range items {
+		addrs[i] = ActiveAddress{Address: item.Key, Transactions: item.Count, Error: item.Error}
+	}
+	return addrs
+}
+
+func (d *defaultParser) TopAddresses(n int) []ActiveAddress {
+	return d.analytics.topAddresses(n)
+}
+
+func (d *defaultParser) AddressStats(address string, interval string, top int) (AddressStats, bool) {
+	return d.analytics.stats(address, interval, top)
+}
+
+// hexWei parses a hex quantity, nil if it is not one
+func hexWei(v string) *big.Int {
+	n, ok := new(big.Int).SetString(strings.TrimPrefix(v, "0x"), 16)
+	if !ok {
+		return nil
+	}
+	return n
+}
+
+// addWei returns sum plus v, allocating sum if needed
+func addWei(sum, v *big.Int) *big.Int {
+	if v == nil {
+		return sum
+	}
+	if sum == nil {
+		sum = new(big.Int)
+	}
+	return sum.Add(sum, v)
+}
+
+func orZero(n *big.Int) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+	return n
+}
+
+// weiString formats n as a hex quantity, negative ones with a
+// leading minus
+func weiString(n *big.Int) string {
+	return fmt.Sprintf("%#x", orZero(n))
+}
@@ -0,0 +1,167 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParserAddressStats(t *testing.T) {
+	src := make(chan BlockTxn)
+	close(src)
+	p, err := NewParser(src, ParserConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := p.(*defaultParser)
+	ctx := context.Background()
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	d.processBlock(ctx, BlockTxn{
+		BlockNum: "100",
+		Time:     at,
+		Txns: []Transaction{
+			{Hash: "0x1", From: "0xa", To: "0xb", Value: "0x64", Block: "0x64", Receipt: &Receipt{Status: ReceiptStatusSuccess, Fee: "0x1"}},
+			{Hash: "0x2", From: "0xb", To: "0xa", Value: "0x10", Block: "0x64", Receipt: &Receipt{Status: ReceiptStatusSuccess, Fee: "0x2"}},
+			{Hash: "0x3", From: "0xa", To: "0xc", Value: "0x20", Block: "0x64", Receipt: &Receipt{Status: ReceiptStatusFailure, Fee: "0x3"}},
+		},
+	})
+	d.processBlock(ctx, BlockTxn{
+		BlockNum: "101",
+		Time:     at.Add(2 * time.Hour),
+		Txns: []Transaction{
+			{Hash: "0x4", From: "0xa", To: "0xa", Value: "0x8", Block: "0x65"},
+			{Hash: "0x5", From: "0xb", To: "0xd", Value: "0x1", Block: "0x65", Internal: []InternalTransfer{
+				{From: "0xd", To: "0xa", Value: "0x4"},
+				{From: "0xd", To: "0xa", Value: "0x40", Failed: true},
+			}},
+		},
+	})
+
+	stats, ok := d.AddressStats("0xA", IntervalHour, 1)
+	if !ok {
+		t.Fatal("expected stats for 0xa")
+	}
+	want := AddressStats{Address: "0xa", Transactions: 4, In: "0x1c", Out: "0x6c", Net: "-0x50", Fees: "0x4", FirstBlock: 100, LastBlock: 101}
+	if stats.Address != want.Address || stats.Transactions != want.Transactions || stats.In != want.In || stats.Out != want.Out ||
+		stats.Net != want.Net || stats.Fees != want.Fees || stats.FirstBlock != want.FirstBlock || stats.LastBlock != want.LastBlock {
+		t.Errorf("expected %+v; got %+v", want, stats)
+	}
+	if len(stats.Counterparties) != 1 || stats.Counterparties[0].Address != "0xb" || stats.Counterparties[0].Transactions != 2 {
+		t.Errorf("expected 0xb as top counterparty; got %+v", stats.Counterparties)
+	}
+	if len(stats.Activity) != 2 || stats.Activity[0].Transactions != 3 || !stats.Activity[1].Start.Equal(at.Truncate(time.Hour).Add(2*time.Hour)) {
+		t.Errorf("unexpected hourly activity %+v", stats.Activity)
+	}
+	if stats, _ := d.AddressStats("0xa", IntervalDay, 0); len(stats.Activity) != 1 || stats.Activity[0].Transactions != 4 {
+		t.Errorf("unexpected daily activity %+v", stats.Activity)
+	}
+	if _, ok := d.AddressStats("0xe", IntervalDay, 0); ok {
+		t.Error("expected no stats for 0xe")
+	}
+	// The blocks are too old for the last hour, day or week
+	if stats.Recent != (RecentActivity{}) || stats.UniqueCounterparties != 2 {
+		t.Errorf("unexpected recent activity %+v or %d unique counterparties", stats.Recent, stats.UniqueCounterparties)
+	}
+	d.processBlock(ctx, BlockTxn{
+		BlockNum: "102",
+		Time:     time.Now().Add(-2 * time.Hour),
+		Txns:     []Transaction{{Hash: "0x6", From: "0xa", To: "0xb", Value: "0x0", Block: "0x66"}},
+	})
+	if stats, _ := d.AddressStats("0xa", IntervalDay, 0); stats.Recent != (RecentActivity{LastHour: 0, LastDay: 1, LastWeek: 1}) {
+		t.Errorf("unexpected recent activity %+v", stats.Recent)
+	}
+	stats, _ = d.AddressStats("0xa", IntervalHour, 1)
+	if top := d.TopAddresses(2); len(top) != 2 || top[0] != (ActiveAddress{Address: "0xa", Transactions: 5}) || top[1].Address != "0xb" {
+		t.Errorf("unexpected top addresses %+v", top)
+	}
+
+	var buf bytes.Buffer
+	if err := d.ExportSnapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	dst := make(chan BlockTxn)
+	close(dst)
+	restored, err := NewParser(dst, ParserConfig{Snapshot: &buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzer := restored.(Analyzer)
+	if got, _ := analyzer.AddressStats("0xa", IntervalHour, 1); got.Net != stats.Net || len(got.Activity) != len(stats.Activity) ||
+		got.Recent != stats.Recent || got.UniqueCounterparties != stats.UniqueCounterparties {
+		t.Errorf("expected the stats restored; got %+v", got)
+	}
+	if got := analyzer.TopAddresses(1); len(got) != 1 || got[0].Address != "0xa" {
+		t.Errorf("expected the top addresses restored; got %+v", got)
+	}
+}
+
+func TestBucketRetention(t *testing.T) {
+	buckets := map[int64]*flow{}
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		bucket(buckets, at.Add(time.Duration(i)*time.Hour), time.Hour, 3)
+	}
+	if len(buckets) != 3 {
+		t.Errorf("expected 3 buckets kept; got %d", len(buckets))
+	}
+	if f := bucket(buckets, at, time.Hour, 3); f != nil {
+		t.Error("expected no bucket before the retention")
+	}
+}
+
+func TestCounterpartiesBounded(t *testing.T) {
+	a := newAnalytics(0)
+	a.recordTxn("0xa", Transaction{From: "0xa", To: "0xb"}, time.Time{})
+	a.recordTxn("0xa", Transaction{From: "0xa", To: "0xb"}, time.Time{})
+	for i := range maxCounterparties - 1 {
+		a.recordTxn("0xa", Transaction{From: "0xa", To: fmt.Sprintf("0x%x", i+0x100)}, time.Time{})
+	}
+	// The counterparties are counted exactly until one is dropped
+	if stats, _ := a.stats("0xa", IntervalDay, 1); a.addrs["0xa"].Unique != nil || stats.UniqueCounterparties != maxCounterparties {
+		t.Errorf("expected %d unique counterparties without a sketch; got %d", maxCounterparties, stats.UniqueCounterparties)
+	}
+	for i := maxCounterparties - 1; i < maxCounterparties+10; i++ {
+		a.recordTxn("0xa", Transaction{From: "0xa", To: fmt.Sprintf("0x%x", i+0x100)}, time.Time{})
+	}
+	a.recordTxn("0xa", Transaction{From: "0xa", To: "0xb"}, time.Time{})
+
+	stats, _ := a.stats("0xa", IntervalDay, 1)
+	if n := len(a.addrs["0xa"].Counterparties); n != maxCounterparties {
+		t.Errorf("expected %d counterparties kept; got %d", maxCounterparties, n)
+	}
+	if stats.Counterparties[0].Address != "0xb" {
+		t.Errorf("expected 0xb kept as top counterparty; got %+v", stats.Counterparties)
+	}
+	if n := stats.UniqueCounterparties; n < maxCounterparties || n > maxCounterparties+30 {
+		t.Errorf("expected about %d unique counterparties; got %d", maxCounterparties+11, n)
+	}
+}
+
+func TestRecentActivityManyAddresses(t *testing.T) {
+	a := newAnalytics(0)
+	now := time.Now()
+	a.recordTxn("0xa", Transaction{From: "0xa", To: "0xb"}, now.Add(-2*time.Hour))
+	// Every address keeps its windows however many are active
+	for i := range 20000 {
+		a.recordTxn(fmt.Sprintf("0x%x", i+0x100), Transaction{From: "0xc", To: "0xd"}, now)
+	}
+	stats, _ := a.stats("0xa", IntervalDay, 0)
+	if stats.Recent != (RecentActivity{LastDay: 1, LastWeek: 1}) {
+		t.Errorf("unexpected recent activity %+v", stats.Recent)
+	}
+}
+
+func TestAnalyzedAddressesBounded(t *testing.T) {
+	a := newAnalytics(2)
+	for _, addr := range []string{"0xa", "0xb", "0xc", "0xc"} {
+		a.recordTxn(addr, Transaction{From: addr, To: "0xd"}, time.Time{})
+	}
+	if _, ok := a.stats("0xc", IntervalDay, 0); ok || len(a.addrs) != 2 {
+		t.Errorf("expected 2 addresses analyzed; got %d", len(a.addrs))
+	}
+	if top := a.topAddresses(1); len(top) != 1 || top[0].Address != "0xc" {
+		t.Errorf("expected 0xc still counted as most active; got %+v", top)
+	}
+}
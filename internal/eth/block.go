@@ -1,6 +1,13 @@
 package eth
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -11,10 +18,13 @@ type LatestParseBlock interface {
 	Get() string
 }
 
+// NoBlock is the latest block before any block is parsed
+const NoBlock = "-1"
+
 // NewLatestParseBlock instantiate a parsed block counter
 func NewLatestParseBlock() LatestParseBlock {
 	return &latestBlock{
-		block: "-1",
+		block: NoBlock,
 	}
 }
 
@@ -30,5 +40,50 @@ func (l *latestBlock) Update(block string) {
 }
 
 func (l *latestBlock) Get() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	return l.block
 }
+
+// compareBlocks compares two decimal block numbers, such as
+// NoBlock and BlockTxn.BlockNum, numerically when both parse
+func compareBlocks(a, b string) int {
+	x, errA := strconv.ParseInt(a, 10, 64)
+	y, errB := strconv.ParseInt(b, 10, 64)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return cmp.Compare(x, y)
+}
+
+var ErrBlockNotProcessed = errors.New("block not processed")
+
+// BlockGetter is implemented by parsers that read the blocks they
+// processed back from the node
+type BlockGetter interface {
+	// GetBlock returns a processed block with the transactions
+	// of the addresses indexed
+	GetBlock(ctx context.Context, number int64) (BlockTxn, error)
+}
+
+func (d *defaultParser) GetBlock(ctx context.Context, number int64) (BlockTxn, error) {
+	if d.blocks == nil {
+		return BlockTxn{}, ErrNoBlockReader
+	}
+	latest, err := strconv.ParseInt(d.latestBlock.Get(), 10, 64)
+	if err != nil || number < 0 || number > latest {
+		return BlockTxn{}, fmt.Errorf("%w-%d", ErrBlockNotProcessed, number)
+	}
+	b, err := d.blocks(ctx, big.NewInt(number))
+	if err != nil {
+		return BlockTxn{}, err
+	}
+	txns := []Transaction{}
+	for _, tx := range b.Txns {
+		if d.indexed(tx.From) || (tx.To != "" && d.indexed(tx.To)) {
+			txns = append(txns, tx)
+		}
+	}
+	b.Txns = txns
+	return b, nil
+}
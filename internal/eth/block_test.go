@@ -0,0 +1,48 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestGetBlock(t *testing.T) {
+	block := BlockTxn{BlockNum: "1", Hash: "0xb1", Txns: []Transaction{
+		{Hash: "0x1", From: watchAlice, To: watchBob},
+		{Hash: "0x2", From: watchBob, To: watchCarol},
+	}}
+	watchlist, _ := NewWatchlist("")
+	watchlist.Add(watchAlice)
+	blocks := make(chan BlockTxn)
+	defer close(blocks)
+	p, _ := NewParser(blocks, ParserConfig{
+		Watchlist: watchlist,
+		Blocks: func(ctx context.Context, number *big.Int) (BlockTxn, error) {
+			return block, nil
+		},
+	})
+	g := p.(BlockGetter)
+
+	if _, err := g.GetBlock(context.Background(), 1); !errors.Is(err, ErrBlockNotProcessed) {
+		t.Errorf("expected %v before the block is processed; got %v", ErrBlockNotProcessed, err)
+	}
+	blocks <- block
+	deadline := time.Now().Add(time.Second)
+	for p.GetCurrentBlock() != "1" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	got, err := g.GetBlock(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the transactions of watched addresses are returned
+	if got.Hash != "0xb1" || len(got.Txns) != 1 || got.Txns[0].Hash != "0x1" {
+		t.Errorf("expected the transaction of alice; got %+v", got)
+	}
+	if len(block.Txns) != 2 {
+		t.Error("expected the block read left untouched")
+	}
+}
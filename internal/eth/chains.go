@@ -0,0 +1,15 @@
+package eth
+
+import (
+	"maps"
+	"slices"
+)
+
+// Chains holds a parser per ingested chain, keyed by chain ID.
+// Each parser has its own storage, counters and subscriptions.
+type Chains map[int64]Parser
+
+// IDs returns the chain IDs in ascending order
+func (c Chains) IDs() []int64 {
+	return slices.Sorted(maps.Keys(c))
+}
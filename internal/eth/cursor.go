@@ -0,0 +1,77 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Cursor is the position of a transaction in the chain. It
+// orders published events and is rendered as the event ID
+// "<block>-<index>" in decimal.
+type Cursor struct {
+	Block uint64
+	Index uint64
+}
+
+// String returns the event ID of the cursor
+func (c Cursor) String() string {
+	return fmt.Sprintf("%d-%d", c.Block, c.Index)
+}
+
+// Less reports whether c comes before o
+func (c Cursor) Less(o Cursor) bool {
+	if c.Block != o.Block {
+		return c.Block < o.Block
+	}
+	return c.Index < o.Index
+}
+
+// ParseCursor parses an event ID of the form "<block>-<index>".
+// A bare block number is accepted and points at the first
+// transaction of that block.
+func ParseCursor(s string) (Cursor, error) {
+	blk, idx, found := strings.Cut(s, "-")
+	block, err := strconv.ParseUint(blk, 10, 64)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w-%s", ErrInvalidCursor, s)
+	}
+	if !found {
+		return Cursor{Block: block}, nil
+	}
+	index, err := strconv.ParseUint(idx, 10, 64)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w-%s", ErrInvalidCursor, s)
+	}
+	return Cursor{Block: block, Index: index}, nil
+}
+
+// TxCursor returns the cursor of a mined transaction. It
+// returns false if the block number or index is missing.
+func TxCursor(tx Transaction) (Cursor, bool) {
+	block, ok := hexToUint64(tx.Block)
+	if !ok {
+		return Cursor{}, false
+	}
+	index, ok := hexToUint64(tx.TransactionIndex)
+	if !ok {
+		return Cursor{}, false
+	}
+	return Cursor{Block: block, Index: index}, true
+}
+
+func hexToUint64(s string) (uint64, bool) {
+	if !strings.HasPrefix(s, "0x") || len(s) < 3 {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(s[2:], 16, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
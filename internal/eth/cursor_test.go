@@ -0,0 +1,37 @@
+package eth
+
+import "testing"
+
+func TestCursor(t *testing.T) {
+	testcases := []struct {
+		input   string
+		want    Cursor
+		wantErr bool
+	}{
+		{"21000000-7", Cursor{Block: 21000000, Index: 7}, false},
+		{"21000000", Cursor{Block: 21000000}, false},
+		{"0x10-1", Cursor{}, true},
+		{"10-", Cursor{}, true},
+		{"", Cursor{}, true},
+	}
+	for _, tc := range testcases {
+		got, err := ParseCursor(tc.input)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%q: unexpected error %v", tc.input, err)
+		}
+		if got != tc.want {
+			t.Errorf("%q: expected %v; got %v", tc.input, tc.want, got)
+		}
+	}
+
+	c, ok := TxCursor(Transaction{Block: "0x140", TransactionIndex: "0xa"})
+	if !ok || c.String() != "320-10" {
+		t.Errorf("expected 320-10; got %v %v", c, ok)
+	}
+	if _, ok := TxCursor(Transaction{Block: "0x140"}); ok {
+		t.Errorf("expected no cursor without transaction index")
+	}
+	if !(Cursor{1, 9}).Less(Cursor{2, 0}) || (Cursor{2, 1}).Less(Cursor{2, 1}) {
+		t.Errorf("unexpected cursor ordering")
+	}
+}
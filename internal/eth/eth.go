@@ -2,8 +2,16 @@ package eth
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/metrics"
+	"paulwizviz/go-eth-app/internal/tracing"
+	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Transaction is a structure for storing transaction data
@@ -13,67 +21,276 @@ type Transaction struct {
 	From                 string `json:"from"`
 	To                   string `json:"to"`
 	Input                string `json:"input"`
+	Nonce                string `json:"nonce"`
 	Value                string `json:"value"`
 	Block                string `json:"blockNumber"`
+	TransactionIndex     string `json:"transactionIndex"`
 	Type                 string `json:"type"`
 	Gas                  string `json:"gas"`
 	GasPrice             string `json:"gasPrice"`
 	MaxFeePerGas         string `json:"maxFeePerGas"`
 	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"`
+
+	// Receipt is set when receipts are fetched
+	Receipt *Receipt `json:"receipt,omitempty"`
+	// Internal is set when blocks are traced
+	Internal []InternalTransfer `json:"internal,omitempty"`
 }
 
 // Block is a representation of a block from Ethereum
 // node
 type Block struct {
-	Number       string        `json:"number"`
+	Number string `json:"number"`
+	Hash   string `json:"hash"`
+	// Timestamp is when the block was produced, in seconds
+	// since the epoch
+	Timestamp    string        `json:"timestamp"`
 	Transactions []Transaction `json:"transactions"`
 }
 
+// Time returns the timestamp of b, zero if it is not set
+func (b Block) Time() time.Time {
+	s, ok := new(big.Int).SetString(strings.TrimPrefix(b.Timestamp, "0x"), 16)
+	if !ok || !s.IsInt64() {
+		return time.Time{}
+	}
+	return time.Unix(s.Int64(), 0).UTC()
+}
+
 // BlockTxn is a replication of Block but
 // replaced with the field BlockNum to make
 // it easier to map for downstream operation.
 type BlockTxn struct {
+	// ChainID is the chain the block was read from, zero if
+	// unknown
+	ChainID  int64
 	BlockNum string
-	Txns     []Transaction
+	// Hash is empty unless read by ReadNetwork or a BlockReader
+	Hash string
+	// Time is when the block was produced, zero if unknown
+	Time time.Time
+	Txns []Transaction
+	// Tokens holds the metadata of the tokens transferred in
+	// the block, when receipts are fetched
+	Tokens map[string]TokenMetadata
+
+	// span is the ingestion span the block was read under,
+	// continued by the parser
+	span trace.SpanContext
+}
+
+// DefaultPollInterval is how often ReadNetwork polls the
+// node for the latest block
+const DefaultPollInterval = 5 * time.Second
+
+// DefaultMaxBlocksPerPoll is the number of blocks ReadNetwork
+// reads at most on a poll to catch up with the head
+const DefaultMaxBlocksPerPoll = 64
+
+// Finality rules, the block tag ReadNetworkWithConfig follows
+const (
+	FinalityLatest    = "latest"
+	FinalitySafe      = "safe"
+	FinalityFinalized = "finalized"
+)
+
+// NetworkConfig holds the optional settings of ReadNetworkWithConfig
+type NetworkConfig struct {
+	// ChainID labels the blocks and metrics of the network
+	ChainID int64
+	// Finality is the block tag followed, FinalityLatest if empty
+	Finality string
+	// Confirmations is the number of blocks kept behind the
+	// followed head
+	Confirmations int64
+	// Receipts attaches the receipt of every transaction, and
+	// resolves the metadata of the tokens they transfer
+	Receipts bool
+	// Traces attaches the internal transfers of every
+	// transaction, read with debug_traceBlockByNumber
+	Traces bool
+	// PollInterval defaults to DefaultPollInterval
+	PollInterval time.Duration
+	// MaxBlocksPerPoll caps the blocks read on a poll when the
+	// followed head moved by several, e.g. by an epoch when
+	// following the finalized head; DefaultMaxBlocksPerPoll if
+	// zero. The blocks left are read on the next polls.
+	MaxBlocksPerPoll int64
+	// Timeout of a JSON-RPC request, none if zero
+	Timeout time.Duration
+	// Logger receives the ingestion logs; nil discards them
+	Logger *slog.Logger
 }
 
 // ReadNetwork is an operation to read data from
 // the Ethereum network and ensure data is channelled
-// to receiver.
+// to receiver. The channel is closed once c is done.
 func ReadNetwork(c context.Context, url string) chan BlockTxn {
+	return ReadNetworkWithConfig(c, url, NetworkConfig{})
+}
+
+// ReadNetworkWithConfig is ReadNetwork with the given settings
+func ReadNetworkWithConfig(c context.Context, url string, cfg NetworkConfig) chan BlockTxn {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.MaxBlocksPerPoll <= 0 {
+		cfg.MaxBlocksPerPoll = DefaultMaxBlocksPerPoll
+	}
+	in := newIngester(url, cfg)
+
 	ch := make(chan BlockTxn, 1)
-	ticker := time.NewTicker(5000 * time.Millisecond)
+	ticker := time.NewTicker(cfg.PollInterval)
 	go func(ch chan BlockTxn) {
+		defer close(ch)
+		defer ticker.Stop()
+		in.poll(c, ch)
 		for {
 			select {
 			case <-ticker.C:
-				getLatestBlock(ch, url)
+				in.poll(c, ch)
 			case <-c.Done():
 				return
 			}
 		}
 	}(ch)
 
-	getLatestBlock(ch, url)
-
 	return ch
 }
 
-func getLatestBlock(ch chan BlockTxn, url string) {
-	log.Println("Getting latest block...")
-	bt := BlockTxn{}
-	blockNumber, err := currentBlock(url)
+// BlockReader reads a block by number
+type BlockReader func(ctx context.Context, number *big.Int) (BlockTxn, error)
+
+// NewBlockReader returns a BlockReader of the node at url. Blocks
+// are read as ReadNetworkWithConfig reads them, with receipts and
+// traces as cfg sets.
+func NewBlockReader(url string, cfg NetworkConfig) BlockReader {
+	return newIngester(url, cfg).readBlock
+}
+
+// ingester reads blocks from a node
+type ingester struct {
+	client   *http.Client
+	url      string
+	cfg      NetworkConfig
+	logger   *slog.Logger
+	receipts *receiptFetcher // nil unless cfg.Receipts
+	tokens   *tokenResolver  // nil unless cfg.Receipts
+	traces   *traceFetcher   // nil unless cfg.Traces
+	// next is the block to read on the next poll, negative
+	// until the first
+	next int64
+}
+
+func newIngester(url string, cfg NetworkConfig) *ingester {
+	client := &http.Client{Timeout: cfg.Timeout}
+	in := &ingester{
+		client: client,
+		url:    url,
+		cfg:    cfg,
+		logger: orDiscard(cfg.Logger).With("chain", cfg.ChainID),
+		next:   -1,
+	}
+	if cfg.Receipts {
+		in.receipts = newReceiptFetcher(client, url)
+		in.tokens = newTokenResolver(client, url)
+	}
+	if cfg.Traces {
+		in.traces = newTraceFetcher(client, url)
+	}
+	return in
+}
+
+// poll reads the blocks from the last one read up to the followed
+// head, less the confirmations, starting at that head on the first
+// poll. A block that cannot be read is retried on the next poll.
+func (in *ingester) poll(ctx context.Context, ch chan BlockTxn) {
+	cfg, logger := in.cfg, in.logger
+	logger.Debug("getting latest block")
+	head, err := headBlock(ctx, in.client, in.url, cfg.Finality)
 	if err != nil {
-		log.Println(err)
+		logger.Error("get latest block number", "finality", cfg.Finality, "err", err)
 		return
 	}
-	bt.BlockNum = blockNumber.String()
-	log.Printf("Got block %s", bt.BlockNum)
-	txns, err := getBlockTransactions(url, blockNumber)
-	if err != nil {
-		log.Println(err)
+	metrics.SetChainHead(cfg.ChainID, head.Int64())
+	target := head.Int64() - cfg.Confirmations
+	if target < 0 {
+		logger.Debug("chain shorter than confirmations", "confirmations", cfg.Confirmations)
 		return
 	}
+	if in.next < 0 {
+		in.next = target
+	}
+	last := min(target, in.next+cfg.MaxBlocksPerPoll-1)
+	if last < target {
+		logger.Info("catching up with the head", "from", in.next, "to", last, "head", target)
+	}
+	for ; in.next <= last; in.next++ {
+		if !in.ingestBlock(ctx, ch, in.next) {
+			return
+		}
+	}
+}
+
+// ingestBlock reads a block and sends it on ch, reporting whether
+// it did
+func (in *ingester) ingestBlock(ctx context.Context, ch chan BlockTxn, number int64) bool {
+	ctx, span := tracer.Start(ctx, "eth.ingestBlock", trace.WithNewRoot(), trace.WithAttributes(
+		attribute.Int64("eth.chain", in.cfg.ChainID),
+		attribute.Int64("eth.block", number),
+	))
+	var err error
+	defer func() { tracing.End(span, err) }()
+
+	block, err := in.readBlock(ctx, big.NewInt(number))
+	if err != nil {
+		in.logger.Error("get block transactions", "method", methodBlockByNumber, "block", number, "err", err)
+		return false
+	}
+	block.span = span.SpanContext()
+	in.logger.Debug("got block", "block", block.BlockNum, "transactions", len(block.Txns))
+	select {
+	case ch <- block:
+		return true
+	case <-ctx.Done():
+		err = ctx.Err()
+		return false
+	}
+}
+
+// readBlock reads the transactions of a block, with their receipts
+// and traces when configured. A block whose receipts or traces
+// cannot be read is returned without them.
+func (in *ingester) readBlock(ctx context.Context, blockNumber *big.Int) (BlockTxn, error) {
+	bt := BlockTxn{ChainID: in.cfg.ChainID, BlockNum: blockNumber.String()}
+	block, err := getBlock(ctx, in.client, in.url, blockNumber)
+	if err != nil {
+		return BlockTxn{}, err
+	}
+	bt.Hash = block.Hash
+	bt.Time = block.Time()
+	txns := block.Transactions
+	if in.receipts != nil {
+		if err := in.receipts.attach(ctx, blockNumber, txns); err != nil {
+			in.logger.Warn("get block receipts", "block", bt.BlockNum, "err", err)
+		} else {
+			bt.Tokens = in.tokens.resolve(ctx, transferTokens(txns))
+		}
+	}
+	if in.traces != nil {
+		if err := in.traces.attach(ctx, blockNumber, txns); err != nil {
+			in.logger.Warn("trace block", "method", methodTraceBlock, "block", bt.BlockNum, "err", err)
+		}
+	}
 	bt.Txns = txns
-	ch <- bt
+	return bt, nil
+}
+
+// orDiscard returns logger, or a logger discarding every record
+// if it is nil
+func orDiscard(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return logger
 }
@@ -0,0 +1,98 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadNetworkFinality(t *testing.T) {
+	requested := make(chan []any, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		var result any
+		switch req.Params[0] {
+		case FinalityFinalized:
+			result = map[string]any{"number": "0x64", "transactions": []string{"0xaa"}}
+		default:
+			requested <- req.Params
+			result = map[string]any{"number": req.Params[0], "transactions": []Transaction{{Hash: "0x1"}}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := ReadNetworkWithConfig(ctx, srv.URL, NetworkConfig{
+		ChainID:       8453,
+		Finality:      FinalityFinalized,
+		Confirmations: 2,
+		PollInterval:  time.Hour,
+	})
+	select {
+	case b := <-ch:
+		if b.ChainID != 8453 || b.BlockNum != "98" || len(b.Txns) != 1 {
+			t.Errorf("expected block 98 of chain 8453; got %+v", b)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a block")
+	}
+	if params := <-requested; params[0] != "0x62" || params[1] != true {
+		t.Errorf("expected the block two behind the finalized head; got %v", params)
+	}
+}
+
+func TestReadNetworkCatchUp(t *testing.T) {
+	var head atomic.Int64
+	head.Store(100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		var result any = fmt.Sprintf("0x%x", head.Load())
+		if req.Method == methodBlockByNumber {
+			result = map[string]any{"number": req.Params[0], "transactions": []Transaction{}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := ReadNetworkWithConfig(ctx, srv.URL, NetworkConfig{
+		PollInterval:     10 * time.Millisecond,
+		MaxBlocksPerPoll: 3,
+	})
+	next := func() string {
+		select {
+		case b := <-ch:
+			return b.BlockNum
+		case <-time.After(time.Second):
+			t.Fatal("expected a block")
+			return ""
+		}
+	}
+	if got := next(); got != "100" {
+		t.Fatalf("expected the head first; got %s", got)
+	}
+	// The head moving by several blocks reads every one, a few
+	// per poll
+	head.Store(107)
+	for want := 101; want <= 107; want++ {
+		if got := next(); got != fmt.Sprint(want) {
+			t.Fatalf("expected block %d; got %s", want, got)
+		}
+	}
+}
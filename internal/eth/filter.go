@@ -0,0 +1,217 @@
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"paulwizviz/go-eth-app/internal/observer"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInvalidChecksum = errors.New("invalid EIP-55 checksum")
+	ErrInvalidMinValue = errors.New("invalid minValue")
+	ErrInvalidSelector = errors.New("invalid selector")
+	ErrInvalidCreation = errors.New("invalid creation")
+	ErrInvalidType     = errors.New("invalid type")
+)
+
+var (
+	addressPattern  = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	selectorPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{8}$`)
+	typePattern     = regexp.MustCompile(`^0x[0-9a-fA-F]+$`)
+)
+
+// Filter selects transactions for a subscription. Every
+// field that is set must match; a zero Filter matches
+// all transactions.
+type Filter struct {
+	// Addresses matches transactions sent from or to any
+	// of the addresses. Empty matches all addresses.
+	Addresses []string `json:"addresses,omitempty"`
+	// MinValue matches transactions with a value strictly
+	// above it, in wei.
+	MinValue *big.Int `json:"minValue,omitempty"`
+	// Selector matches transactions whose input starts with
+	// the 4-byte function selector, e.g. 0xa9059cbb.
+	Selector string `json:"selector,omitempty"`
+	// ContractCreation matches transactions without a
+	// recipient.
+	ContractCreation bool `json:"contractCreation,omitempty"`
+	// Type matches the transaction type, e.g. 0x2.
+	Type string `json:"type,omitempty"`
+}
+
+// Match reports whether tx is selected by the filter
+func (f Filter) Match(tx Transaction) bool {
+	if len(f.Addresses) > 0 {
+		found := false
+		for _, addr := range f.Addresses {
+			if strings.EqualFold(addr, tx.From) || strings.EqualFold(addr, tx.To) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.MinValue != nil {
+		value, ok := hexToBig(tx.Value)
+		if !ok || value.Cmp(f.MinValue) <= 0 {
+			return false
+		}
+	}
+	if f.Selector != "" {
+		if len(tx.Input) < 10 || !strings.EqualFold(tx.Input[:10], f.Selector) {
+			return false
+		}
+	}
+	if f.ContractCreation && tx.To != "" {
+		return false
+	}
+	if f.Type != "" && !strings.EqualFold(f.Type, tx.Type) {
+		return false
+	}
+	return true
+}
+
+// topics returns the observer topics covering the filter
+func (f Filter) topics() []string {
+	if len(f.Addresses) == 0 {
+		return []string{observer.Wildcard}
+	}
+	topics := make([]string, 0, len(f.Addresses))
+	for _, addr := range f.Addresses {
+		topics = append(topics, strings.ToLower(addr))
+	}
+	return topics
+}
+
+// observerFilter returns the predicate applied by the
+// observer, or nil if the topics alone are sufficient
+func (f Filter) observerFilter() observer.Filter {
+	if f.MinValue == nil && f.Selector == "" && !f.ContractCreation && f.Type == "" {
+		return nil
+	}
+	return func(msg []byte) bool {
+		var tx Transaction
+		if err := json.Unmarshal(msg, &tx); err != nil {
+			return false
+		}
+		return f.Match(tx)
+	}
+}
+
+func hexToBig(s string) (*big.Int, bool) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if s == "" {
+		return big.NewInt(0), true
+	}
+	return new(big.Int).SetString(s, 16)
+}
+
+// NormalizeAddress validates a hex address and returns it in
+// its EIP-55 checksum form. All-lowercase and all-uppercase
+// addresses are accepted as is; mixed case must carry a valid
+// checksum.
+func NormalizeAddress(s string) (string, error) {
+	if !addressPattern.MatchString(s) {
+		return "", fmt.Errorf("%w-%s", ErrInvalidAddress, s)
+	}
+	checksum := common.HexToAddress(s).Hex()
+	digits := s[2:]
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && s != checksum {
+		return "", fmt.Errorf("%w-%s", ErrInvalidChecksum, s)
+	}
+	return checksum, nil
+}
+
+// ParseFilter builds a transaction filter from query parameters:
+//
+//	address  - repeatable; matches sender or recipient
+//	minValue - wei, decimal or 0x prefixed hex; matches values above it
+//	selector - 4-byte function selector, e.g. 0xa9059cbb
+//	creation - true to match contract creations only
+//	type     - transaction type, e.g. 0x2
+func ParseFilter(q url.Values) (Filter, error) {
+	var f Filter
+
+	for _, addr := range q["address"] {
+		for _, a := range strings.Split(addr, ",") {
+			checksum, err := NormalizeAddress(a)
+			if err != nil {
+				return Filter{}, err
+			}
+			f.Addresses = append(f.Addresses, strings.ToLower(checksum))
+		}
+	}
+
+	if v := q.Get("minValue"); v != "" {
+		value, ok := new(big.Int).SetString(v, 0)
+		if !ok || value.Sign() < 0 {
+			return Filter{}, fmt.Errorf("%w-%s", ErrInvalidMinValue, v)
+		}
+		f.MinValue = value
+	}
+
+	if v := q.Get("selector"); v != "" {
+		if !selectorPattern.MatchString(v) {
+			return Filter{}, fmt.Errorf("%w-%s", ErrInvalidSelector, v)
+		}
+		f.Selector = strings.ToLower(v)
+	}
+
+	if v := q.Get("creation"); v != "" {
+		creation, err := strconv.ParseBool(v)
+		if err != nil {
+			return Filter{}, fmt.Errorf("%w-%s", ErrInvalidCreation, v)
+		}
+		f.ContractCreation = creation
+	}
+
+	if v := q.Get("type"); v != "" {
+		if !typePattern.MatchString(v) {
+			return Filter{}, fmt.Errorf("%w-%s", ErrInvalidType, v)
+		}
+		f.Type = strings.ToLower(v)
+	}
+
+	return f, nil
+}
+
+// FilterSpec is the JSON form of a filter used by the WebSocket,
+// webhook, GraphQL and gRPC APIs. Its fields mirror the query
+// parameters accepted by ParseFilter.
+type FilterSpec struct {
+	Addresses []string `json:"addresses,omitempty"`
+	MinValue  string   `json:"minValue,omitempty"`
+	Selector  string   `json:"selector,omitempty"`
+	Creation  bool     `json:"creation,omitempty"`
+	Type      string   `json:"type,omitempty"`
+}
+
+// Parse validates the spec and returns the filter
+func (f FilterSpec) Parse() (Filter, error) {
+	q := url.Values{}
+	q["address"] = f.Addresses
+	if f.MinValue != "" {
+		q.Set("minValue", f.MinValue)
+	}
+	if f.Selector != "" {
+		q.Set("selector", f.Selector)
+	}
+	if f.Creation {
+		q.Set("creation", strconv.FormatBool(f.Creation))
+	}
+	if f.Type != "" {
+		q.Set("type", f.Type)
+	}
+	return ParseFilter(q)
+}
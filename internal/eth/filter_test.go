@@ -0,0 +1,73 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	transfer := Transaction{
+		From:  "0xaaaa",
+		To:    "0xbbbb",
+		Value: "0xde0b6b3a7640000", // 1 ether
+		Input: "0xa9059cbb0000",
+		Type:  "0x2",
+	}
+	creation := Transaction{From: "0xaaaa", Value: "0x0", Input: "0x6080", Type: "0x0"}
+
+	testcases := []struct {
+		name   string
+		filter Filter
+		tx     Transaction
+		want   bool
+	}{
+		{"zero filter", Filter{}, transfer, true},
+		{"address to", Filter{Addresses: []string{"0xcccc", "0xBBBB"}}, transfer, true},
+		{"address miss", Filter{Addresses: []string{"0xcccc"}}, transfer, false},
+		{"value above", Filter{MinValue: big.NewInt(1)}, transfer, true},
+		{"value not above", Filter{MinValue: big.NewInt(1_000_000_000_000_000_000)}, transfer, false},
+		{"selector", Filter{Selector: "0xA9059CBB"}, transfer, true},
+		{"selector miss", Filter{Selector: "0x095ea7b3"}, transfer, false},
+		{"selector short input", Filter{Selector: "0xa9059cbb"}, creation, false},
+		{"creation", Filter{ContractCreation: true}, creation, true},
+		{"creation miss", Filter{ContractCreation: true}, transfer, false},
+		{"type", Filter{Type: "0x2"}, transfer, true},
+		{"type miss", Filter{Type: "0x2"}, creation, false},
+	}
+
+	for _, tc := range testcases {
+		if got := tc.filter.Match(tc.tx); got != tc.want {
+			t.Errorf("%s: expected %v; got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestFilterSpecParse(t *testing.T) {
+	testcases := []struct {
+		name string
+		spec FilterSpec
+		want error
+	}{
+		{"lower case", FilterSpec{Addresses: []string{"0x00000000000000000000000000000000000000aa"}, MinValue: "0x10", Selector: "0xA9059CBB"}, nil},
+		{"checksum", FilterSpec{Addresses: []string{"0x52908400098527886E0F7030069857D2E4169EE7"}}, nil},
+		{"bad checksum", FilterSpec{Addresses: []string{"0x00000000000000000000000000000000000000aA"}}, ErrInvalidChecksum},
+		{"short address", FilterSpec{Addresses: []string{"0xaa"}}, ErrInvalidAddress},
+		{"negative value", FilterSpec{MinValue: "-1"}, ErrInvalidMinValue},
+		{"bad selector", FilterSpec{Selector: "0xa9"}, ErrInvalidSelector},
+		{"bad type", FilterSpec{Type: "2"}, ErrInvalidType},
+	}
+	for _, tc := range testcases {
+		f, err := tc.spec.Parse()
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v; got %v", tc.name, tc.want, err)
+		}
+		if err == nil && len(tc.spec.Addresses) > 0 && f.Addresses[0] != strings.ToLower(tc.spec.Addresses[0]) {
+			t.Errorf("%s: expected the address in lower case; got %v", tc.name, f.Addresses)
+		}
+	}
+	if f, _ := (FilterSpec{MinValue: "0x10", Selector: "0xA9059CBB"}).Parse(); f.MinValue.Int64() != 16 || f.Selector != "0xa9059cbb" {
+		t.Errorf("unexpected filter %+v", f)
+	}
+}
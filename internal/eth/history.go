@@ -0,0 +1,473 @@
+package eth
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"paulwizviz/go-eth-app/internal/metrics"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Range indexing job states
+const (
+	RangeRunning = "running"
+	RangeDone    = "done"
+	RangeFailed  = "failed"
+)
+
+// Range indexing defaults
+const (
+	DefaultRangeWorkers     = 4
+	DefaultRangeLogInterval = 10 * time.Second
+	// rangeAttempts is the number of reads of a block before the
+	// job fails
+	rangeAttempts = 3
+)
+
+var (
+	ErrRangeRunning      = errors.New("range indexing job already running")
+	ErrLoadRangeProgress = errors.New("load range progress error")
+	ErrSaveRangeProgress = errors.New("save range progress error")
+	ErrRangeIndexing     = errors.New("range indexing error")
+)
+
+// BlockRange is an inclusive range of block numbers
+type BlockRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// RangeConfig holds the settings of a range indexing job
+type RangeConfig struct {
+	// From and To are the inclusive range of blocks indexed. To
+	// is capped at, and defaults to, the latest block processed
+	// when the job starts; later blocks are left to ingestion.
+	From, To int64
+	// Last, if set, indexes the Last blocks up to To instead of
+	// starting at From
+	Last int64
+	// Workers read blocks in parallel, DefaultRangeWorkers if
+	// zero
+	Workers int
+	// Progress, if set, is the file the progress is kept in. A
+	// job that did not finish is resumed over its range, from its
+	// saved Next.
+	Progress string
+	// LogInterval defaults to DefaultRangeLogInterval
+	LogInterval time.Duration
+}
+
+// RangeProgress reports a range indexing job
+type RangeProgress struct {
+	State string `json:"state"`
+	From  int64  `json:"from"`
+	To    int64  `json:"to"`
+	// Next is the lowest block not yet indexed; the job resumes
+	// from it
+	Next int64 `json:"next"`
+	// Indexed counts the blocks indexed, Skipped those already
+	// indexed by ingestion
+	Indexed int64 `json:"indexed"`
+	Skipped int64 `json:"skipped"`
+	Total   int64 `json:"total"`
+	// Rate is the blocks per second done since the job started,
+	// and ETA the time left at that rate
+	Rate      float64       `json:"rate"`
+	ETA       time.Duration `json:"-"`
+	StartedAt time.Time     `json:"startedAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// Indexer is implemented by parsers that index block ranges
+// alongside ingestion
+type Indexer interface {
+	// IndexRange indexes a range of blocks, each at most once
+	// with ingestion, and returns once it is done
+	IndexRange(ctx context.Context, cfg RangeConfig) error
+	// RangeProgress returns the progress of the latest job, false
+	// if none ran
+	RangeProgress() (RangeProgress, bool)
+}
+
+// blockSet holds the blocks indexed as sorted, disjoint ranges,
+// so contiguous blocks cost one entry
+type blockSet struct {
+	mu     sync.Mutex
+	ranges []BlockRange
+}
+
+// search returns the index of the range holding n, or where a
+// range holding it would be inserted. The lock must be held.
+func (s *blockSet) search(n int64) (int, bool) {
+	return slices.BinarySearchFunc(s.ranges, n, func(r BlockRange, n int64) int {
+		switch {
+		case r.To < n:
+			return -1
+		case r.From > n:
+			return 1
+		}
+		return 0
+	})
+}
+
+// claim adds n and reports whether it was not already in the set
+func (s *blockSet) claim(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.search(n)
+	if found {
+		return false
+	}
+	// ranges[i-1] ends before n and ranges[i] starts after it
+	joinPrev := i > 0 && s.ranges[i-1].To == n-1
+	joinNext := i < len(s.ranges) && s.ranges[i].From == n+1
+	switch {
+	case joinPrev && joinNext:
+		s.ranges[i-1].To = s.ranges[i].To
+		s.ranges = slices.Delete(s.ranges, i, i+1)
+	case joinPrev:
+		s.ranges[i-1].To = n
+	case joinNext:
+		s.ranges[i].From = n
+	default:
+		s.ranges = slices.Insert(s.ranges, i, BlockRange{From: n, To: n})
+	}
+	return true
+}
+
+func (s *blockSet) has(n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, found := s.search(n)
+	return found
+}
+
+func (s *blockSet) list() []BlockRange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.ranges)
+}
+
+// add merges ranges into the set
+func (s *blockSet) add(ranges []BlockRange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all := append(slices.Clone(s.ranges), ranges...)
+	slices.SortFunc(all, func(a, b BlockRange) int { return cmp.Compare(a.From, b.From) })
+	merged := make([]BlockRange, 0, len(all))
+	for _, r := range all {
+		if r.To < r.From {
+			continue
+		}
+		if last := len(merged) - 1; last >= 0 && r.From <= merged[last].To+1 {
+			merged[last].To = max(merged[last].To, r.To)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	s.ranges = merged
+}
+
+// rangeJob is the state of a running range indexing job
+type rangeJob struct {
+	mu       sync.Mutex
+	progress RangeProgress
+	// done holds the blocks finished above progress.Next
+	done    map[int64]bool
+	resumed int64
+}
+
+func (d *defaultParser) RangeProgress() (RangeProgress, bool) {
+	d.rangeMu.Lock()
+	job := d.rangeJob
+	d.rangeMu.Unlock()
+	if job == nil {
+		return RangeProgress{}, false
+	}
+	return job.snapshot(time.Now()), true
+}
+
+func (d *defaultParser) IndexRange(ctx context.Context, cfg RangeConfig) error {
+	if d.blocks == nil {
+		return ErrNoBlockReader
+	}
+	d.work.Add(1)
+	defer d.work.Done()
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultRangeWorkers
+	}
+	if cfg.LogInterval <= 0 {
+		cfg.LogInterval = DefaultRangeLogInterval
+	}
+	latest, err := d.waitForBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg.To <= 0 || cfg.To > latest {
+		cfg.To = latest
+	}
+	if cfg.Last > 0 {
+		cfg.From = max(cfg.To-cfg.Last+1, 0)
+	}
+	var saved RangeProgress
+	if cfg.Progress != "" {
+		p, ok, err := loadRangeProgress(cfg.Progress)
+		if err != nil {
+			return err
+		}
+		if ok && p.State != RangeDone {
+			cfg.From, cfg.To = p.From, p.To
+			// The progress file alone keeps the resume point, so
+			// the blocks before Next are not read again whether
+			// or not a snapshot kept them. Blocks done past Next
+			// are read again.
+			saved.Next = min(max(p.Next, p.From), p.To+1)
+			saved.Skipped = min(p.Skipped, saved.Next-p.From)
+			saved.Indexed = saved.Next - p.From - saved.Skipped
+		}
+	}
+	if cfg.From < 0 || cfg.To < cfg.From {
+		return fmt.Errorf("%w-%d to %d", ErrBackfillRange, cfg.From, cfg.To)
+	}
+
+	now := time.Now()
+	job := &rangeJob{
+		progress: RangeProgress{
+			State:     RangeRunning,
+			From:      cfg.From,
+			To:        cfg.To,
+			Next:      max(saved.Next, cfg.From),
+			Indexed:   saved.Indexed,
+			Skipped:   saved.Skipped,
+			Total:     cfg.To - cfg.From + 1,
+			StartedAt: now,
+			UpdatedAt: now,
+		},
+		done:    map[int64]bool{},
+		resumed: saved.Indexed + saved.Skipped,
+	}
+
+	d.rangeMu.Lock()
+	if d.rangeJob != nil && d.rangeJob.snapshot(now).State == RangeRunning {
+		d.rangeMu.Unlock()
+		return ErrRangeRunning
+	}
+	d.rangeJob = job
+	d.rangeMu.Unlock()
+
+	logger := d.logger.With("from", cfg.From, "to", cfg.To)
+	logger.Info("range indexing started", "next", job.progress.Next, "workers", cfg.Workers)
+	err = d.runRange(ctx, cfg, job)
+	p := job.finish(err, time.Now())
+	metrics.RangeProgress.WithLabelValues(d.chain).Set(float64(p.Indexed+p.Skipped) / float64(p.Total))
+	if cfg.Progress != "" {
+		if serr := saveRangeProgress(cfg.Progress, p); serr != nil {
+			logger.Error("save range progress", "err", serr)
+		}
+	}
+	if err != nil {
+		logger.Error("range indexing failed", "next", p.Next, "indexed", p.Indexed, "err", err)
+		return err
+	}
+	logger.Info("range indexing done", "indexed", p.Indexed, "skipped", p.Skipped, "elapsed", p.UpdatedAt.Sub(p.StartedAt))
+	return nil
+}
+
+// runRange reads the blocks of the job with cfg.Workers workers
+// and indexes those ingestion has not
+func (d *defaultParser) runRange(ctx context.Context, cfg RangeConfig, job *rangeJob) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	numbers := make(chan int64)
+	go func() {
+		defer close(numbers)
+		for n := job.progress.Next; n <= cfg.To; n++ {
+			select {
+			case numbers <- n:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range numbers {
+				indexed, err := d.indexRangeBlock(ctx, n)
+				if err != nil {
+					cancel(fmt.Errorf("%w-block %d: %v", ErrRangeIndexing, n, err))
+					return
+				}
+				job.complete(n, indexed, time.Now())
+			}
+		}()
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+	ticker := time.NewTicker(cfg.LogInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopped:
+			if err := context.Cause(ctx); err != nil {
+				return err
+			}
+			return nil
+		case <-ticker.C:
+			p := job.snapshot(time.Now())
+			metrics.RangeProgress.WithLabelValues(d.chain).Set(float64(p.Indexed+p.Skipped) / float64(p.Total))
+			d.logger.Info("range indexing progress", "next", p.Next, "indexed", p.Indexed, "skipped", p.Skipped,
+				"total", p.Total, "rate", fmt.Sprintf("%.1f/s", p.Rate), "eta", p.ETA.Round(time.Second))
+			if cfg.Progress != "" {
+				if err := saveRangeProgress(cfg.Progress, p); err != nil {
+					d.logger.Error("save range progress", "err", err)
+				}
+			}
+		}
+	}
+}
+
+// indexRangeBlock indexes block n unless already indexed, and
+// reports whether it did
+func (d *defaultParser) indexRangeBlock(ctx context.Context, n int64) (bool, error) {
+	if d.indexedBlocks.has(n) {
+		return false, nil
+	}
+	var b BlockTxn
+	var err error
+	for attempt := range rangeAttempts {
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(attempt) * time.Second):
+			case <-ctx.Done():
+				return false, ctx.Err()
+			}
+		}
+		if b, err = d.blocks(ctx, big.NewInt(n)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	// Ingestion may have processed the block meanwhile
+	if !d.indexedBlocks.claim(n) {
+		return false, nil
+	}
+	d.indexBlock(ctx, b, d.indexed, false)
+	return true, nil
+}
+
+// waitForBlock returns the latest block processed, once there is
+// one
+func (d *defaultParser) waitForBlock(ctx context.Context) (int64, error) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if n, err := strconv.ParseInt(d.latestBlock.Get(), 10, 64); err == nil && n >= 0 {
+			return n, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// complete records block n as done and advances Next past the
+// blocks done in sequence
+func (j *rangeJob) complete(n int64, indexed bool, now time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if indexed {
+		j.progress.Indexed++
+	} else {
+		j.progress.Skipped++
+	}
+	j.done[n] = true
+	for j.done[j.progress.Next] {
+		delete(j.done, j.progress.Next)
+		j.progress.Next++
+	}
+	j.progress.UpdatedAt = now
+}
+
+func (j *rangeJob) finish(err error, now time.Time) RangeProgress {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.progress.State = RangeDone
+	if err != nil {
+		j.progress.State = RangeFailed
+		j.progress.Error = err.Error()
+	}
+	j.progress.UpdatedAt = now
+	p := j.progress
+	p.Rate, p.ETA = 0, 0
+	return p
+}
+
+// snapshot returns the progress with the rate and ETA at now
+func (j *rangeJob) snapshot(now time.Time) RangeProgress {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	p := j.progress
+	if p.State != RangeRunning {
+		return p
+	}
+	done := p.Indexed + p.Skipped - j.resumed
+	if elapsed := now.Sub(p.StartedAt).Seconds(); elapsed > 0 && done > 0 {
+		p.Rate = float64(done) / elapsed
+		remaining := p.Total - p.Indexed - p.Skipped
+		p.ETA = time.Duration(float64(remaining) / p.Rate * float64(time.Second))
+	}
+	return p
+}
+
+func loadRangeProgress(path string) (RangeProgress, bool, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return RangeProgress{}, false, nil
+	}
+	if err != nil {
+		return RangeProgress{}, false, fmt.Errorf("%w-%v", ErrLoadRangeProgress, err)
+	}
+	var p RangeProgress
+	if err := json.Unmarshal(b, &p); err != nil {
+		return RangeProgress{}, false, fmt.Errorf("%w-%v", ErrLoadRangeProgress, err)
+	}
+	return p, true, nil
+}
+
+// saveRangeProgress writes to a temporary file and renames it so
+// a crash never leaves partial progress
+func saveRangeProgress(path string, p RangeProgress) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("%w-%v", ErrSaveRangeProgress, err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return fmt.Errorf("%w-%v", ErrSaveRangeProgress, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("%w-%v", ErrSaveRangeProgress, err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockSet(t *testing.T) {
+	var s blockSet
+	for _, n := range []int64{5, 7, 6, 1, 2} {
+		if !s.claim(n) {
+			t.Errorf("expected %d claimed", n)
+		}
+	}
+	if s.claim(6) {
+		t.Error("expected 6 already claimed")
+	}
+	want := []BlockRange{{From: 1, To: 2}, {From: 5, To: 7}}
+	if got := s.list(); !slices.Equal(got, want) {
+		t.Errorf("expected %v; got %v", want, got)
+	}
+	s.add([]BlockRange{{From: 3, To: 4}, {From: 10, To: 12}})
+	want = []BlockRange{{From: 1, To: 7}, {From: 10, To: 12}}
+	if got := s.list(); !slices.Equal(got, want) {
+		t.Errorf("expected %v; got %v", want, got)
+	}
+	if s.has(8) || !s.has(11) {
+		t.Error("unexpected membership")
+	}
+}
+
+// rangeChain serves blocks holding one transaction each and
+// records the blocks read
+type rangeChain struct {
+	mu    sync.Mutex
+	reads []int64
+}
+
+func (c *rangeChain) block(n int64) BlockTxn {
+	return BlockTxn{BlockNum: fmt.Sprint(n), Txns: []Transaction{{Hash: fmt.Sprintf("0x%x", n), From: watchAlice, To: watchBob}}}
+}
+
+func (c *rangeChain) read(ctx context.Context, number *big.Int) (BlockTxn, error) {
+	c.mu.Lock()
+	c.reads = append(c.reads, number.Int64())
+	c.mu.Unlock()
+	return c.block(number.Int64()), nil
+}
+
+func (c *rangeChain) blocksRead() []int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	read := slices.Clone(c.reads)
+	slices.Sort(read)
+	return read
+}
+
+func newRangeParser(t *testing.T, c *rangeChain, live ...int64) Parser {
+	t.Helper()
+	blocks := make(chan BlockTxn)
+	t.Cleanup(func() { close(blocks) })
+	p, _ := NewParser(blocks, ParserConfig{Blocks: c.read})
+	for _, n := range live {
+		blocks <- c.block(n)
+	}
+	deadline := time.Now().Add(time.Second)
+	for p.GetCurrentBlock() != fmt.Sprint(live[len(live)-1]) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	return p
+}
+
+func TestIndexRange(t *testing.T) {
+	c := &rangeChain{}
+	p := newRangeParser(t, c, 7, 10)
+	indexer := p.(Indexer)
+	if _, ok := indexer.RangeProgress(); ok {
+		t.Error("expected no job yet")
+	}
+
+	progress := filepath.Join(t.TempDir(), "progress.json")
+	if err := indexer.IndexRange(context.Background(), RangeConfig{From: 5, To: 100, Workers: 3, Progress: progress}); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.blocksRead(); !slices.Equal(got, []int64{5, 6, 8, 9}) {
+		t.Errorf("expected the blocks not ingested read; got %v", got)
+	}
+	// Each transaction is stored once
+	if got := p.GetTransactions(context.Background(), watchAlice); len(got) != 6 {
+		t.Errorf("expected 6 transactions; got %d", len(got))
+	}
+
+	got, _ := indexer.RangeProgress()
+	if got.State != RangeDone || got.To != 10 || got.Next != 11 || got.Indexed != 4 || got.Skipped != 2 {
+		t.Errorf("unexpected progress %+v", got)
+	}
+	b, _ := os.ReadFile(progress)
+	var saved RangeProgress
+	json.Unmarshal(b, &saved)
+	if saved.State != RangeDone || saved.Next != 11 {
+		t.Errorf("unexpected saved progress %s", b)
+	}
+}
+
+func TestIndexRangeResume(t *testing.T) {
+	testcases := []struct {
+		name string
+		// live are the blocks the parser has, e.g. from a
+		// snapshot
+		live []int64
+		read []int64
+		want RangeProgress
+	}{
+		{
+			name: "indexed blocks kept",
+			live: []int64{5, 6, 7, 10},
+			read: []int64{8, 9},
+			want: RangeProgress{From: 5, To: 9, Next: 10, Indexed: 4, Skipped: 1},
+		},
+		{
+			// The progress file resumes the job without a
+			// snapshot of the blocks indexed before the restart
+			name: "no snapshot",
+			live: []int64{10},
+			read: []int64{8, 9},
+			want: RangeProgress{From: 5, To: 9, Next: 10, Indexed: 4, Skipped: 1},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			progress := filepath.Join(t.TempDir(), "progress.json")
+			b, _ := json.Marshal(RangeProgress{State: RangeFailed, From: 5, To: 9, Next: 8, Indexed: 3, Skipped: 1})
+			os.WriteFile(progress, b, 0o644)
+
+			c := &rangeChain{}
+			p := newRangeParser(t, c, tc.live...)
+			// The unfinished job is resumed over its own range
+			if err := p.(Indexer).IndexRange(context.Background(), RangeConfig{Last: 100, Progress: progress}); err != nil {
+				t.Fatal(err)
+			}
+			if got := c.blocksRead(); !slices.Equal(got, tc.read) {
+				t.Errorf("expected blocks %v read; got %v", tc.read, got)
+			}
+			got, _ := p.(Indexer).RangeProgress()
+			if got.From != tc.want.From || got.To != tc.want.To || got.Next != tc.want.Next ||
+				got.Indexed != tc.want.Indexed || got.Skipped != tc.want.Skipped {
+				t.Errorf("unexpected progress %+v", got)
+			}
+		})
+	}
+}
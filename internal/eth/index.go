@@ -0,0 +1,114 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"paulwizviz/go-eth-app/internal/store"
+	"slices"
+	"strings"
+)
+
+var ErrStoreTransaction = errors.New("store transaction error")
+
+// storedTxn is a transaction kept under its hash, with the
+// addresses it is indexed under
+type storedTxn struct {
+	Transaction json.RawMessage `json:"transaction"`
+	Addresses   []string        `json:"addresses"`
+}
+
+// storeTxn keeps tx under its hash and indexes it under the
+// addresses, each once, so storing a transaction again is a no-op.
+// It returns the addresses it was newly indexed under.
+func (d *defaultParser) storeTxn(ctx context.Context, tx Transaction, msg []byte, addrs []string) ([]string, error) {
+	txnStorage := store.Traced(ctx, d.txnStorage)
+	addrIndex := store.Traced(ctx, d.addrIndex)
+	key := strings.ToLower(tx.Hash)
+
+	d.storeMu.Lock()
+	defer d.storeMu.Unlock()
+	rec := storedTxn{Transaction: msg}
+	values, err := txnStorage.Get(key)
+	switch {
+	case err == nil && len(values) > 0:
+		if err := json.Unmarshal(values[0], &rec); err != nil {
+			return nil, fmt.Errorf("%w-%v", ErrStoreTransaction, err)
+		}
+	case err != nil && !errors.Is(err, store.ErrKeyNotFound):
+		return nil, fmt.Errorf("%w-%v", ErrStoreTransaction, err)
+	}
+
+	var added []string
+	for _, addr := range addrs {
+		if slices.Contains(rec.Addresses, addr) {
+			continue
+		}
+		rec.Addresses = append(rec.Addresses, addr)
+		added = append(added, addr)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("%w-%v", ErrStoreTransaction, err)
+	}
+	if err := txnStorage.Set(key, [][]byte{b}); err != nil {
+		return nil, fmt.Errorf("%w-%v", ErrStoreTransaction, err)
+	}
+	for i, addr := range added {
+		if err := addrIndex.Append(addr, []byte(key)); err != nil {
+			// The record names only the addresses indexed
+			rec.Addresses = rec.Addresses[:len(rec.Addresses)-len(added)+i]
+			if b, merr := json.Marshal(rec); merr == nil {
+				txnStorage.Set(key, [][]byte{b})
+			}
+			return added[:i], fmt.Errorf("%w-%v", ErrStoreTransaction, err)
+		}
+		d.counter.Add(addr)
+	}
+	return added, nil
+}
+
+// claimTransfer records that the transfer id is indexed under the
+// addresses and returns those it was not indexed under before
+func (d *defaultParser) claimTransfer(id string, addrs []string) []string {
+	if len(addrs) == 0 {
+		return nil
+	}
+	d.storeMu.Lock()
+	defer d.storeMu.Unlock()
+	var claimed []string
+	for _, addr := range addrs {
+		if !slices.Contains(d.transferIDs[id], addr) {
+			d.transferIDs[id] = append(d.transferIDs[id], addr)
+			claimed = append(claimed, addr)
+		}
+	}
+	return claimed
+}
+
+// addressTxns returns the transactions indexed under address, in
+// the order they were indexed
+func (d *defaultParser) addressTxns(ctx context.Context, address string) ([]json.RawMessage, error) {
+	hashes, err := store.Traced(ctx, d.addrIndex).Get(address)
+	if err != nil {
+		return nil, err
+	}
+	txnStorage := store.Traced(ctx, d.txnStorage)
+	txns := make([]json.RawMessage, 0, len(hashes))
+	for _, h := range hashes {
+		values, err := txnStorage.Get(string(h))
+		if err != nil || len(values) == 0 {
+			continue
+		}
+		var rec storedTxn
+		if err := json.Unmarshal(values[0], &rec); err != nil {
+			continue
+		}
+		txns = append(txns, rec.Transaction)
+	}
+	return txns, nil
+}
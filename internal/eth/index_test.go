@@ -0,0 +1,78 @@
+package eth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParserIdempotentIndexing(t *testing.T) {
+	src := make(chan BlockTxn)
+	close(src)
+	p, err := NewParser(src, ParserConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := p.(*defaultParser)
+	ctx := context.Background()
+	b := BlockTxn{
+		BlockNum: "100",
+		Txns: []Transaction{
+			{Hash: "0x1", From: "0xa", To: "0xa", Block: "0x64"},
+			{Hash: "0x2", From: "0xa", To: "0xb", Block: "0x64"},
+		},
+	}
+	d.processBlock(ctx, b)
+	if got := d.indexBlock(ctx, b, d.indexed, true); len(got.Txns) != 0 {
+		t.Errorf("expected no transaction indexed again; got %+v", got.Txns)
+	}
+
+	testcases := []struct {
+		address string
+		count   int64
+		hashes  []string
+	}{
+		{"0xa", 2, []string{"0x1", "0x2"}},
+		{"0xb", 1, []string{"0x2"}},
+	}
+	for i, tc := range testcases {
+		if got := d.GetCount(tc.address); got != tc.count {
+			t.Errorf("case %d: expected count %d; got %d", i, tc.count, got)
+		}
+		txns := d.GetTransactions(ctx, tc.address)
+		if len(txns) != len(tc.hashes) {
+			t.Fatalf("case %d: expected %d transactions; got %+v", i, len(tc.hashes), txns)
+		}
+		for j, h := range tc.hashes {
+			if txns[j].Hash != h {
+				t.Errorf("case %d: expected transaction %s; got %s", i, h, txns[j].Hash)
+			}
+		}
+	}
+	if got := len(d.GetAddresses(ctx)); got != 2 {
+		t.Errorf("expected 2 addresses; got %d", got)
+	}
+}
+
+func TestStoreTxnAddsAddresses(t *testing.T) {
+	src := make(chan BlockTxn)
+	close(src)
+	p, err := NewParser(src, ParserConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := p.(*defaultParser)
+	ctx := context.Background()
+	tx := Transaction{Hash: "0x1", From: "0xa", To: "0xb"}
+
+	added, err := d.storeTxn(ctx, tx, []byte(`{"hash":"0x1"}`), []string{"0xa"})
+	if err != nil || len(added) != 1 {
+		t.Fatalf("expected 0xa added; got %v %v", added, err)
+	}
+	added, err = d.storeTxn(ctx, tx, []byte(`{"hash":"0x1"}`), []string{"0xa", "0xb"})
+	if err != nil || len(added) != 1 || added[0] != "0xb" {
+		t.Errorf("expected only 0xb added; got %v %v", added, err)
+	}
+	if got := d.GetCount("0xa") + d.GetCount("0xb"); got != 2 {
+		t.Errorf("expected 2 counts; got %d", got)
+	}
+}
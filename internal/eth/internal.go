@@ -0,0 +1,139 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+const methodTraceBlock = "debug_traceBlockByNumber"
+
+var (
+	errUnmarshalTrace = errors.New("unmarshal trace error")
+	errTraceMismatch  = errors.New("traces do not match transactions")
+)
+
+// InternalTransfer is value moved by a call a contract made. The
+// top level call is the transaction itself and is not included.
+type InternalTransfer struct {
+	// Type is the call type, e.g. CALL, CREATE or SELFDESTRUCT
+	Type  string `json:"type"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value string `json:"value"`
+	// Depth is one for a call made by the transaction's
+	// recipient, two for a call made by that callee, and so on
+	Depth int `json:"depth"`
+	// Failed is set when the call, or one it was made from,
+	// reverted; no value was moved then
+	Failed bool `json:"failed,omitempty"`
+	// Error is the reason the call itself failed
+	Error           string `json:"error,omitempty"`
+	TransactionHash string `json:"transactionHash"`
+	Block           string `json:"blockNumber"`
+	// Index orders the transfers of a transaction
+	Index int `json:"index"`
+}
+
+// Addresses returns the lower case sender and recipient
+func (t InternalTransfer) Addresses() []string {
+	return []string{strings.ToLower(t.From), strings.ToLower(t.To)}
+}
+
+// callFrame is a call as reported by the callTracer
+type callFrame struct {
+	Type  string      `json:"type"`
+	From  string      `json:"from"`
+	To    string      `json:"to"`
+	Value string      `json:"value"`
+	Error string      `json:"error"`
+	Calls []callFrame `json:"calls"`
+}
+
+// txTrace is the trace of a transaction in a block trace. Nodes
+// before geth 1.11 leave out the hash.
+type txTrace struct {
+	TxHash string    `json:"txHash"`
+	Result callFrame `json:"result"`
+}
+
+// traceFetcher attaches internal transfers to the transactions of
+// a block
+type traceFetcher struct {
+	client *http.Client
+	url    string
+}
+
+func newTraceFetcher(client *http.Client, url string) *traceFetcher {
+	return &traceFetcher{client: client, url: url}
+}
+
+// attach sets the internal transfers of every transaction in txns.
+// Unless the block trace matches txns, none is set.
+func (f *traceFetcher) attach(ctx context.Context, blockNumber *big.Int, txns []Transaction) error {
+	if len(txns) == 0 {
+		return nil
+	}
+	rpcResp, err := postRPC(ctx, f.client, f.url, methodTraceBlock, []any{
+		fmt.Sprintf("0x%x", blockNumber),
+		map[string]string{"tracer": "callTracer"},
+	})
+	if err != nil {
+		return err
+	}
+	var traces []txTrace
+	if err := json.Unmarshal(rpcResp.Result, &traces); err != nil {
+		return fmt.Errorf("%w-%v", errUnmarshalTrace, err)
+	}
+	if len(traces) != len(txns) {
+		return fmt.Errorf("%w-%d traces, %d transactions", errTraceMismatch, len(traces), len(txns))
+	}
+	for i, tr := range traces {
+		if tr.TxHash != "" && !strings.EqualFold(tr.TxHash, txns[i].Hash) {
+			return fmt.Errorf("%w-%s", errTraceMismatch, tr.TxHash)
+		}
+	}
+	for i, tr := range traces {
+		txns[i].Internal = flattenCalls(tr.Result, txns[i])
+	}
+	return nil
+}
+
+// flattenCalls returns the calls nested in root that moved value,
+// in execution order
+func flattenCalls(root callFrame, tx Transaction) []InternalTransfer {
+	transfers := []InternalTransfer{}
+	var walk func(f callFrame, depth int, failed bool)
+	walk = func(f callFrame, depth int, failed bool) {
+		failed = failed || f.Error != ""
+		if depth > 0 && hasValue(f.Value) {
+			transfers = append(transfers, InternalTransfer{
+				Type:            f.Type,
+				From:            strings.ToLower(f.From),
+				To:              strings.ToLower(f.To),
+				Value:           f.Value,
+				Depth:           depth,
+				Failed:          failed,
+				Error:           f.Error,
+				TransactionHash: tx.Hash,
+				Block:           tx.Block,
+				Index:           len(transfers),
+			})
+		}
+		for _, c := range f.Calls {
+			walk(c, depth+1, failed)
+		}
+	}
+	walk(root, 0, false)
+	return transfers
+}
+
+// hasValue reports whether a hex quantity is above zero
+func hasValue(v string) bool {
+	n, ok := new(big.Int).SetString(strings.TrimPrefix(v, "0x"), 16)
+	return ok && n.Sign() > 0
+}
@@ -0,0 +1,117 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// multisigTrace is a wallet paying out twice, one payout reverting
+// inside a reverted sub call
+var multisigTrace = map[string]any{
+	"type": "CALL", "from": "0xsender", "to": "0xWallet", "value": "0x0",
+	"calls": []any{
+		map[string]any{"type": "CALL", "from": "0xwallet", "to": "0xalice", "value": "0x64"},
+		map[string]any{"type": "STATICCALL", "from": "0xwallet", "to": "0xoracle"},
+		map[string]any{"type": "CALL", "from": "0xwallet", "to": "0xrouter", "value": "0x0", "error": "execution reverted",
+			"calls": []any{
+				map[string]any{"type": "CALL", "from": "0xrouter", "to": "0xbob", "value": "0xa"},
+			}},
+	},
+}
+
+func TestTraceFetcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		traces  []any
+		wantErr error
+	}{
+		{name: "with hashes", traces: []any{
+			map[string]any{"txHash": "0xa", "result": multisigTrace},
+			map[string]any{"txHash": "0xb", "result": map[string]any{"type": "CALL", "from": "0xc", "to": "0xd", "value": "0x1"}},
+		}},
+		{name: "without hashes", traces: []any{
+			map[string]any{"result": multisigTrace},
+			map[string]any{"result": map[string]any{"type": "CALL", "from": "0xc", "to": "0xd", "value": "0x1"}},
+		}},
+		{name: "missing trace", traces: []any{map[string]any{"txHash": "0xa", "result": multisigTrace}}, wantErr: errTraceMismatch},
+		{name: "other transaction", traces: []any{
+			map[string]any{"txHash": "0xb", "result": multisigTrace},
+			map[string]any{"txHash": "0xa", "result": multisigTrace},
+		}, wantErr: errTraceMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Method string `json:"method"`
+					Params []any  `json:"params"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				if req.Method != methodTraceBlock || req.Params[0] != "0x5" {
+					t.Errorf("unexpected request %+v", req)
+				}
+				json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": tt.traces})
+			}))
+			defer srv.Close()
+
+			txns := []Transaction{{Hash: "0xa", Block: "0x5"}, {Hash: "0xb", Block: "0x5"}}
+			err := newTraceFetcher(srv.Client(), srv.URL).attach(context.Background(), big.NewInt(5), txns)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v; got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				if txns[0].Internal != nil {
+					t.Errorf("expected no internal transfers; got %+v", txns[0].Internal)
+				}
+				return
+			}
+			want := []InternalTransfer{
+				{Type: "CALL", From: "0xwallet", To: "0xalice", Value: "0x64", Depth: 1, TransactionHash: "0xa", Block: "0x5"},
+				{Type: "CALL", From: "0xrouter", To: "0xbob", Value: "0xa", Depth: 2, Failed: true, TransactionHash: "0xa", Block: "0x5", Index: 1},
+			}
+			if len(txns[0].Internal) != len(want) {
+				t.Fatalf("expected %d internal transfers; got %+v", len(want), txns[0].Internal)
+			}
+			for i := range want {
+				if txns[0].Internal[i] != want[i] {
+					t.Errorf("transfer %d: expected %+v; got %+v", i, want[i], txns[0].Internal[i])
+				}
+			}
+			// The top level call is the transaction itself
+			if len(txns[1].Internal) != 0 {
+				t.Errorf("expected no internal transfers; got %+v", txns[1].Internal)
+			}
+		})
+	}
+}
+
+func TestParserInternalTransfers(t *testing.T) {
+	blocks := make(chan BlockTxn)
+	defer close(blocks)
+	p := NewDefaultParser(blocks)
+
+	blocks <- BlockTxn{BlockNum: "5", Txns: []Transaction{{
+		Hash: "0xa", From: "0xsender", To: "0xwallet", Block: "0x5",
+		Internal: []InternalTransfer{
+			{Type: "CALL", From: "0xwallet", To: "0xalice", Value: "0x64", Depth: 1, TransactionHash: "0xa", Block: "0x5"},
+		},
+	}}}
+	// The next block is only read once the previous is processed
+	blocks <- BlockTxn{BlockNum: "6"}
+
+	got := p.GetInternalTransfers(context.Background(), "0xAlice")
+	if len(got) != 1 || got[0].Value != "0x64" {
+		t.Errorf("expected the payout to alice; got %+v", got)
+	}
+	if got := p.GetInternalTransfers(context.Background(), "0xwallet"); len(got) != 1 {
+		t.Errorf("expected the payout from the wallet; got %+v", got)
+	}
+	if got := p.GetInternalTransfers(context.Background(), "0xsender"); len(got) != 0 {
+		t.Errorf("expected no internal transfers of the sender; got %+v", got)
+	}
+}
@@ -2,11 +2,22 @@ package eth
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
+	"paulwizviz/go-eth-app/internal/metrics"
+	"paulwizviz/go-eth-app/internal/tracing"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -21,8 +32,11 @@ var (
 	errUnmarshalBlock       = errors.New("unmarshal block error")
 	errUnmarshalBlockNumber = errors.New("unmarshal block number error")
 	errSendingRequest       = errors.New("sending request error")
+	errRPC                  = errors.New("json-rpc error")
 )
 
+var tracer = otel.Tracer("paulwizviz/go-eth-app/internal/eth")
+
 type request struct {
 	JsonRPC string `json:"jsonrpc"`
 	Method  string `json:"method"`
@@ -34,31 +48,79 @@ type response struct {
 	JsonRPC string          `json:"jsonrpc"`
 	ID      int             `json:"id"`
 	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+}
+
+// rpcError is an error returned by the node
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// codeMethodNotFound is returned for methods a node does not serve
+const codeMethodNotFound = -32601
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%d %s", e.Code, e.Message)
 }
 
-func currentBlock(url string) (*big.Int, error) {
+// postRPC sends a JSON-RPC request to the node, recording its
+// latency and a client span
+func postRPC(ctx context.Context, client *http.Client, url string, method string, params []any) (_ response, err error) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemKey.String("jsonrpc"),
+			semconv.RPCMethod(method),
+			tracing.Endpoint(url),
+		),
+	)
+	defer func() {
+		metrics.ObserveRPC(method, start, err)
+		tracing.End(span, err)
+	}()
 
 	req := request{
 		JsonRPC: rpcVersion,
-		Method:  methodBlockNumber,
-		Params:  []interface{}{},
+		Method:  method,
+		Params:  params,
 		ID:      1, // In production this would be replaced by Nounce
 	}
 
 	reqBody, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("%w-%v", errMarshalRequest, err)
+		return response{}, fmt.Errorf("%w-%v", errMarshalRequest, err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return response{}, fmt.Errorf("%w-%v", errSendingRequest, err)
 	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
 
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(reqBody))
+	resp, err := client.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("%w-%v", errSendingRequest, err)
+		return response{}, fmt.Errorf("%w-%v", errSendingRequest, err)
 	}
 	defer resp.Body.Close()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
 
 	var rpcResp response
 	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
-		return nil, fmt.Errorf("%w-%v", errUmarshalResponse, err)
+		return response{}, fmt.Errorf("%w-%v", errUmarshalResponse, err)
+	}
+	if rpcResp.Error != nil {
+		return response{}, fmt.Errorf("%w-%w", errRPC, rpcResp.Error)
+	}
+	return rpcResp, nil
+}
+
+func currentBlock(ctx context.Context, client *http.Client, url string) (*big.Int, error) {
+	rpcResp, err := postRPC(ctx, client, url, methodBlockNumber, []any{})
+	if err != nil {
+		return nil, err
 	}
 
 	// Convert the block number from hex to decimal
@@ -71,44 +133,46 @@ func currentBlock(url string) (*big.Int, error) {
 	blockNumberBig := new(big.Int)
 	blockNumberBig.SetString(blockNumber[2:], 16) // Remove Ox prefix
 
-	// Print the block number (hexadecimal format)
 	return blockNumberBig, nil
 }
 
-func getBlockTransactions(url string, blockNumber *big.Int) ([]Transaction, error) {
-	// Create the request body for eth_getBlockByNumber
-	hexBlockNumber := fmt.Sprintf("0x%x", blockNumber) // Convert block number to hex format
-	req := request{
-		JsonRPC: rpcVersion,
-		Method:  methodBlockByNumber,
-		Params:  []interface{}{hexBlockNumber, true}, // 'true' to get full transaction objects
-		ID:      1,
+// headBlock returns the block a node reports under the finality
+// tag: latest, safe or finalized
+func headBlock(ctx context.Context, client *http.Client, url string, finality string) (*big.Int, error) {
+	if finality == "" || finality == FinalityLatest {
+		return currentBlock(ctx, client, url)
 	}
-
-	// Marshal the request to JSON
-	reqBody, err := json.Marshal(req)
+	rpcResp, err := postRPC(ctx, client, url, methodBlockByNumber, []any{finality, false})
 	if err != nil {
-		return nil, fmt.Errorf("%w-%v", errMarshalRequest, err)
+		return nil, err
 	}
-
-	// Send the request
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, fmt.Errorf("%w-%v", errSendingRequest, err)
+	var header struct {
+		Number string `json:"number"`
 	}
-	defer resp.Body.Close()
+	if err := json.Unmarshal(rpcResp.Result, &header); err != nil {
+		return nil, fmt.Errorf("%w-%v", errUnmarshalBlock, err)
+	}
+	blockNumber, ok := new(big.Int).SetString(strings.TrimPrefix(header.Number, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("%w-%q", errUnmarshalBlockNumber, header.Number)
+	}
+	return blockNumber, nil
+}
 
-	// Decode the response
-	var rpcResp response
-	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
-		return nil, fmt.Errorf("%w-%v", errUmarshalResponse, err)
+func getBlock(ctx context.Context, client *http.Client, url string, blockNumber *big.Int) (Block, error) {
+	// Request full transaction objects of the block
+	hexBlockNumber := fmt.Sprintf("0x%x", blockNumber) // Convert block number to hex format
+	rpcResp, err := postRPC(ctx, client, url, methodBlockByNumber, []any{hexBlockNumber, true})
+	if err != nil {
+		return Block{}, err
 	}
 
 	// Unmarshal the block data (including transactions)
 	var block Block
 	if err := json.Unmarshal(rpcResp.Result, &block); err != nil {
-		return nil, fmt.Errorf("%w-%v", errUnmarshalBlock, err)
+		return Block{}, fmt.Errorf("%w-%v", errUnmarshalBlock, err)
 	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("eth.transactions", len(block.Transactions)))
 
-	return block.Transactions, nil
+	return block, nil
 }
@@ -1,23 +1,25 @@
 package eth
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 )
 
 func Example_ethBlockNum() {
 
 	url := "https://ethereum-rpc.publicnode.com"
-	blocknumber, err := currentBlock(url)
+	blocknumber, err := currentBlock(context.Background(), http.DefaultClient, url)
 	if err != nil {
 		fmt.Println(err)
 	}
 	fmt.Println(blocknumber.Int64() != int64(0))
 
-	txns, err := getBlockTransactions(url, blocknumber)
+	block, err := getBlock(context.Background(), http.DefaultClient, url, blocknumber)
 	if err != nil {
 		fmt.Println(err)
 	}
-	fmt.Println(len(txns) != 0)
+	fmt.Println(len(block.Transactions) != 0)
 
 	// Output:
 	// true
@@ -0,0 +1,221 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/metrics"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Mempool feeds
+const (
+	// MempoolTxpool polls txpool_content over HTTP
+	MempoolTxpool = "txpool"
+	// MempoolWebSocket subscribes to newPendingTransactions
+	MempoolWebSocket = "websocket"
+)
+
+const (
+	methodTxpoolContent     = "txpool_content"
+	methodSubscribe         = "eth_subscribe"
+	methodTransactionByHash = "eth_getTransactionByHash"
+)
+
+// DefaultMempoolPollInterval is how often the txpool feed polls
+// the node, and how long the websocket feed waits to reconnect
+const DefaultMempoolPollInterval = 2 * time.Second
+
+var (
+	ErrMempoolFeed   = errors.New("unknown mempool feed")
+	errUnmarshalPool = errors.New("unmarshal txpool error")
+)
+
+// MempoolConfig holds the settings of ReadMempool
+type MempoolConfig struct {
+	// Feed is MempoolTxpool or MempoolWebSocket
+	Feed string
+	// PollInterval defaults to DefaultMempoolPollInterval
+	PollInterval time.Duration
+	// Timeout of a JSON-RPC request, none if zero
+	Timeout time.Duration
+	// ChainID labels the logs of the feed
+	ChainID int64
+	// Logger receives the feed logs; nil discards them
+	Logger *slog.Logger
+}
+
+// ReadMempool reads the pending transactions of a node, each
+// once, until the context is done. url is a ws(s) URL for
+// MempoolWebSocket.
+func ReadMempool(c context.Context, url string, cfg MempoolConfig) (chan Transaction, error) {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultMempoolPollInterval
+	}
+	logger := orDiscard(cfg.Logger).With("chain", cfg.ChainID, "feed", cfg.Feed)
+
+	ch := make(chan Transaction, 256)
+	switch cfg.Feed {
+	case MempoolTxpool:
+		go pollTxpool(c, ch, &http.Client{Timeout: cfg.Timeout}, url, cfg.PollInterval, logger)
+	case MempoolWebSocket:
+		go subscribePending(c, ch, url, cfg.PollInterval, logger)
+	default:
+		return nil, fmt.Errorf("%w-%q", ErrMempoolFeed, cfg.Feed)
+	}
+	return ch, nil
+}
+
+func pollTxpool(ctx context.Context, ch chan Transaction, client *http.Client, url string, interval time.Duration, logger *slog.Logger) {
+	defer close(ch)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Only transactions new since the previous poll are sent
+	seen := map[string]bool{}
+	for {
+		txns, err := txpoolContent(ctx, client, url)
+		if err != nil {
+			logger.Error("get txpool content", "method", methodTxpoolContent, "err", err)
+		} else {
+			current := make(map[string]bool, len(txns))
+			for _, tx := range txns {
+				current[tx.Hash] = true
+				if seen[tx.Hash] {
+					continue
+				}
+				select {
+				case ch <- tx:
+				case <-ctx.Done():
+					return
+				}
+			}
+			seen = current
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// txpoolContent returns the executable transactions of the node's
+// pool; queued transactions have a nonce gap and are left out
+func txpoolContent(ctx context.Context, client *http.Client, url string) ([]Transaction, error) {
+	rpcResp, err := postRPC(ctx, client, url, methodTxpoolContent, []any{})
+	if err != nil {
+		return nil, err
+	}
+	var pool struct {
+		Pending map[string]map[string]Transaction `json:"pending"`
+	}
+	if err := json.Unmarshal(rpcResp.Result, &pool); err != nil {
+		return nil, fmt.Errorf("%w-%v", errUnmarshalPool, err)
+	}
+	var txns []Transaction
+	for _, byNonce := range pool.Pending {
+		for _, tx := range byNonce {
+			txns = append(txns, tx)
+		}
+	}
+	return txns, nil
+}
+
+// subscribePending streams newPendingTransactions, reconnecting
+// after retry when the connection fails
+func subscribePending(ctx context.Context, ch chan Transaction, url string, retry time.Duration, logger *slog.Logger) {
+	defer close(ch)
+	for {
+		err := readPendingSubscription(ctx, ch, url, logger)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Warn("pending transaction subscription failed", "retry", retry, "err", err)
+		select {
+		case <-time.After(retry):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+type wsMessage struct {
+	ID     int             `json:"id"`
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+	Params struct {
+		Result json.RawMessage `json:"result"`
+	} `json:"params"`
+}
+
+func readPendingSubscription(ctx context.Context, ch chan Transaction, url string, logger *slog.Logger) error {
+	start := time.Now()
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	metrics.ObserveRPC(methodSubscribe, start, err)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	// Ask for full transactions; nodes that only send hashes are
+	// asked for each transaction over the same connection
+	id := 1
+	if err := conn.WriteJSON(request{JsonRPC: rpcVersion, Method: methodSubscribe, Params: []any{"newPendingTransactions", true}, ID: id}); err != nil {
+		return err
+	}
+	logger.Info("subscribed to pending transactions")
+	for {
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return err
+		}
+		if msg.Error != nil {
+			// Only a failed subscription ends the connection
+			if msg.ID == 1 {
+				return fmt.Errorf("%w-%s", errSendingRequest, msg.Error.Message)
+			}
+			continue
+		}
+
+		var raw json.RawMessage
+		switch {
+		case msg.Method == "eth_subscription":
+			raw = msg.Params.Result
+		case msg.ID > 1:
+			raw = msg.Result
+		default:
+			continue
+		}
+
+		var hash string
+		if json.Unmarshal(raw, &hash) == nil && hash != "" {
+			id++
+			if err := conn.WriteJSON(request{JsonRPC: rpcVersion, Method: methodTransactionByHash, Params: []any{hash}, ID: id}); err != nil {
+				return err
+			}
+			continue
+		}
+		var tx Transaction
+		if err := json.Unmarshal(raw, &tx); err != nil || tx.Hash == "" {
+			// Already mined or dropped before it was fetched
+			continue
+		}
+		select {
+		case ch <- tx:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
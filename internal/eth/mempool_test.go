@@ -0,0 +1,51 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadMempoolTxpool(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		pending := map[string]map[string]Transaction{
+			"0xaa": {"1": {Hash: "0x1", From: "0xaa", Nonce: "0x1"}},
+		}
+		if polls > 1 {
+			pending["0xbb"] = map[string]Transaction{"4": {Hash: "0x2", From: "0xbb", Nonce: "0x4"}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": map[string]any{
+			"pending": pending,
+			"queued":  map[string]any{},
+		}})
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := ReadMempool(ctx, srv.URL, MempoolConfig{Feed: MempoolTxpool, PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Transactions still in the pool are not sent again
+	for _, want := range []string{"0x1", "0x2"} {
+		select {
+		case tx := <-ch:
+			if tx.Hash != want {
+				t.Errorf("expected %s; got %s", want, tx.Hash)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected %s", want)
+		}
+	}
+
+	if _, err := ReadMempool(ctx, srv.URL, MempoolConfig{Feed: "gossip"}); !errors.Is(err, ErrMempoolFeed) {
+		t.Errorf("expected ErrMempoolFeed; got %v", err)
+	}
+}
@@ -1,11 +1,25 @@
 package eth
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"paulwizviz/go-eth-app/internal/counter"
+	"paulwizviz/go-eth-app/internal/metrics"
 	"paulwizviz/go-eth-app/internal/observer"
 	"paulwizviz/go-eth-app/internal/store"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Parser represents a handler to enable a
@@ -14,78 +28,502 @@ import (
 type Parser interface {
 	// last parsed block
 	GetCurrentBlock() string
-	// add address to observer
-	Subscribe(address string) *observer.Subscription
+	// add address to observer with the given buffer and overflow policy
+	Subscribe(address string, opts observer.Options) *observer.Subscription
+	// SubscribeFilter subscribes to all transactions matching the filter
+	SubscribeFilter(filter Filter, opts observer.Options) *observer.Subscription
 	// list of inbound or outbound transactions for an address
-	GetTransactions(address string) []Transaction
+	GetTransactions(ctx context.Context, address string) []Transaction
 	// GetAddresses returns a list of all addresses seen
-	GetAddresses() []string
+	GetAddresses(ctx context.Context) []string
 	// GetCount returns the tx count for a given address
 	GetCount(address string) int64
+	// SubscribePending subscribes to the pending transactions of
+	// an address and their outcomes
+	SubscribePending(address string, opts observer.Options) *observer.Subscription
+	// GetPending returns the in-flight transactions of an address
+	GetPending(address string) []PendingTransaction
+	// GetTokenTransfers returns the token transfers from or to an
+	// address, decoded from the receipts of its blocks
+	GetTokenTransfers(ctx context.Context, address string) []TokenTransfer
+	// SubscribeTokenTransfers subscribes to the token transfers
+	// from or to an address
+	SubscribeTokenTransfers(address string, opts observer.Options) *observer.Subscription
+	// GetInternalTransfers returns the value moved from or to an
+	// address by contract calls, traced from its blocks
+	GetInternalTransfers(ctx context.Context, address string) []InternalTransfer
+	// SubscribeInternalTransfers subscribes to the internal
+	// transfers from or to an address
+	SubscribeInternalTransfers(address string, opts observer.Options) *observer.Subscription
+}
+
+// BlockSink receives every processed block. PublishBlock is
+// called from the parser goroutine, so a sink that blocks
+// until delivery applies backpressure to ingestion.
+type BlockSink interface {
+	PublishBlock(b BlockTxn) error
+}
+
+// ParserConfig holds the optional settings of a parser
+type ParserConfig struct {
+	// ChainID labels the metrics and logs of the parser
+	ChainID int64
+	// Snapshot, if set, is loaded into the parser
+	// before any block is processed.
+	Snapshot io.Reader
+	// Sinks receive each block once it is stored
+	Sinks []BlockSink
+	// Logger receives the parser and observer logs; nil
+	// discards them
+	Logger *slog.Logger
+	// Pending, if set, feeds mempool transactions. Those of
+	// watched addresses, already seen in a block or with a
+	// pending subscriber, are tracked until mined, replaced or
+	// dropped after PendingTTL. At most MaxPending are tracked.
+	Pending    <-chan Transaction
+	PendingTTL time.Duration
+	MaxPending int
+	// Watchlist, if set, limits indexing to the transactions
+	// from or to its addresses
+	Watchlist *Watchlist
+	// MaxAnalyzed bounds the addresses with analytics,
+	// DefaultMaxAnalyzed if zero
+	MaxAnalyzed int
+	// Blocks reads the blocks a backfill indexes and GetBlock
+	// serves
+	Blocks BlockReader
+	// MaxBackfill bounds the blocks of a backfill,
+	// DefaultMaxBackfill if zero
+	MaxBackfill int64
 }
 
+// NewDefaultParser instantiate a parser with default settings
 func NewDefaultParser(blocktxn chan BlockTxn) Parser {
-	d := defaultParser{
+	p, _ := NewParser(blocktxn, ParserConfig{})
+	return p
+}
+
+// NewParser instantiate a parser with the given settings
+func NewParser(blocktxn chan BlockTxn, cfg ParserConfig) (Parser, error) {
+	logger := orDiscard(cfg.Logger).With("chain", cfg.ChainID)
+	d := &defaultParser{
+		chainID:     cfg.ChainID,
+		chain:       metrics.Chain(cfg.ChainID),
 		latestBlock: NewLatestParseBlock(),
 		txnStorage:  store.NewInMemoryStorage(),
-		observer:    observer.New(),
+		addrIndex:   store.NewInMemoryStorage(),
+		transferIDs: map[string][]string{},
+		tokStorage:  store.NewInMemoryStorage(),
+		tokenObs:    observer.NewWithLogger(logger),
+		intStorage:  store.NewInMemoryStorage(),
+		internalObs: observer.NewWithLogger(logger),
+		observer:    observer.NewWithLogger(logger),
+		pending:     newPendingPool(cfg.PendingTTL, cfg.MaxPending),
+		pendingObs:  observer.NewWithLogger(logger),
 		counter:     counter.New(),
+		analytics:   newAnalytics(cfg.MaxAnalyzed),
+		sinks:       cfg.Sinks,
+		watchlist:   cfg.Watchlist,
+		blocks:      cfg.Blocks,
+		maxBackfill: cfg.MaxBackfill,
+		logger:      logger,
+	}
+	if d.maxBackfill <= 0 {
+		d.maxBackfill = DefaultMaxBackfill
+	}
+	if cfg.Snapshot != nil {
+		if err := d.ImportSnapshot(cfg.Snapshot); err != nil {
+			return nil, fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+		}
 	}
 	// Initiate a Goroutine to read data
 	// from the Ethereum network.
+	d.work.Add(1)
 	go func() {
+		defer d.work.Done()
 		for b := range blocktxn {
-			if d.latestBlock.Get() >= b.BlockNum {
-				log.Printf("Already processed block %s", b.BlockNum)
-				continue
-			}
-			d.latestBlock.Update(b.BlockNum)
-
-			for _, tx := range b.Txns {
-				txMarshal, err := json.Marshal(tx)
-				if err != nil {
-					log.Println(err)
-					continue
-				}
-
-				d.counter.Add(tx.From)
-				d.counter.Add(tx.To)
-				d.observer.Notify(tx.From, txMarshal)
-				d.observer.Notify(tx.To, txMarshal)
-				if err := d.txnStorage.Append(tx.From, txMarshal); err != nil {
-					log.Println(err)
-				}
-				if err := d.txnStorage.Append(tx.To, txMarshal); err != nil {
-					log.Println(err)
-				}
-			}
+			d.processBlock(trace.ContextWithSpanContext(context.Background(), b.span), b)
 		}
 	}()
-	return &d
+	if cfg.Pending != nil {
+		go func() {
+			for tx := range cfg.Pending {
+				d.processPending(tx)
+			}
+		}()
+	}
+	return d, nil
+}
+
+// Drainer is implemented by parsers that report when they stop
+// writing, e.g. to export a consistent snapshot
+type Drainer interface {
+	// Done is closed once the block channel is closed, its last
+	// block processed and the running backfills and range jobs
+	// have returned
+	Done() <-chan struct{}
+}
+
+func (d *defaultParser) Done() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		d.work.Wait()
+		close(done)
+	}()
+	return done
 }
 
 type defaultParser struct {
-	latestBlock LatestParseBlock   // persistent store for latest block
-	txnStorage  store.Storage      // store for transactions
-	observer    *observer.Observer // subscriber list
+	chainID     int64
+	chain       string              // metrics label of chainID
+	latestBlock LatestParseBlock    // persistent store for latest block
+	txnStorage  store.Storage       // store for transactions by hash
+	addrIndex   store.Storage       // hashes of the transactions of an address
+	storeMu     sync.Mutex          // guards txnStorage, addrIndex and transferIDs
+	transferIDs map[string][]string // addresses a transfer is indexed under
+	tokStorage  store.Storage       // store for token transfers
+	tokenObs    *observer.Observer  // token transfer subscribers
+	intStorage  store.Storage       // store for internal transfers
+	internalObs *observer.Observer  // internal transfer subscribers
+	observer    *observer.Observer  // subscriber list
+	pending     *pendingPool
+	pendingObs  *observer.Observer // pending transaction subscribers
 	counter     *counter.Counter
+	analytics   *analytics
+	sinks       []BlockSink
+	watchlist   *Watchlist  // nil indexes every address
+	blocks      BlockReader // nil unless backfills are served
+	maxBackfill int64
+	backfilling atomic.Bool // a backfill is running
+	// work counts the goroutines writing to the stores: the
+	// block loop, backfills and range jobs
+	work sync.WaitGroup
+	// indexedBlocks are the blocks processed or indexed by a
+	// range job, each indexed once
+	indexedBlocks blockSet
+	rangeMu       sync.Mutex
+	rangeJob      *rangeJob // latest range indexing job
+	logger        *slog.Logger
+}
+
+func (d *defaultParser) processBlock(ctx context.Context, b BlockTxn) {
+	ctx, span := tracer.Start(ctx, "eth.processBlock", trace.WithAttributes(
+		attribute.String("eth.block", b.BlockNum),
+		attribute.Int("eth.transactions", len(b.Txns)),
+	))
+	defer span.End()
+
+	if compareBlocks(d.latestBlock.Get(), b.BlockNum) >= 0 {
+		d.logger.Debug("block already processed", "block", b.BlockNum)
+		span.SetAttributes(attribute.Bool("eth.duplicate", true))
+		return
+	}
+	if n, err := strconv.ParseInt(b.BlockNum, 10, 64); err == nil && !d.indexedBlocks.claim(n) {
+		d.logger.Debug("block already indexed", "block", b.BlockNum)
+		span.SetAttributes(attribute.Bool("eth.duplicate", true))
+		return
+	}
+
+	// Pending transactions are tracked whether indexed or not, so
+	// they settle against the whole block
+	txns := b.Txns
+	b = d.indexBlock(ctx, b, d.indexed, true)
+	// Only advance once the whole block is stored
+	d.latestBlock.Update(b.BlockNum)
+	d.settlePending(txns)
+
+	metrics.BlocksProcessed.WithLabelValues(d.chain).Inc()
+	metrics.TransactionsProcessed.WithLabelValues(d.chain).Add(float64(len(b.Txns)))
+	metrics.StorageKeys.WithLabelValues(d.chain).Set(float64(d.counter.Len()))
+	if n, err := strconv.ParseInt(b.BlockNum, 10, 64); err == nil {
+		metrics.SetParsedBlock(d.chainID, n)
+	}
+	d.logger.Info("processed block", "block", b.BlockNum, "transactions", len(b.Txns))
+
+	if len(d.sinks) == 0 {
+		return
+	}
+	_, sinkSpan := tracer.Start(ctx, "eth.publishSinks")
+	defer sinkSpan.End()
+	for _, s := range d.sinks {
+		if err := s.PublishBlock(b); err != nil {
+			sinkSpan.RecordError(err)
+			d.logger.Error("publish block", "block", b.BlockNum, "err", err)
+		}
+	}
+}
+
+// indexBlock stores the transactions of b under the addresses
+// match accepts, and their token and internal transfers under the
+// addresses of the transfers match accepts, and publishes them if
+// publish is set. It returns b with only the transactions stored.
+func (d *defaultParser) indexBlock(ctx context.Context, b BlockTxn, match func(address string) bool, publish bool) BlockTxn {
+	matched := make([]Transaction, 0, len(b.Txns))
+	for _, tx := range b.Txns {
+		// A token payment or contract payout to an address is
+		// indexed though the transaction is not
+		d.processTransfers(ctx, tx, b.Tokens, match, publish)
+		d.processInternal(ctx, tx, match, publish)
+
+		var addrs []string
+		for _, addr := range []string{tx.From, tx.To} {
+			if match(addr) {
+				addrs = append(addrs, addr)
+			}
+		}
+		if len(addrs) == 0 {
+			continue
+		}
+		txMarshal, err := json.Marshal(tx)
+		if err != nil {
+			d.logger.Error("encode transaction", "block", b.BlockNum, "hash", tx.Hash, "err", err)
+			continue
+		}
+
+		added, err := d.storeTxn(ctx, tx, txMarshal, addrs)
+		if err != nil {
+			d.logger.Error("store transaction", "block", b.BlockNum, "hash", tx.Hash, "err", err)
+		}
+		// A transaction already indexed under every address is
+		// not processed again
+		if len(added) == 0 {
+			continue
+		}
+		matched = append(matched, tx)
+		metrics.StorageValues.WithLabelValues(d.chain).Add(float64(len(added)))
+		for _, addr := range added {
+			d.analytics.recordTxn(addr, tx, b.Time)
+		}
+		// Publish after storing so a resuming subscriber
+		// replaying from storage cannot miss it
+		if publish {
+			d.observer.Publish(txTopics(tx), txMarshal)
+		}
+	}
+	b.Txns = matched
+	return b
+}
+
+// indexed reports whether the parser indexes address
+func (d *defaultParser) indexed(address string) bool {
+	return d.watchlist == nil || d.watchlist.Contains(address)
+}
+
+// processTransfers indexes the token transfers of a transaction
+// by sender and recipient, leaving out the zero address of mints
+// and burns. A transfer is indexed under an address once.
+func (d *defaultParser) processTransfers(ctx context.Context, tx Transaction, tokens map[string]TokenMetadata, match func(string) bool, publish bool) {
+	tokStorage := store.Traced(ctx, d.tokStorage)
+	for _, t := range DecodeTransfers(tx) {
+		t.TokenMetadata = tokens[t.Token]
+		msg, err := json.Marshal(t)
+		if err != nil {
+			d.logger.Error("encode token transfer", "hash", tx.Hash, "log", t.LogIndex, "err", err)
+			continue
+		}
+		var addrs []string
+		for _, addr := range t.Addresses() {
+			if addr != zeroAddress && match(addr) && !slices.Contains(addrs, addr) {
+				addrs = append(addrs, addr)
+			}
+		}
+		id := fmt.Sprintf("token:%s:%s:%d", strings.ToLower(tx.Hash), t.LogIndex, t.BatchIndex)
+		var topics []string
+		for _, addr := range d.claimTransfer(id, addrs) {
+			if err := tokStorage.Append(addr, msg); err != nil {
+				d.logger.Error("store token transfer", "address", addr, "hash", tx.Hash, "err", err)
+				continue
+			}
+			topics = append(topics, addr)
+		}
+		if len(topics) == 0 {
+			continue
+		}
+		if publish {
+			d.tokenObs.Publish(topics, msg)
+		}
+		metrics.TokenTransfersProcessed.WithLabelValues(d.chain, t.Standard).Inc()
+	}
+}
+
+func (d *defaultParser) GetTokenTransfers(ctx context.Context, address string) []TokenTransfer {
+	entries, err := store.Traced(ctx, d.tokStorage).Get(strings.ToLower(address))
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		d.logger.Error("get token transfers", "address", address, "err", err)
+		return nil
+	}
+	var transfers []TokenTransfer
+	for _, e := range entries {
+		var t TokenTransfer
+		if err := json.Unmarshal(e, &t); err != nil {
+			continue
+		}
+		transfers = append(transfers, t)
+	}
+	return transfers
+}
+
+func (d *defaultParser) SubscribeTokenTransfers(address string, opts observer.Options) *observer.Subscription {
+	return d.tokenObs.SubscribeWithOptions(strings.ToLower(address), opts)
+}
+
+// processInternal indexes the internal transfers of a
+// transaction by sender and recipient
+func (d *defaultParser) processInternal(ctx context.Context, tx Transaction, match func(string) bool, publish bool) {
+	intStorage := store.Traced(ctx, d.intStorage)
+	for _, t := range tx.Internal {
+		msg, err := json.Marshal(t)
+		if err != nil {
+			d.logger.Error("encode internal transfer", "hash", tx.Hash, "index", t.Index, "err", err)
+			continue
+		}
+		var addrs []string
+		for _, addr := range t.Addresses() {
+			if addr != "" && match(addr) && !slices.Contains(addrs, addr) {
+				addrs = append(addrs, addr)
+			}
+		}
+		id := fmt.Sprintf("internal:%s:%d", strings.ToLower(tx.Hash), t.Index)
+		var topics []string
+		for _, addr := range d.claimTransfer(id, addrs) {
+			if err := intStorage.Append(addr, msg); err != nil {
+				d.logger.Error("store internal transfer", "address", addr, "hash", tx.Hash, "err", err)
+				continue
+			}
+			topics = append(topics, addr)
+			d.analytics.recordInternal(addr, t)
+		}
+		if len(topics) == 0 {
+			continue
+		}
+		if publish {
+			d.internalObs.Publish(topics, msg)
+		}
+		metrics.InternalTransfersProcessed.WithLabelValues(d.chain, strconv.FormatBool(t.Failed)).Inc()
+	}
+}
+
+func (d *defaultParser) GetInternalTransfers(ctx context.Context, address string) []InternalTransfer {
+	entries, err := store.Traced(ctx, d.intStorage).Get(strings.ToLower(address))
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		d.logger.Error("get internal transfers", "address", address, "err", err)
+		return nil
+	}
+	var transfers []InternalTransfer
+	for _, e := range entries {
+		var t InternalTransfer
+		if err := json.Unmarshal(e, &t); err != nil {
+			continue
+		}
+		transfers = append(transfers, t)
+	}
+	return transfers
+}
+
+func (d *defaultParser) SubscribeInternalTransfers(address string, opts observer.Options) *observer.Subscription {
+	return d.internalObs.SubscribeWithOptions(strings.ToLower(address), opts)
+}
+
+// processPending tracks a mempool transaction of a watched
+// address and publishes it
+func (d *defaultParser) processPending(tx Transaction) {
+	if !d.watched(tx.From) && !d.watched(tx.To) {
+		return
+	}
+	pt, ok := d.pending.add(tx, time.Now())
+	if !ok {
+		return
+	}
+	d.publishPending(pt)
+	metrics.PendingTransactions.WithLabelValues(d.chain).Set(float64(d.pending.len()))
+}
+
+func (d *defaultParser) watched(address string) bool {
+	if address == "" {
+		return false
+	}
+	address = strings.ToLower(address)
+	if d.watchlist != nil && d.watchlist.Contains(address) {
+		return true
+	}
+	return d.counter.Get(address) > 0 || d.pendingObs.Subscribed(address)
+}
+
+// settlePending publishes the outcome of the pending transactions
+// a block accounts for, and of those that expired
+func (d *defaultParser) settlePending(txns []Transaction) {
+	var settled []PendingTransaction
+	for _, tx := range txns {
+		settled = append(settled, d.pending.settle(tx)...)
+	}
+	settled = append(settled, d.pending.expire(time.Now())...)
+	for _, pt := range settled {
+		d.publishPending(pt)
+	}
+	metrics.PendingTransactions.WithLabelValues(d.chain).Set(float64(d.pending.len()))
+}
+
+func (d *defaultParser) publishPending(pt PendingTransaction) {
+	msg, err := json.Marshal(pt)
+	if err != nil {
+		d.logger.Error("encode pending transaction", "hash", pt.Hash, "err", err)
+		return
+	}
+	d.logger.Debug("pending transaction", "hash", pt.Hash, "status", pt.Status)
+	d.pendingObs.Publish(txTopics(pt.Transaction), msg)
+}
+
+func (d *defaultParser) SubscribePending(address string, opts observer.Options) *observer.Subscription {
+	return d.pendingObs.SubscribeWithOptions(strings.ToLower(address), opts)
+}
+
+func (d *defaultParser) GetPending(address string) []PendingTransaction {
+	return d.pending.list(address)
 }
 
 func (d *defaultParser) GetCurrentBlock() string {
 	return d.latestBlock.Get()
 }
 
-func (d *defaultParser) Subscribe(address string) *observer.Subscription {
-	return d.observer.Subscribe(address)
+func (d *defaultParser) Subscribe(address string, opts observer.Options) *observer.Subscription {
+	return d.observer.SubscribeWithOptions(strings.ToLower(address), opts)
 }
 
-func (d *defaultParser) GetTransactions(address string) []Transaction {
-	txs, err := d.txnStorage.Get(address)
+func (d *defaultParser) SubscribeFilter(filter Filter, opts observer.Options) *observer.Subscription {
+	return d.observer.SubscribeTopics(filter.topics(), filter.observerFilter(), opts)
+}
+
+// txTopics returns the observer topics a transaction is
+// published under
+func txTopics(tx Transaction) []string {
+	topics := []string{strings.ToLower(tx.From)}
+	if tx.To != "" {
+		topics = append(topics, strings.ToLower(tx.To))
+	}
+	return topics
+}
+
+func (d *defaultParser) GetTransactions(ctx context.Context, address string) []Transaction {
+	ctx, span := tracer.Start(ctx, "eth.GetTransactions", trace.WithAttributes(attribute.String("eth.address", address)))
+	defer span.End()
+
+	txs, err := d.addressTxns(ctx, address)
+	if errors.Is(err, store.ErrKeyNotFound) {
+		return nil
+	}
 	if err != nil {
-		log.Println(err)
+		d.logger.Error("get transactions", "address", address, "err", err)
 		return nil
 	}
 
+	_, decode := tracer.Start(ctx, "eth.decodeTransactions")
+	defer decode.End()
 	var txns []Transaction
 	for _, tx := range txs {
 		var t Transaction
@@ -97,8 +535,8 @@ func (d *defaultParser) GetTransactions(address string) []Transaction {
 	return txns
 }
 
-func (d *defaultParser) GetAddresses() []string {
-	return d.txnStorage.Keys()
+func (d *defaultParser) GetAddresses(ctx context.Context) []string {
+	return store.Traced(ctx, d.addrIndex).Keys()
 }
 
 func (d *defaultParser) GetCount(address string) int64 {
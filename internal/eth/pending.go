@@ -0,0 +1,171 @@
+package eth
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Pending transaction statuses
+const (
+	// PendingStatusPending is a transaction seen in the mempool
+	PendingStatusPending = "pending"
+	// PendingStatusMined is a pending transaction included in a block
+	PendingStatusMined = "mined"
+	// PendingStatusReplaced is a pending transaction whose nonce was
+	// used by another transaction of the same sender
+	PendingStatusReplaced = "replaced"
+	// PendingStatusDropped is a pending transaction that left the
+	// mempool without being mined
+	PendingStatusDropped = "dropped"
+)
+
+// Pending transaction tracking defaults
+const (
+	DefaultPendingTTL = 30 * time.Minute
+	DefaultMaxPending = 10000
+)
+
+// PendingTransaction is a transaction seen in the mempool and
+// the latest known outcome of it. Once mined, Transaction holds
+// the mined copy.
+type PendingTransaction struct {
+	Transaction
+	Status string `json:"status"`
+	// ReplacedBy is the hash of the mined transaction that took
+	// its nonce
+	ReplacedBy string    `json:"replacedBy,omitempty"`
+	SeenAt     time.Time `json:"seenAt"`
+}
+
+// pendingPool holds the in-flight transactions until they are
+// mined, replaced or expire
+type pendingPool struct {
+	sync.Mutex
+	byHash   map[string]*PendingTransaction
+	bySender map[string]map[string]bool
+	ttl      time.Duration
+	max      int
+}
+
+func newPendingPool(ttl time.Duration, max int) *pendingPool {
+	if ttl <= 0 {
+		ttl = DefaultPendingTTL
+	}
+	if max <= 0 {
+		max = DefaultMaxPending
+	}
+	return &pendingPool{
+		byHash:   map[string]*PendingTransaction{},
+		bySender: map[string]map[string]bool{},
+		ttl:      ttl,
+		max:      max,
+	}
+}
+
+// add tracks tx and reports whether it is new. A full pool
+// ignores new transactions.
+func (p *pendingPool) add(tx Transaction, now time.Time) (PendingTransaction, bool) {
+	p.Lock()
+	defer p.Unlock()
+	if _, ok := p.byHash[tx.Hash]; ok || len(p.byHash) >= p.max {
+		return PendingTransaction{}, false
+	}
+	pt := &PendingTransaction{Transaction: tx, Status: PendingStatusPending, SeenAt: now}
+	p.byHash[tx.Hash] = pt
+	sender := strings.ToLower(tx.From)
+	if p.bySender[sender] == nil {
+		p.bySender[sender] = map[string]bool{}
+	}
+	p.bySender[sender][tx.Hash] = true
+	return *pt, true
+}
+
+// settle resolves the pending transactions a mined transaction
+// accounts for: itself, or those of its sender with a nonce it
+// used or skipped past
+func (p *pendingPool) settle(mined Transaction) []PendingTransaction {
+	p.Lock()
+	defer p.Unlock()
+	sender := strings.ToLower(mined.From)
+	nonce, ok := parseNonce(mined.Nonce)
+	var settled []PendingTransaction
+	for hash := range p.bySender[sender] {
+		pt := p.byHash[hash]
+		switch n, known := parseNonce(pt.Nonce); {
+		case hash == mined.Hash:
+			pt.Transaction = mined
+			pt.Status = PendingStatusMined
+		case ok && known && n == nonce:
+			pt.Status = PendingStatusReplaced
+			pt.ReplacedBy = mined.Hash
+		case ok && known && n < nonce:
+			pt.Status = PendingStatusDropped
+		default:
+			continue
+		}
+		settled = append(settled, *pt)
+		p.remove(hash)
+	}
+	return settled
+}
+
+// expire drops the transactions pending for longer than the TTL
+func (p *pendingPool) expire(now time.Time) []PendingTransaction {
+	p.Lock()
+	defer p.Unlock()
+	var expired []PendingTransaction
+	for hash, pt := range p.byHash {
+		if now.Sub(pt.SeenAt) < p.ttl {
+			continue
+		}
+		pt.Status = PendingStatusDropped
+		expired = append(expired, *pt)
+		p.remove(hash)
+	}
+	return expired
+}
+
+func (p *pendingPool) remove(hash string) {
+	pt, ok := p.byHash[hash]
+	if !ok {
+		return
+	}
+	delete(p.byHash, hash)
+	sender := strings.ToLower(pt.From)
+	delete(p.bySender[sender], hash)
+	if len(p.bySender[sender]) == 0 {
+		delete(p.bySender, sender)
+	}
+}
+
+// list returns the transactions pending from or to an address,
+// oldest first
+func (p *pendingPool) list(address string) []PendingTransaction {
+	p.Lock()
+	defer p.Unlock()
+	address = strings.ToLower(address)
+	txns := []PendingTransaction{}
+	for _, pt := range p.byHash {
+		if strings.ToLower(pt.From) == address || strings.ToLower(pt.To) == address {
+			txns = append(txns, *pt)
+		}
+	}
+	slices.SortFunc(txns, func(a, b PendingTransaction) int {
+		return a.SeenAt.Compare(b.SeenAt)
+	})
+	return txns
+}
+
+func (p *pendingPool) len() int {
+	p.Lock()
+	defer p.Unlock()
+	return len(p.byHash)
+}
+
+func parseNonce(nonce string) (uint64, bool) {
+	n, err := strconv.ParseUint(strings.TrimPrefix(nonce, "0x"), 16, 64)
+	return n, err == nil
+}
@@ -0,0 +1,129 @@
+package eth
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"paulwizviz/go-eth-app/internal/observer"
+	"testing"
+	"time"
+)
+
+func TestPendingTransactions(t *testing.T) {
+	const alice, bob = "0x00000000000000000000000000000000000000aa", "0x00000000000000000000000000000000000000bb"
+	blocks := make(chan BlockTxn)
+	defer close(blocks)
+	pending := make(chan Transaction)
+	defer close(pending)
+	p, err := NewParser(blocks, ParserConfig{Pending: pending})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub := p.SubscribePending(alice, observer.Options{Buffer: 8})
+	defer sub.Unsubscribe()
+	next := func() PendingTransaction {
+		t.Helper()
+		select {
+		case msg := <-sub.Ch:
+			var pt PendingTransaction
+			if err := json.Unmarshal(msg, &pt); err != nil {
+				t.Fatal(err)
+			}
+			return pt
+		case <-time.After(time.Second):
+			t.Fatal("expected a pending event")
+			return PendingTransaction{}
+		}
+	}
+
+	pending <- Transaction{Hash: "0x1", From: alice, To: bob, Nonce: "0x1"}
+	pending <- Transaction{Hash: "0x2", From: alice, To: bob, Nonce: "0x2"}
+	pending <- Transaction{Hash: "0x3", From: bob, To: "0x00000000000000000000000000000000000000cc", Nonce: "0x7"}
+	for _, hash := range []string{"0x1", "0x2"} {
+		if pt := next(); pt.Hash != hash || pt.Status != PendingStatusPending {
+			t.Errorf("expected %s pending; got %+v", hash, pt)
+		}
+	}
+	if got := p.GetPending(bob); len(got) != 2 {
+		t.Errorf("expected the 2 pending transactions of the watched address only; got %+v", got)
+	}
+
+	// 0x1 is mined and 0x2 loses its nonce to a speed-up
+	blocks <- BlockTxn{BlockNum: "5", Txns: []Transaction{
+		{Hash: "0x1", From: alice, To: bob, Nonce: "0x1", Block: "0x5"},
+		{Hash: "0x9", From: alice, To: bob, Nonce: "0x2", Block: "0x5"},
+	}}
+	outcomes := map[string]PendingTransaction{}
+	for range 2 {
+		pt := next()
+		outcomes[pt.Hash] = pt
+	}
+	if pt := outcomes["0x1"]; pt.Status != PendingStatusMined || pt.Block != "0x5" {
+		t.Errorf("expected 0x1 mined in block 5; got %+v", pt)
+	}
+	if pt := outcomes["0x2"]; pt.Status != PendingStatusReplaced || pt.ReplacedBy != "0x9" {
+		t.Errorf("expected 0x2 replaced by 0x9; got %+v", pt)
+	}
+	if got := p.GetPending(alice); len(got) != 0 {
+		t.Errorf("expected nothing in flight; got %+v", got)
+	}
+}
+
+func TestPendingTransactionsUnwatched(t *testing.T) {
+	const alice, bob = "0x00000000000000000000000000000000000000aa", "0x00000000000000000000000000000000000000bb"
+	watchlist, err := NewWatchlist(filepath.Join(t.TempDir(), "watchlist"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	watchlist.Add("0x00000000000000000000000000000000000000cc")
+	blocks := make(chan BlockTxn)
+	defer close(blocks)
+	pending := make(chan Transaction)
+	defer close(pending)
+	p, err := NewParser(blocks, ParserConfig{Pending: pending, Watchlist: watchlist})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// alice is not indexed, yet her pending transaction settles
+	// against the block that mines it
+	sub := p.SubscribePending(alice, observer.Options{Buffer: 8})
+	defer sub.Unsubscribe()
+	pending <- Transaction{Hash: "0x1", From: alice, To: bob, Nonce: "0x1"}
+	blocks <- BlockTxn{BlockNum: "5", Txns: []Transaction{{Hash: "0x1", From: alice, To: bob, Nonce: "0x1", Block: "0x5"}}}
+	var statuses []string
+	for range 2 {
+		select {
+		case msg := <-sub.Ch:
+			var pt PendingTransaction
+			json.Unmarshal(msg, &pt)
+			statuses = append(statuses, pt.Status)
+		case <-time.After(time.Second):
+			t.Fatalf("expected 2 pending events; got %v", statuses)
+		}
+	}
+	if statuses[1] != PendingStatusMined {
+		t.Errorf("expected 0x1 mined; got %v", statuses)
+	}
+	if got := p.GetPending(alice); len(got) != 0 {
+		t.Errorf("expected nothing in flight; got %+v", got)
+	}
+}
+
+func TestPendingPoolExpire(t *testing.T) {
+	pool := newPendingPool(time.Minute, 1)
+	now := time.Now()
+	if _, ok := pool.add(Transaction{Hash: "0x1", From: "0xa", Nonce: "0x0"}, now); !ok {
+		t.Fatal("expected the transaction to be tracked")
+	}
+	if _, ok := pool.add(Transaction{Hash: "0x2", From: "0xa", Nonce: "0x1"}, now); ok {
+		t.Error("expected a full pool to ignore the transaction")
+	}
+	if expired := pool.expire(now.Add(30 * time.Second)); len(expired) != 0 {
+		t.Errorf("expected nothing to expire yet; got %+v", expired)
+	}
+	expired := pool.expire(now.Add(time.Minute))
+	if len(expired) != 1 || expired[0].Status != PendingStatusDropped || pool.len() != 0 {
+		t.Errorf("expected 0x1 dropped; got %+v", expired)
+	}
+}
@@ -0,0 +1,174 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Receipt statuses
+const (
+	ReceiptStatusSuccess = "0x1"
+	ReceiptStatusFailure = "0x0"
+)
+
+const (
+	methodBlockReceipts      = "eth_getBlockReceipts"
+	methodTransactionReceipt = "eth_getTransactionReceipt"
+
+	// receiptConcurrency bounds the per transaction requests
+	receiptConcurrency = 8
+)
+
+var (
+	errUnmarshalReceipt = errors.New("unmarshal receipt error")
+	errMissingReceipt   = errors.New("missing receipt")
+)
+
+// Receipt is the outcome of a mined transaction
+type Receipt struct {
+	// Status is ReceiptStatusSuccess or ReceiptStatusFailure
+	Status            string `json:"status"`
+	GasUsed           string `json:"gasUsed"`
+	EffectiveGasPrice string `json:"effectiveGasPrice"`
+	// Fee is GasUsed times EffectiveGasPrice, in wei
+	Fee string `json:"fee"`
+	// ContractAddress is the contract a creation deployed
+	ContractAddress string `json:"contractAddress,omitempty"`
+	Logs            []Log  `json:"logs"`
+}
+
+// Log is an event emitted by a transaction
+type Log struct {
+	Address  string   `json:"address"`
+	Topics   []string `json:"topics"`
+	Data     string   `json:"data"`
+	LogIndex string   `json:"logIndex"`
+}
+
+// rpcReceipt is a receipt as returned by the node
+type rpcReceipt struct {
+	TransactionHash string `json:"transactionHash"`
+	Receipt
+}
+
+// receiptFetcher attaches receipts to the transactions of a
+// block. It asks for the receipts of a block at once until the
+// node reports eth_getBlockReceipts is not served, then falls
+// back to a request per transaction.
+type receiptFetcher struct {
+	client *http.Client
+	url    string
+	perTxn atomic.Bool
+}
+
+func newReceiptFetcher(client *http.Client, url string) *receiptFetcher {
+	return &receiptFetcher{client: client, url: url}
+}
+
+// attach sets the receipt of every transaction in txns
+func (f *receiptFetcher) attach(ctx context.Context, blockNumber *big.Int, txns []Transaction) error {
+	if len(txns) == 0 {
+		return nil
+	}
+	if !f.perTxn.Load() {
+		receipts, err := f.blockReceipts(ctx, blockNumber)
+		var rpcErr *rpcError
+		switch {
+		case err == nil:
+			return setReceipts(txns, receipts)
+		case errors.As(err, &rpcErr) && rpcErr.Code == codeMethodNotFound:
+			f.perTxn.Store(true)
+		default:
+			return err
+		}
+	}
+
+	receipts := make([]rpcReceipt, len(txns))
+	errs := make([]error, len(txns))
+	sem := make(chan struct{}, receiptConcurrency)
+	var wg sync.WaitGroup
+	for i, tx := range txns {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			receipts[i], errs[i] = f.txnReceipt(ctx, tx.Hash)
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return setReceipts(txns, receipts)
+}
+
+func (f *receiptFetcher) blockReceipts(ctx context.Context, blockNumber *big.Int) ([]rpcReceipt, error) {
+	rpcResp, err := postRPC(ctx, f.client, f.url, methodBlockReceipts, []any{fmt.Sprintf("0x%x", blockNumber)})
+	if err != nil {
+		return nil, err
+	}
+	var receipts []rpcReceipt
+	if err := json.Unmarshal(rpcResp.Result, &receipts); err != nil {
+		return nil, fmt.Errorf("%w-%v", errUnmarshalReceipt, err)
+	}
+	return receipts, nil
+}
+
+func (f *receiptFetcher) txnReceipt(ctx context.Context, hash string) (rpcReceipt, error) {
+	rpcResp, err := postRPC(ctx, f.client, f.url, methodTransactionReceipt, []any{hash})
+	if err != nil {
+		return rpcReceipt{}, err
+	}
+	var receipt *rpcReceipt
+	if err := json.Unmarshal(rpcResp.Result, &receipt); err != nil {
+		return rpcReceipt{}, fmt.Errorf("%w-%v", errUnmarshalReceipt, err)
+	}
+	if receipt == nil {
+		return rpcReceipt{}, fmt.Errorf("%w-%s", errMissingReceipt, hash)
+	}
+	return *receipt, nil
+}
+
+// setReceipts matches receipts to txns by hash and computes fees.
+// Unless every transaction has a receipt, none is set.
+func setReceipts(txns []Transaction, receipts []rpcReceipt) error {
+	byHash := make(map[string]Receipt, len(receipts))
+	for _, r := range receipts {
+		r.Fee = fee(r.GasUsed, r.EffectiveGasPrice)
+		if r.Logs == nil {
+			r.Logs = []Log{}
+		}
+		byHash[strings.ToLower(r.TransactionHash)] = r.Receipt
+	}
+	for _, tx := range txns {
+		if _, ok := byHash[strings.ToLower(tx.Hash)]; !ok {
+			return fmt.Errorf("%w-%s", errMissingReceipt, tx.Hash)
+		}
+	}
+	for i := range txns {
+		r := byHash[strings.ToLower(txns[i].Hash)]
+		txns[i].Receipt = &r
+	}
+	return nil
+}
+
+// fee returns gasUsed times gasPrice in hex, or "" if either is
+// not a hex quantity
+func fee(gasUsed, gasPrice string) string {
+	used, ok := new(big.Int).SetString(strings.TrimPrefix(gasUsed, "0x"), 16)
+	if !ok {
+		return ""
+	}
+	price, ok := new(big.Int).SetString(strings.TrimPrefix(gasPrice, "0x"), 16)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("0x%x", used.Mul(used, price))
+}
@@ -0,0 +1,79 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestReceiptFetcher(t *testing.T) {
+	receipts := map[string]map[string]any{
+		"0xa": {"transactionHash": "0xa", "status": "0x1", "gasUsed": "0x5208", "effectiveGasPrice": "0x3b9aca00", "contractAddress": nil,
+			"logs": []map[string]any{{"address": "0xt", "topics": []string{"0xddf2"}, "data": "0x01", "logIndex": "0x0"}}},
+		"0xb": {"transactionHash": "0xb", "status": "0x0", "gasUsed": "0x10", "effectiveGasPrice": "0x2", "contractAddress": "0xc", "logs": []any{}},
+	}
+	tests := []struct {
+		name          string
+		blockReceipts bool
+		want          []string
+	}{
+		{name: "block receipts", blockReceipts: true, want: []string{methodBlockReceipts}},
+		{name: "per transaction", want: []string{methodBlockReceipts, methodTransactionReceipt, methodTransactionReceipt}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var methods []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Method string `json:"method"`
+					Params []any  `json:"params"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				mu.Lock()
+				methods = append(methods, req.Method)
+				mu.Unlock()
+				resp := map[string]any{"jsonrpc": "2.0", "id": 1}
+				switch {
+				case req.Method == methodBlockReceipts && tt.blockReceipts:
+					resp["result"] = []any{receipts["0xb"], receipts["0xa"]}
+				case req.Method == methodBlockReceipts:
+					resp["error"] = map[string]any{"code": codeMethodNotFound, "message": "the method eth_getBlockReceipts does not exist"}
+				default:
+					resp["result"] = receipts[req.Params[0].(string)]
+				}
+				json.NewEncoder(w).Encode(resp)
+			}))
+			defer srv.Close()
+
+			f := newReceiptFetcher(srv.Client(), srv.URL)
+			txns := []Transaction{{Hash: "0xa"}, {Hash: "0xb"}}
+			if err := f.attach(context.Background(), big.NewInt(5), txns); err != nil {
+				t.Fatal(err)
+			}
+			a, b := txns[0].Receipt, txns[1].Receipt
+			if a == nil || a.Status != ReceiptStatusSuccess || a.Fee != "0x1319718a5000" || len(a.Logs) != 1 || a.ContractAddress != "" {
+				t.Errorf("unexpected receipt of 0xa %+v", a)
+			}
+			if b == nil || b.Status != ReceiptStatusFailure || b.Fee != "0x20" || b.ContractAddress != "0xc" {
+				t.Errorf("unexpected receipt of 0xb %+v", b)
+			}
+			if len(methods) != len(tt.want) {
+				t.Errorf("expected calls %v; got %v", tt.want, methods)
+			}
+
+			// The fallback is remembered
+			if !tt.blockReceipts {
+				methods = nil
+				f.attach(context.Background(), big.NewInt(6), []Transaction{{Hash: "0xa"}})
+				if len(methods) != 1 || methods[0] != methodTransactionReceipt {
+					t.Errorf("expected a receipt request only; got %v", methods)
+				}
+			}
+		})
+	}
+}
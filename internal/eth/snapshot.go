@@ -0,0 +1,303 @@
+package eth
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"paulwizviz/go-eth-app/internal/metrics"
+	"paulwizviz/go-eth-app/internal/store"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// SnapshotVersion is the version of the snapshot
+// format written by ExportSnapshot
+const SnapshotVersion = 2
+
+const (
+	snapshotRecordCount = "count"
+	snapshotRecordEntry = "entry"
+	snapshotRecordStats = "stats"
+	// a counter of the analytics, by name
+	snapshotRecordCounter = "counter"
+	// a token or internal transfer stored for an address
+	snapshotRecordToken    = "token"
+	snapshotRecordInternal = "internal"
+	// the addresses a transfer is indexed under, by transfer ID
+	snapshotRecordTransfer = "transfer"
+)
+
+var (
+	ErrExportSnapshot      = errors.New("export snapshot error")
+	ErrImportSnapshot      = errors.New("import snapshot error")
+	ErrSnapshotVersion     = errors.New("unsupported snapshot version")
+	ErrSnapshotRecordType  = errors.New("unknown snapshot record type")
+	ErrSnapshotMissingHead = errors.New("snapshot header missing")
+)
+
+// Snapshotter is implemented by parsers that can dump
+// and restore their state.
+//
+// A snapshot is a gzip compressed JSON-lines stream. The
+// first line is a SnapshotHeader and every following line
+// is a SnapshotRecord.
+type Snapshotter interface {
+	ExportSnapshot(w io.Writer) error
+	ImportSnapshot(r io.Reader) error
+}
+
+// SnapshotHeader is the first line of a snapshot
+type SnapshotHeader struct {
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"createdAt"`
+	LatestBlock string    `json:"latestBlock"`
+	// Blocks are the blocks indexed, so a range job does not
+	// index them again
+	Blocks []BlockRange `json:"blocks,omitempty"`
+}
+
+// SnapshotRecord is either a per-address count, a stored
+// transaction or transfer of an address, the addresses of a
+// transfer, the analytics of an address or a counter of the
+// analytics
+type SnapshotRecord struct {
+	Type  string          `json:"type"`
+	Key   string          `json:"key"`
+	Count int64           `json:"count,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func (d *defaultParser) ExportSnapshot(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+
+	hdr := SnapshotHeader{
+		Version:     SnapshotVersion,
+		CreatedAt:   time.Now().UTC(),
+		LatestBlock: d.latestBlock.Get(),
+		Blocks:      d.indexedBlocks.list(),
+	}
+	if err := enc.Encode(hdr); err != nil {
+		return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+	}
+
+	for k, v := range d.counter.Counts() {
+		rec := SnapshotRecord{Type: snapshotRecordCount, Key: k, Count: v}
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+		}
+	}
+
+	for _, k := range d.addrIndex.Keys() {
+		values, err := d.addressTxns(context.Background(), k)
+		if err != nil {
+			continue
+		}
+		for _, v := range values {
+			rec := SnapshotRecord{Type: snapshotRecordEntry, Key: k, Value: v}
+			if err := enc.Encode(rec); err != nil {
+				return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+			}
+		}
+	}
+
+	for _, s := range []struct {
+		typ     string
+		storage store.Storage
+	}{{snapshotRecordToken, d.tokStorage}, {snapshotRecordInternal, d.intStorage}} {
+		for _, k := range s.storage.Keys() {
+			values, err := s.storage.Get(k)
+			if err != nil {
+				continue
+			}
+			for _, v := range values {
+				rec := SnapshotRecord{Type: s.typ, Key: k, Value: v}
+				if err := enc.Encode(rec); err != nil {
+					return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+				}
+			}
+		}
+	}
+
+	d.storeMu.Lock()
+	transfers := make(map[string][]string, len(d.transferIDs))
+	for id, addrs := range d.transferIDs {
+		transfers[id] = slices.Clone(addrs)
+	}
+	d.storeMu.Unlock()
+	for id, addrs := range transfers {
+		v, err := json.Marshal(addrs)
+		if err != nil {
+			return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+		}
+		rec := SnapshotRecord{Type: snapshotRecordTransfer, Key: id, Value: v}
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+		}
+	}
+
+	stats, err := d.analytics.export()
+	if err != nil {
+		return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+	}
+	for k, v := range stats {
+		rec := SnapshotRecord{Type: snapshotRecordStats, Key: k, Value: v}
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+		}
+	}
+	for k, c := range d.analytics.counters() {
+		v, err := json.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+		}
+		rec := SnapshotRecord{Type: snapshotRecordCounter, Key: k, Value: v}
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("%w-%v", ErrExportSnapshot, err)
+	}
+	return nil
+}
+
+func (d *defaultParser) ImportSnapshot(r io.Reader) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+	}
+	defer zr.Close()
+
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	if !scanner.Scan() {
+		return ErrSnapshotMissingHead
+	}
+	var hdr SnapshotHeader
+	if err := json.Unmarshal(scanner.Bytes(), &hdr); err != nil {
+		return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+	}
+	if hdr.Version != SnapshotVersion {
+		return fmt.Errorf("%w-%d", ErrSnapshotVersion, hdr.Version)
+	}
+
+	// Decode the whole snapshot before touching the parser
+	// state so that a corrupt file leaves it unchanged.
+	counts := map[string]int64{}
+	var keys []string
+	entries := map[string][]Transaction{}
+	var transferRecs []SnapshotRecord
+	transfers := map[string][]string{}
+	stats := map[string]*addressAnalytics{}
+	restored := newAnalytics(0)
+	counters := restored.counters()
+	for scanner.Scan() {
+		var rec SnapshotRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+		}
+		switch rec.Type {
+		case snapshotRecordCount:
+			counts[rec.Key] = rec.Count
+		case snapshotRecordEntry:
+			var tx Transaction
+			if err := json.Unmarshal(rec.Value, &tx); err != nil {
+				return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+			}
+			if _, ok := entries[rec.Key]; !ok {
+				keys = append(keys, rec.Key)
+			}
+			entries[rec.Key] = append(entries[rec.Key], tx)
+		case snapshotRecordToken, snapshotRecordInternal:
+			var v any
+			if rec.Type == snapshotRecordToken {
+				v = &TokenTransfer{}
+			} else {
+				v = &InternalTransfer{}
+			}
+			if err := json.Unmarshal(rec.Value, v); err != nil {
+				return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+			}
+			transferRecs = append(transferRecs, rec)
+		case snapshotRecordTransfer:
+			var addrs []string
+			if err := json.Unmarshal(rec.Value, &addrs); err != nil {
+				return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+			}
+			transfers[rec.Key] = addrs
+		case snapshotRecordStats:
+			var s addressAnalytics
+			if err := json.Unmarshal(rec.Value, &s); err != nil {
+				return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+			}
+			stats[rec.Key] = &s
+		case snapshotRecordCounter:
+			c, ok := counters[rec.Key]
+			if !ok {
+				return fmt.Errorf("%w-%s %s", ErrSnapshotRecordType, rec.Type, rec.Key)
+			}
+			if err := json.Unmarshal(rec.Value, c); err != nil {
+				return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+			}
+		default:
+			return fmt.Errorf("%w-%s", ErrSnapshotRecordType, rec.Type)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+	}
+
+	// Storing the entries by hash counts each transaction of
+	// an address once, superseding the count records of older
+	// snapshots that counted duplicates
+	ctx := context.Background()
+	for _, k := range keys {
+		for _, tx := range entries[k] {
+			msg, err := json.Marshal(tx)
+			if err != nil {
+				return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+			}
+			added, err := d.storeTxn(ctx, tx, msg, []string{k})
+			if err != nil {
+				return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+			}
+			metrics.StorageValues.WithLabelValues(d.chain).Add(float64(len(added)))
+		}
+	}
+	for _, rec := range transferRecs {
+		storage := d.tokStorage
+		if rec.Type == snapshotRecordInternal {
+			storage = d.intStorage
+		}
+		if err := storage.Append(rec.Key, rec.Value); err != nil {
+			return fmt.Errorf("%w-%v", ErrImportSnapshot, err)
+		}
+	}
+	d.storeMu.Lock()
+	for id, addrs := range transfers {
+		d.transferIDs[id] = addrs
+	}
+	d.storeMu.Unlock()
+	for k, v := range counts {
+		if _, ok := entries[k]; !ok {
+			d.counter.Set(k, v)
+		}
+	}
+	d.analytics.restore(stats)
+	d.analytics.restoreCounters(restored)
+	d.latestBlock.Update(hdr.LatestBlock)
+	d.indexedBlocks.add(hdr.Blocks)
+	metrics.StorageKeys.WithLabelValues(d.chain).Set(float64(d.counter.Len()))
+	if n, err := strconv.ParseInt(hdr.LatestBlock, 10, 64); err == nil && n >= 0 {
+		metrics.SetParsedBlock(d.chainID, n)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	src := make(chan BlockTxn)
+	close(src)
+	p, err := NewParser(src, ParserConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := p.(*defaultParser)
+	transfer := Transaction{
+		Hash: "0x3", From: tokenAlice, To: "0xt20", Block: "0x64",
+		Receipt: &Receipt{Logs: []Log{
+			{Address: "0xt20", Topics: []string{TopicTransfer, topic(tokenAlice), topic(tokenBob)}, Data: "0x" + word("0x64"), LogIndex: "0x0"},
+		}},
+		Internal: []InternalTransfer{
+			{Type: "CALL", From: "0xt20", To: tokenBob, Value: "0x1", Depth: 1, TransactionHash: "0x3", Block: "0x64"},
+		},
+	}
+	d.processBlock(context.Background(), BlockTxn{
+		BlockNum: "100",
+		Txns: []Transaction{
+			{Hash: "0x1", From: "0xa", To: "0xb", Block: "0x64"},
+			{Hash: "0x2", From: "0xa", To: "0xc", Block: "0x64"},
+			transfer,
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := d.ExportSnapshot(&buf); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	dst := make(chan BlockTxn)
+	close(dst)
+	restored, err := NewParser(dst, ParserConfig{Snapshot: bytes.NewReader(buf.Bytes())})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	if got := restored.GetCurrentBlock(); got != "100" {
+		t.Errorf("expected block 100; got %s", got)
+	}
+	if got := restored.GetCount("0xa"); got != 2 {
+		t.Errorf("expected count 2 for 0xa; got %d", got)
+	}
+	txns := restored.GetTransactions(context.Background(), "0xa")
+	if len(txns) != 2 || txns[0].Hash != "0x1" || txns[1].Hash != "0x2" {
+		t.Errorf("unexpected transactions for 0xa: %+v", txns)
+	}
+	if got := len(restored.GetAddresses(context.Background())); got != 5 {
+		t.Errorf("expected 5 addresses; got %d", got)
+	}
+
+	// The transfers are restored with the record of the addresses
+	// they are indexed under, so indexing them again is a no-op
+	r := restored.(*defaultParser)
+	r.processTransfers(context.Background(), transfer, nil, r.indexed, false)
+	r.processInternal(context.Background(), transfer, r.indexed, false)
+	if got := restored.GetTokenTransfers(context.Background(), tokenBob); len(got) != 1 {
+		t.Errorf("expected 1 token transfer of bob; got %+v", got)
+	}
+	if got := restored.GetInternalTransfers(context.Background(), tokenBob); len(got) != 1 {
+		t.Errorf("expected 1 internal transfer of bob; got %+v", got)
+	}
+}
+
+func TestSnapshotImportInvalid(t *testing.T) {
+	src := make(chan BlockTxn)
+	close(src)
+	_, err := NewParser(src, ParserConfig{Snapshot: bytes.NewReader([]byte("not a snapshot"))})
+	if !errors.Is(err, ErrImportSnapshot) {
+		t.Errorf("expected ErrImportSnapshot; got %v", err)
+	}
+}
+
+func TestDrainer(t *testing.T) {
+	src := make(chan BlockTxn)
+	p, err := NewParser(src, ParserConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := p.(Drainer).Done()
+	src <- BlockTxn{BlockNum: "1", Txns: []Transaction{{Hash: "0x1", From: "0xa", To: "0xb", Block: "0x1"}}}
+	select {
+	case <-done:
+		t.Fatal("done before the block channel is closed")
+	default:
+	}
+	close(src)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("not done after the block channel is closed")
+	}
+	if got := p.GetCurrentBlock(); got != "1" {
+		t.Errorf("expected block 1; got %s", got)
+	}
+}
@@ -0,0 +1,303 @@
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Token standards
+const (
+	TokenERC20   = "erc20"
+	TokenERC721  = "erc721"
+	TokenERC1155 = "erc1155"
+)
+
+// Event topics of token transfers
+const (
+	// TopicTransfer is Transfer(address,address,uint256) of
+	// ERC-20 and ERC-721
+	TopicTransfer = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	// TopicTransferSingle is ERC-1155 TransferSingle
+	TopicTransferSingle = "0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"
+	// TopicTransferBatch is ERC-1155 TransferBatch
+	TopicTransferBatch = "0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb"
+)
+
+const methodCall = "eth_call"
+
+// zeroAddress is the sender of mints and recipient of burns
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// Selectors of the token metadata functions
+const (
+	selectorName     = "0x06fdde03"
+	selectorSymbol   = "0x95d89b41"
+	selectorDecimals = "0x313ce567"
+)
+
+var errTokenCall = errors.New("token call error")
+
+// TokenMetadata describes a token contract. Fields a contract
+// does not implement are left empty.
+type TokenMetadata struct {
+	Name     string `json:"name,omitempty"`
+	Symbol   string `json:"symbol,omitempty"`
+	Decimals *int   `json:"decimals,omitempty"`
+}
+
+// TokenTransfer is a token movement decoded from a log. Amount
+// is the number of units moved, one for ERC-721; TokenID is set
+// for ERC-721 and ERC-1155. Both are hex quantities.
+type TokenTransfer struct {
+	Standard        string `json:"standard"`
+	Token           string `json:"token"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Amount          string `json:"amount"`
+	TokenID         string `json:"tokenId,omitempty"`
+	TransactionHash string `json:"transactionHash"`
+	Block           string `json:"blockNumber"`
+	LogIndex        string `json:"logIndex"`
+	// BatchIndex orders the transfers of an ERC-1155 batch
+	BatchIndex int `json:"batchIndex,omitempty"`
+	TokenMetadata
+}
+
+// DecodeTransfers returns the token transfers in the logs of a
+// transaction's receipt. Logs that are not well-formed transfers
+// are skipped.
+func DecodeTransfers(tx Transaction) []TokenTransfer {
+	if tx.Receipt == nil {
+		return nil
+	}
+	var transfers []TokenTransfer
+	for _, l := range tx.Receipt.Logs {
+		if len(l.Topics) == 0 {
+			continue
+		}
+		base := TokenTransfer{
+			Token:           strings.ToLower(l.Address),
+			TransactionHash: tx.Hash,
+			Block:           tx.Block,
+			LogIndex:        l.LogIndex,
+		}
+		words := dataWords(l.Data)
+		switch strings.ToLower(l.Topics[0]) {
+		case TopicTransfer:
+			switch {
+			case len(l.Topics) == 3 && len(words) == 1:
+				base.Standard = TokenERC20
+				base.Amount = hexWord(words[0])
+			case len(l.Topics) == 4:
+				base.Standard = TokenERC721
+				base.Amount = "0x1"
+				base.TokenID = hexWord(topicWord(l.Topics[3]))
+			default:
+				continue
+			}
+			base.From, base.To = topicAddress(l.Topics[1]), topicAddress(l.Topics[2])
+			transfers = append(transfers, base)
+		case TopicTransferSingle:
+			if len(l.Topics) != 4 || len(words) != 2 {
+				continue
+			}
+			base.Standard = TokenERC1155
+			base.From, base.To = topicAddress(l.Topics[2]), topicAddress(l.Topics[3])
+			base.TokenID, base.Amount = hexWord(words[0]), hexWord(words[1])
+			transfers = append(transfers, base)
+		case TopicTransferBatch:
+			if len(l.Topics) != 4 {
+				continue
+			}
+			ids, values, ok := decodeBatch(words)
+			if !ok {
+				continue
+			}
+			base.Standard = TokenERC1155
+			base.From, base.To = topicAddress(l.Topics[2]), topicAddress(l.Topics[3])
+			for i := range ids {
+				t := base
+				t.TokenID, t.Amount, t.BatchIndex = hexWord(ids[i]), hexWord(values[i]), i
+				transfers = append(transfers, t)
+			}
+		}
+	}
+	return transfers
+}
+
+// transferTokens returns the token contracts transferred in txns
+func transferTokens(txns []Transaction) []string {
+	var tokens []string
+	seen := map[string]bool{}
+	for _, tx := range txns {
+		for _, t := range DecodeTransfers(tx) {
+			if !seen[t.Token] {
+				seen[t.Token] = true
+				tokens = append(tokens, t.Token)
+			}
+		}
+	}
+	return tokens
+}
+
+// Addresses returns the lower case sender and recipient
+func (t TokenTransfer) Addresses() []string {
+	return []string{strings.ToLower(t.From), strings.ToLower(t.To)}
+}
+
+// dataWords splits hex ABI data into 32 byte words
+func dataWords(data string) [][]byte {
+	b, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil || len(b)%32 != 0 {
+		return nil
+	}
+	words := make([][]byte, 0, len(b)/32)
+	for i := 0; i < len(b); i += 32 {
+		words = append(words, b[i:i+32])
+	}
+	return words
+}
+
+func topicWord(topic string) []byte {
+	b, _ := hex.DecodeString(strings.TrimPrefix(topic, "0x"))
+	return b
+}
+
+func topicAddress(topic string) string {
+	b := topicWord(topic)
+	if len(b) != 32 {
+		return ""
+	}
+	return "0x" + hex.EncodeToString(b[12:])
+}
+
+func hexWord(word []byte) string {
+	return fmt.Sprintf("0x%x", new(big.Int).SetBytes(word))
+}
+
+// decodeBatch decodes the (uint256[], uint256[]) data of a
+// TransferBatch event
+func decodeBatch(words [][]byte) (ids, values [][]byte, ok bool) {
+	array := func(offset []byte) ([][]byte, bool) {
+		o := new(big.Int).SetBytes(offset)
+		if !o.IsInt64() || o.Int64()%32 != 0 {
+			return nil, false
+		}
+		i := int(o.Int64() / 32)
+		if i >= len(words) {
+			return nil, false
+		}
+		n := new(big.Int).SetBytes(words[i])
+		if !n.IsInt64() || int(n.Int64()) > len(words)-i-1 {
+			return nil, false
+		}
+		return words[i+1 : i+1+int(n.Int64())], true
+	}
+	if len(words) < 2 {
+		return nil, nil, false
+	}
+	ids, okIDs := array(words[0])
+	values, okValues := array(words[1])
+	return ids, values, okIDs && okValues && len(ids) == len(values)
+}
+
+// tokenResolver reads token metadata with eth_call and caches it,
+// including the tokens that do not implement it
+type tokenResolver struct {
+	client *http.Client
+	url    string
+	mu     sync.Mutex
+	cache  map[string]TokenMetadata
+}
+
+func newTokenResolver(client *http.Client, url string) *tokenResolver {
+	return &tokenResolver{client: client, url: url, cache: map[string]TokenMetadata{}}
+}
+
+// resolve returns the metadata of the tokens, calling the node
+// for those not yet cached
+func (r *tokenResolver) resolve(ctx context.Context, tokens []string) map[string]TokenMetadata {
+	resolved := make(map[string]TokenMetadata, len(tokens))
+	for _, token := range tokens {
+		r.mu.Lock()
+		md, ok := r.cache[token]
+		r.mu.Unlock()
+		if !ok {
+			var err error
+			md, err = r.read(ctx, token)
+			// Unreachable nodes are asked again with the next
+			// transfer of the token
+			if err != nil {
+				continue
+			}
+			r.mu.Lock()
+			r.cache[token] = md
+			r.mu.Unlock()
+		}
+		resolved[token] = md
+	}
+	return resolved
+}
+
+// read calls the metadata functions of a token. A function the
+// contract reverts is left out; any other failure is returned.
+func (r *tokenResolver) read(ctx context.Context, token string) (TokenMetadata, error) {
+	var md TokenMetadata
+	results := map[string][]byte{}
+	for _, selector := range []string{selectorName, selectorSymbol, selectorDecimals} {
+		b, err := r.call(ctx, token, selector)
+		if err != nil && !errors.Is(err, errRPC) {
+			return TokenMetadata{}, err
+		}
+		results[selector] = b
+	}
+	md.Name = abiString(results[selectorName])
+	md.Symbol = abiString(results[selectorSymbol])
+	if b := results[selectorDecimals]; len(b) == 32 {
+		if d := new(big.Int).SetBytes(b); d.IsInt64() && d.Int64() <= 255 {
+			decimals := int(d.Int64())
+			md.Decimals = &decimals
+		}
+	}
+	return md, nil
+}
+
+func (r *tokenResolver) call(ctx context.Context, token, selector string) ([]byte, error) {
+	rpcResp, err := postRPC(ctx, r.client, r.url, methodCall, []any{map[string]string{"to": token, "data": selector}, "latest"})
+	if err != nil {
+		return nil, err
+	}
+	var result string
+	if err := json.Unmarshal(rpcResp.Result, &result); err != nil {
+		return nil, fmt.Errorf("%w-%v", errTokenCall, err)
+	}
+	return hex.DecodeString(strings.TrimPrefix(result, "0x"))
+}
+
+// abiString decodes an ABI string, or the bytes32 some early
+// tokens return instead
+func abiString(b []byte) string {
+	var s []byte
+	switch {
+	case len(b) == 32:
+		s = []byte(strings.TrimRight(string(b), "\x00"))
+	case len(b) >= 64:
+		n := new(big.Int).SetBytes(b[32:64])
+		if !n.IsInt64() || int(n.Int64()) > len(b)-64 {
+			return ""
+		}
+		s = b[64 : 64+int(n.Int64())]
+	}
+	if !utf8.Valid(s) {
+		return ""
+	}
+	return string(s)
+}
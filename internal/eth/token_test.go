@@ -0,0 +1,139 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/observer"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	tokenAlice = "0x00000000000000000000000000000000000000aa"
+	tokenBob   = "0x00000000000000000000000000000000000000bb"
+)
+
+// word left pads a hex quantity or address to 32 bytes
+func word(v string) string {
+	return fmt.Sprintf("%064s", strings.TrimPrefix(v, "0x"))
+}
+
+func topic(v string) string {
+	return "0x" + word(v)
+}
+
+func TestDecodeTransfers(t *testing.T) {
+	tx := Transaction{Hash: "0x1", Block: "0x5", Receipt: &Receipt{Logs: []Log{
+		{Address: "0xT20", Topics: []string{TopicTransfer, topic(tokenAlice), topic(tokenBob)}, Data: "0x" + word("0x64"), LogIndex: "0x0"},
+		{Address: "0xt721", Topics: []string{TopicTransfer, topic(zeroAddress), topic(tokenBob), topic("0x2a")}, Data: "0x", LogIndex: "0x1"},
+		{Address: "0xt1155", Topics: []string{TopicTransferSingle, topic("0xcc"), topic(tokenAlice), topic(tokenBob)}, Data: "0x" + word("0x7") + word("0x3"), LogIndex: "0x2"},
+		{Address: "0xt1155", Topics: []string{TopicTransferBatch, topic("0xcc"), topic(tokenBob), topic(tokenAlice)},
+			Data: "0x" + word("0x40") + word("0xa0") + word("0x2") + word("0x1") + word("0x2") + word("0x2") + word("0xa") + word("0xb"), LogIndex: "0x3"},
+		{Address: "0xother", Topics: []string{"0xdead"}, Data: "0x"},
+		{Address: "0xbad", Topics: []string{TopicTransferBatch, topic("0xcc"), topic(tokenBob), topic(tokenAlice)}, Data: "0x" + word("0x40")},
+	}}}
+	got := DecodeTransfers(tx)
+	want := []TokenTransfer{
+		{Standard: TokenERC20, Token: "0xt20", From: tokenAlice, To: tokenBob, Amount: "0x64", LogIndex: "0x0"},
+		{Standard: TokenERC721, Token: "0xt721", From: zeroAddress, To: tokenBob, Amount: "0x1", TokenID: "0x2a", LogIndex: "0x1"},
+		{Standard: TokenERC1155, Token: "0xt1155", From: tokenAlice, To: tokenBob, Amount: "0x3", TokenID: "0x7", LogIndex: "0x2"},
+		{Standard: TokenERC1155, Token: "0xt1155", From: tokenBob, To: tokenAlice, Amount: "0xa", TokenID: "0x1", LogIndex: "0x3"},
+		{Standard: TokenERC1155, Token: "0xt1155", From: tokenBob, To: tokenAlice, Amount: "0xb", TokenID: "0x2", LogIndex: "0x3", BatchIndex: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d transfers; got %+v", len(want), got)
+	}
+	for i := range want {
+		want[i].TransactionHash, want[i].Block = "0x1", "0x5"
+		if got[i] != want[i] {
+			t.Errorf("transfer %d: expected %+v; got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestTokenResolver(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		var call struct{ To, Data string }
+		json.Unmarshal(req.Params[0], &call)
+		calls++
+
+		resp := map[string]any{"jsonrpc": "2.0", "id": 1}
+		switch {
+		case call.To == "0xnft" && call.Data == selectorDecimals:
+			resp["error"] = map[string]any{"code": 3, "message": "execution reverted"}
+		case call.Data == selectorName:
+			// ABI encoded string
+			resp["result"] = "0x" + word("0x20") + word("0x4") + strings.ReplaceAll(fmt.Sprintf("%-64s", "54657468"), " ", "0")
+		case call.Data == selectorSymbol:
+			// bytes32, as returned by early tokens
+			resp["result"] = "0x" + strings.ReplaceAll(fmt.Sprintf("%-64s", "55534454"), " ", "0")
+		case call.Data == selectorDecimals:
+			resp["result"] = "0x" + word("0x6")
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	r := newTokenResolver(srv.Client(), srv.URL)
+	got := r.resolve(context.Background(), []string{"0xusdt", "0xnft"})
+	if md := got["0xusdt"]; md.Name != "Teth" || md.Symbol != "USDT" || md.Decimals == nil || *md.Decimals != 6 {
+		t.Errorf("unexpected metadata %+v", md)
+	}
+	if md := got["0xnft"]; md.Decimals != nil || md.Symbol != "USDT" {
+		t.Errorf("expected no decimals for a reverting token; got %+v", md)
+	}
+
+	// Metadata is cached
+	r.resolve(context.Background(), []string{"0xusdt", "0xnft"})
+	if calls != 6 {
+		t.Errorf("expected 6 calls; got %d", calls)
+	}
+}
+
+func TestParserTokenTransfers(t *testing.T) {
+	blocks := make(chan BlockTxn)
+	defer close(blocks)
+	p := NewDefaultParser(blocks)
+	sub := p.SubscribeTokenTransfers(tokenBob, observer.Options{Buffer: 4})
+	defer sub.Unsubscribe()
+
+	decimals := 18
+	blocks <- BlockTxn{BlockNum: "5", Tokens: map[string]TokenMetadata{"0xt20": {Symbol: "TKN", Decimals: &decimals}}, Txns: []Transaction{{
+		Hash: "0x1", From: tokenAlice, To: "0xt20", Block: "0x5",
+		Receipt: &Receipt{Logs: []Log{
+			{Address: "0xt20", Topics: []string{TopicTransfer, topic(tokenAlice), topic(tokenBob)}, Data: "0x" + word("0x64"), LogIndex: "0x0"},
+			{Address: "0xt20", Topics: []string{TopicTransfer, topic(zeroAddress), topic(tokenBob)}, Data: "0x" + word("0x1"), LogIndex: "0x1"},
+		}},
+	}}}
+
+	for range 2 {
+		select {
+		case msg := <-sub.Ch:
+			var tt TokenTransfer
+			json.Unmarshal(msg, &tt)
+			if tt.To != tokenBob || tt.Symbol != "TKN" {
+				t.Errorf("unexpected transfer %+v", tt)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected a token transfer")
+		}
+	}
+	if got := p.GetTokenTransfers(context.Background(), tokenBob); len(got) != 2 || *got[0].Decimals != 18 {
+		t.Errorf("expected 2 transfers of bob; got %+v", got)
+	}
+	if got := p.GetTokenTransfers(context.Background(), tokenAlice); len(got) != 1 {
+		t.Errorf("expected 1 transfer of alice; got %+v", got)
+	}
+	if got := p.GetTokenTransfers(context.Background(), zeroAddress); len(got) != 0 {
+		t.Errorf("expected mints not indexed under the zero address; got %+v", got)
+	}
+}
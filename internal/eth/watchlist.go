@@ -0,0 +1,250 @@
+package eth
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidAddress  = errors.New("invalid address")
+	ErrLoadWatchlist   = errors.New("load watchlist error")
+	ErrSaveWatchlist   = errors.New("save watchlist error")
+	ErrNoBlockReader   = errors.New("no block reader to backfill from")
+	ErrBackfillRange   = errors.New("invalid backfill range")
+	ErrBackfill        = errors.New("backfill error")
+	ErrBackfillRunning = errors.New("backfill already running")
+)
+
+// DefaultMaxBackfill bounds the blocks of a backfill
+const DefaultMaxBackfill = 100000
+
+// Watchlist is the set of addresses a parser indexes. It is kept
+// in a file, one address per line, when created with a path.
+type Watchlist struct {
+	mu    sync.RWMutex
+	addrs map[string]bool
+	path  string
+}
+
+// NewWatchlist instantiate a watchlist, loading the addresses in
+// the file at path if it exists. An empty path keeps the
+// watchlist in memory.
+func NewWatchlist(path string) (*Watchlist, error) {
+	w := &Watchlist{addrs: map[string]bool{}, path: path}
+	if path == "" {
+		return w, nil
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return w, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w-%v", ErrLoadWatchlist, err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		addr, err := watchAddress(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w-%v", ErrLoadWatchlist, err)
+		}
+		w.addrs[addr] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w-%v", ErrLoadWatchlist, err)
+	}
+	return w, nil
+}
+
+// Contains reports whether address is watched
+func (w *Watchlist) Contains(address string) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.addrs[strings.ToLower(address)]
+}
+
+// Add watches the addresses and returns those not already
+// watched. Nothing is added if any address is invalid.
+func (w *Watchlist) Add(addresses ...string) ([]string, error) {
+	valid := make([]string, 0, len(addresses))
+	for _, a := range addresses {
+		addr, err := watchAddress(a)
+		if err != nil {
+			return nil, err
+		}
+		valid = append(valid, addr)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	added := []string{}
+	for _, addr := range valid {
+		if !w.addrs[addr] {
+			w.addrs[addr] = true
+			added = append(added, addr)
+		}
+	}
+	if len(added) == 0 {
+		return added, nil
+	}
+	if err := w.save(); err != nil {
+		for _, addr := range added {
+			delete(w.addrs, addr)
+		}
+		return nil, err
+	}
+	return added, nil
+}
+
+// Remove stops watching address and reports whether it was
+// watched. Transactions already indexed are kept.
+func (w *Watchlist) Remove(address string) (bool, error) {
+	addr := strings.ToLower(address)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.addrs[addr] {
+		return false, nil
+	}
+	delete(w.addrs, addr)
+	if err := w.save(); err != nil {
+		w.addrs[addr] = true
+		return false, err
+	}
+	return true, nil
+}
+
+// List returns the watched addresses in order
+func (w *Watchlist) List() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	addrs := make([]string, 0, len(w.addrs))
+	for addr := range w.addrs {
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs)
+	return addrs
+}
+
+// Len returns the number of watched addresses
+func (w *Watchlist) Len() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return len(w.addrs)
+}
+
+// save writes to a temporary file and renames it so a crash
+// never leaves a partial watchlist. The lock must be held.
+func (w *Watchlist) save() error {
+	if w.path == "" {
+		return nil
+	}
+	addrs := make([]string, 0, len(w.addrs))
+	for addr := range w.addrs {
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs)
+	var b strings.Builder
+	for _, addr := range addrs {
+		b.WriteString(addr)
+		b.WriteByte('\n')
+	}
+	tmp := w.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("%w-%v", ErrSaveWatchlist, err)
+	}
+	if err := os.Rename(tmp, w.path); err != nil {
+		return fmt.Errorf("%w-%v", ErrSaveWatchlist, err)
+	}
+	return nil
+}
+
+// watchAddress returns the lower case form of a hex address
+func watchAddress(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) != 42 || !strings.HasPrefix(s, "0x") {
+		return "", fmt.Errorf("%w-%s", ErrInvalidAddress, s)
+	}
+	if _, err := hex.DecodeString(s[2:]); err != nil {
+		return "", fmt.Errorf("%w-%s", ErrInvalidAddress, s)
+	}
+	return s, nil
+}
+
+// Watcher is implemented by parsers that index a watchlist
+type Watcher interface {
+	// Watchlist returns the addresses indexed, nil when every
+	// address is
+	Watchlist() *Watchlist
+	// Backfill starts indexing the transactions of the addresses
+	// in the blocks from and to, inclusive, up to the latest block
+	// processed, and returns. Later blocks are indexed as they are
+	// processed. One backfill runs at a time, until done or ctx is.
+	Backfill(ctx context.Context, addresses []string, from, to int64) error
+}
+
+func (d *defaultParser) Watchlist() *Watchlist {
+	return d.watchlist
+}
+
+func (d *defaultParser) Backfill(ctx context.Context, addresses []string, from, to int64) error {
+	if d.blocks == nil {
+		return ErrNoBlockReader
+	}
+	if from < 0 || to < from {
+		return fmt.Errorf("%w-%d to %d", ErrBackfillRange, from, to)
+	}
+	if to-from >= d.maxBackfill {
+		return fmt.Errorf("%w-%d to %d exceeds %d blocks", ErrBackfillRange, from, to, d.maxBackfill)
+	}
+	if !d.backfilling.CompareAndSwap(false, true) {
+		return ErrBackfillRunning
+	}
+	// Blocks after the latest processed are left to ingestion
+	if latest, err := strconv.ParseInt(d.latestBlock.Get(), 10, 64); err == nil && latest < to {
+		to = latest
+	}
+	only := make(map[string]bool, len(addresses))
+	for _, a := range addresses {
+		only[strings.ToLower(a)] = true
+	}
+
+	logger := d.logger.With("addresses", len(addresses), "from", from, "to", to)
+	logger.Info("backfill started")
+	d.work.Add(1)
+	go func() {
+		defer d.work.Done()
+		defer d.backfilling.Store(false)
+		if err := d.backfill(ctx, only, from, to); err != nil {
+			logger.Error("backfill failed", "err", err)
+			return
+		}
+		logger.Info("backfill done")
+	}()
+	return nil
+}
+
+// backfill indexes the transactions of the only addresses in the
+// blocks from and to
+func (d *defaultParser) backfill(ctx context.Context, only map[string]bool, from, to int64) error {
+	for n := from; n <= to; n++ {
+		b, err := d.blocks(ctx, big.NewInt(n))
+		if err != nil {
+			return fmt.Errorf("%w-block %d: %v", ErrBackfill, n, err)
+		}
+		d.indexBlock(ctx, b, func(addr string) bool { return only[strings.ToLower(addr)] }, false)
+	}
+	return nil
+}
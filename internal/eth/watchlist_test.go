@@ -0,0 +1,203 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"paulwizviz/go-eth-app/internal/observer"
+	"slices"
+	"testing"
+	"time"
+)
+
+const (
+	watchAlice = "0x00000000000000000000000000000000000000aa"
+	watchBob   = "0x00000000000000000000000000000000000000bb"
+	watchCarol = "0x00000000000000000000000000000000000000cc"
+)
+
+func TestWatchlist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watchlist.txt")
+	w, err := NewWatchlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, err := w.Add(watchAlice, "0x00000000000000000000000000000000000000AA", watchBob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(added, []string{watchAlice, watchBob}) {
+		t.Errorf("expected alice and bob added; got %v", added)
+	}
+	if _, err := w.Add(watchCarol, "0xnotanaddress"); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("expected %v; got %v", ErrInvalidAddress, err)
+	}
+	if w.Contains(watchCarol) {
+		t.Error("expected nothing added from an invalid list")
+	}
+	if removed, _ := w.Remove(watchBob); !removed {
+		t.Error("expected bob removed")
+	}
+	if removed, _ := w.Remove(watchBob); removed {
+		t.Error("expected bob no longer watched")
+	}
+
+	// The watchlist is reloaded from its file
+	reloaded, err := NewWatchlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.List(); !slices.Equal(got, []string{watchAlice}) {
+		t.Errorf("expected alice reloaded; got %v", got)
+	}
+
+	os.WriteFile(path, []byte("# customers\n"+watchBob+"\nbad\n"), 0o644)
+	if _, err := NewWatchlist(path); !errors.Is(err, ErrLoadWatchlist) {
+		t.Errorf("expected %v; got %v", ErrLoadWatchlist, err)
+	}
+}
+
+func TestParserWatchlist(t *testing.T) {
+	chain := map[int64]BlockTxn{
+		1: {BlockNum: "1", Txns: []Transaction{{Hash: "0x1", From: watchBob, To: watchCarol}}},
+		2: {BlockNum: "2", Txns: []Transaction{{Hash: "0x2", From: watchCarol, To: watchAlice}}},
+	}
+	watchlist, _ := NewWatchlist("")
+	watchlist.Add(watchAlice)
+	blocks := make(chan BlockTxn)
+	defer close(blocks)
+	p, _ := NewParser(blocks, ParserConfig{
+		Watchlist: watchlist,
+		Blocks: func(ctx context.Context, number *big.Int) (BlockTxn, error) {
+			b, ok := chain[number.Int64()]
+			if !ok {
+				return BlockTxn{}, fmt.Errorf("unknown block %d", number)
+			}
+			return b, nil
+		},
+	})
+
+	blocks <- chain[1]
+	blocks <- chain[2]
+	deadline := time.Now().Add(time.Second)
+	for p.GetCurrentBlock() != "2" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if got := p.GetTransactions(context.Background(), watchAlice); len(got) != 1 || got[0].Hash != "0x2" {
+		t.Errorf("expected the transaction of alice; got %+v", got)
+	}
+	if got := p.GetTransactions(context.Background(), watchCarol); len(got) != 0 {
+		t.Errorf("expected no transactions of an unwatched address; got %+v", got)
+	}
+	if got := p.GetCount(watchCarol); got != 0 {
+		t.Errorf("expected an unwatched address not counted; got %d", got)
+	}
+
+	// Watching carol and backfilling indexes her past
+	// transactions once
+	watchlist.Add(watchCarol)
+	w := p.(Watcher)
+	if err := w.Backfill(context.Background(), []string{watchCarol}, 1, 10); err != nil {
+		t.Fatal(err)
+	}
+	deadline = time.Now().Add(time.Second)
+	for p.GetCount(watchCarol) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := p.GetTransactions(context.Background(), watchCarol); len(got) != 2 {
+		t.Errorf("expected 2 backfilled transactions of carol; got %+v", got)
+	}
+	if got := p.GetTransactions(context.Background(), watchAlice); len(got) != 1 {
+		t.Errorf("expected alice untouched by the backfill; got %+v", got)
+	}
+	if err := w.Backfill(context.Background(), []string{watchCarol}, 2, 1); !errors.Is(err, ErrBackfillRange) {
+		t.Errorf("expected %v; got %v", ErrBackfillRange, err)
+	}
+}
+
+func TestBackfillBounded(t *testing.T) {
+	blocks := make(chan BlockTxn)
+	defer close(blocks)
+	p, _ := NewParser(blocks, ParserConfig{
+		MaxBackfill: 5,
+		// Blocks are read until the backfill is cancelled
+		Blocks: func(ctx context.Context, number *big.Int) (BlockTxn, error) {
+			<-ctx.Done()
+			return BlockTxn{}, ctx.Err()
+		},
+	})
+	w := p.(Watcher)
+
+	if err := w.Backfill(context.Background(), []string{watchCarol}, 0, 5); !errors.Is(err, ErrBackfillRange) {
+		t.Errorf("expected %v for 6 blocks; got %v", ErrBackfillRange, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := w.Backfill(ctx, []string{watchCarol}, 0, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Backfill(context.Background(), []string{watchAlice}, 0, 1); !errors.Is(err, ErrBackfillRunning) {
+		t.Errorf("expected %v; got %v", ErrBackfillRunning, err)
+	}
+
+	// Cancelling the backfill frees its slot
+	cancel()
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	err := ErrBackfillRunning
+	deadline := time.Now().Add(time.Second)
+	for errors.Is(err, ErrBackfillRunning) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		err = w.Backfill(ctx, []string{watchAlice}, 0, 1)
+	}
+	if err != nil {
+		t.Errorf("expected a backfill once the last is cancelled; got %v", err)
+	}
+}
+
+func TestParserWatchlistTransfers(t *testing.T) {
+	const token = "0x00000000000000000000000000000000000000dd"
+	b := BlockTxn{BlockNum: "1", Txns: []Transaction{
+		// bob pays alice in tokens
+		{Hash: "0x1", From: watchBob, To: token, Block: "0x1", Receipt: &Receipt{Status: ReceiptStatusSuccess, Logs: []Log{
+			{Address: token, Topics: []string{TopicTransfer, topic(watchBob), topic(watchAlice)}, Data: "0x" + word("0x64"), LogIndex: "0x0"},
+		}}},
+		// a contract bob calls pays alice out
+		{Hash: "0x2", From: watchBob, To: token, Block: "0x1", Internal: []InternalTransfer{
+			{Type: "CALL", From: token, To: watchAlice, Value: "0x5", Depth: 1, TransactionHash: "0x2", Block: "0x1"},
+		}},
+	}}
+	watchlist, _ := NewWatchlist("")
+	watchlist.Add(watchAlice)
+	src := make(chan BlockTxn)
+	close(src)
+	p, _ := NewParser(src, ParserConfig{Watchlist: watchlist})
+	d := p.(*defaultParser)
+	ctx := context.Background()
+
+	tokens := d.SubscribeTokenTransfers(watchAlice, observer.DefaultOptions)
+	defer tokens.Unsubscribe()
+	d.processBlock(ctx, b)
+	d.indexBlock(ctx, b, d.indexed, true)
+
+	if got := d.GetTokenTransfers(ctx, watchAlice); len(got) != 1 || got[0].Amount != "0x64" {
+		t.Errorf("expected the token payment to alice; got %+v", got)
+	}
+	if got := d.GetInternalTransfers(ctx, watchAlice); len(got) != 1 || got[0].Value != "0x5" {
+		t.Errorf("expected the payout to alice; got %+v", got)
+	}
+	if got := d.GetTransactions(ctx, watchAlice); len(got) != 0 {
+		t.Errorf("expected no transactions of alice; got %+v", got)
+	}
+	if got := d.GetTokenTransfers(ctx, watchBob); len(got) != 0 {
+		t.Errorf("expected nothing indexed for unwatched bob; got %+v", got)
+	}
+	select {
+	case <-tokens.Ch:
+	case <-time.After(time.Second):
+		t.Error("expected the token payment published")
+	}
+}
@@ -0,0 +1,137 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+var ErrTooComplex = errors.New("query too complex")
+
+// pagedFields are the connection fields whose children are
+// multiplied by their page size
+var pagedFields = map[string]bool{
+	"addresses":    true,
+	"transactions": true,
+}
+
+// Complexity estimates the cost of an operation. Every field
+// costs one, and the children of a connection cost once per
+// node of the requested page. Subscription root fields are not
+// multiplied since each event is resolved on its own.
+func Complexity(query, operationName string, variables map[string]interface{}) (int, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return 0, err
+	}
+
+	var op *ast.OperationDefinition
+	switch {
+	case operationName != "":
+		op = doc.Operations.ForName(operationName)
+	case len(doc.Operations) == 1:
+		op = doc.Operations[0]
+	}
+	if op == nil {
+		// Left for the executor to report
+		return 0, nil
+	}
+
+	c := complexity{doc: doc, op: op, vars: variables, visiting: map[string]bool{}}
+	root := op.Operation != ast.Subscription
+	return c.selections(op.SelectionSet, root), nil
+}
+
+type complexity struct {
+	doc      *ast.QueryDocument
+	op       *ast.OperationDefinition
+	vars     map[string]interface{}
+	visiting map[string]bool
+}
+
+func (c complexity) selections(set ast.SelectionSet, multiply bool) int {
+	cost := 0
+	for _, sel := range set {
+		switch s := sel.(type) {
+		case *ast.Field:
+			n := 1
+			if multiply && pagedFields[s.Name] {
+				n = c.first(s)
+			}
+			cost += 1 + n*c.selections(s.SelectionSet, true)
+		case *ast.InlineFragment:
+			cost += c.selections(s.SelectionSet, multiply)
+		case *ast.FragmentSpread:
+			// Cycles are rejected by validation; stop here so
+			// they cannot recurse forever
+			if c.visiting[s.Name] {
+				continue
+			}
+			frag := c.doc.Fragments.ForName(s.Name)
+			if frag == nil {
+				continue
+			}
+			c.visiting[s.Name] = true
+			cost += c.selections(frag.SelectionSet, multiply)
+			delete(c.visiting, s.Name)
+		}
+	}
+	return cost
+}
+
+// first returns the page size requested by a connection field
+func (c complexity) first(f *ast.Field) int {
+	arg := f.Arguments.ForName("first")
+	if arg == nil || arg.Value == nil {
+		return 20
+	}
+	value := arg.Value
+	if value.Kind == ast.Variable {
+		if _, ok := c.vars[value.Raw]; !ok {
+			def := c.op.VariableDefinitions.ForName(value.Raw)
+			if def == nil || def.DefaultValue == nil {
+				return 20
+			}
+			value = def.DefaultValue
+		}
+	}
+	v, err := value.Value(c.vars)
+	if err != nil {
+		return MaxPageSize
+	}
+	switch n := v.(type) {
+	case int64:
+		return clampPage(int(n))
+	case float64:
+		return clampPage(int(n))
+	case int:
+		return clampPage(n)
+	case nil:
+		return 20
+	}
+	return MaxPageSize
+}
+
+func clampPage(n int) int {
+	if n < 0 || n > MaxPageSize {
+		// Rejected by the resolver
+		return 0
+	}
+	return n
+}
+
+// checkComplexity returns ErrTooComplex if the operation exceeds
+// limit
+func checkComplexity(limit int, query, operationName string, variables map[string]interface{}) error {
+	cost, err := Complexity(query, operationName, variables)
+	if err != nil {
+		// Syntax errors are reported by the executor
+		return nil
+	}
+	if cost > limit {
+		return fmt.Errorf("%w-complexity %d exceeds %d", ErrTooComplex, cost, limit)
+	}
+	return nil
+}
@@ -0,0 +1,5 @@
+// Package gql serves a GraphQL API over the data indexed by
+// the transaction parser, including live subscriptions over
+// the graphql-transport-ws WebSocket protocol. A server serves
+// one chain, the primary one in the txparser example.
+package gql
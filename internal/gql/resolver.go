@@ -0,0 +1,487 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/observer"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxPageSize bounds the first argument of a connection
+const MaxPageSize = 100
+
+var (
+	ErrInvalidFirst  = errors.New("invalid first")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidBlock  = errors.New("invalid block number")
+	ErrNoBlocks      = errors.New("blocks are not served")
+)
+
+// resolver is the root of the schema
+type resolver struct {
+	parser eth.Parser
+	// subscribe sizes the transaction subscriptions
+	subscribe observer.Options
+	logger    *slog.Logger
+}
+
+// TransactionFilter is the TransactionFilter input
+type TransactionFilter struct {
+	MinValue *string
+	Selector *string
+	Creation *bool
+	Type     *string
+}
+
+// parse validates the filter against the addresses
+func (f *TransactionFilter) parse(addresses []string) (eth.Filter, error) {
+	spec := eth.FilterSpec{Addresses: addresses}
+	if f != nil {
+		if f.MinValue != nil {
+			spec.MinValue = *f.MinValue
+		}
+		if f.Selector != nil {
+			spec.Selector = *f.Selector
+		}
+		if f.Creation != nil {
+			spec.Creation = *f.Creation
+		}
+		if f.Type != nil {
+			spec.Type = *f.Type
+		}
+	}
+	return spec.Parse()
+}
+
+func pageSize(first int32) (int, error) {
+	if first < 0 || first > MaxPageSize {
+		return 0, fmt.Errorf("%w-must be between 0 and %d", ErrInvalidFirst, MaxPageSize)
+	}
+	return int(first), nil
+}
+
+type statusResolver struct {
+	block string
+}
+
+func (s statusResolver) Block() string {
+	return s.block
+}
+
+func (s statusResolver) Syncing() bool {
+	return s.block == eth.NoBlock
+}
+
+func (r *resolver) Status() statusResolver {
+	return statusResolver{block: r.parser.GetCurrentBlock()}
+}
+
+func (r *resolver) Address(args struct{ Address string }) (*addressResolver, error) {
+	addr, err := eth.NormalizeAddress(args.Address)
+	if err != nil {
+		return nil, err
+	}
+	if r.parser.GetCount(strings.ToLower(addr)) == 0 {
+		return nil, nil
+	}
+	return &addressResolver{parser: r.parser, address: addr}, nil
+}
+
+type addressEdge struct {
+	node *addressResolver
+}
+
+func (e addressEdge) Cursor() string {
+	return e.node.address
+}
+
+func (e addressEdge) Node() *addressResolver {
+	return e.node
+}
+
+type addressConnection struct {
+	edges   []addressEdge
+	hasNext bool
+	total   int
+}
+
+func (c addressConnection) Edges() []addressEdge {
+	return c.edges
+}
+
+func (c addressConnection) PageInfo() pageInfo {
+	p := pageInfo{hasNext: c.hasNext}
+	if len(c.edges) > 0 {
+		p.endCursor = c.edges[len(c.edges)-1].Cursor()
+	}
+	return p
+}
+
+func (c addressConnection) TotalCount() int32 {
+	return int32(c.total)
+}
+
+func (r *resolver) Addresses(ctx context.Context, args struct {
+	First int32
+	After *string
+}) (addressConnection, error) {
+	n, err := pageSize(args.First)
+	if err != nil {
+		return addressConnection{}, err
+	}
+	var after string
+	if args.After != nil {
+		addr, err := eth.NormalizeAddress(*args.After)
+		if err != nil {
+			return addressConnection{}, fmt.Errorf("%w-%v", ErrInvalidCursor, err)
+		}
+		after = strings.ToLower(addr)
+	}
+
+	keys := []string{}
+	for _, k := range r.parser.GetAddresses(ctx) {
+		// Skip the empty recipient of contract creations
+		if _, err := eth.NormalizeAddress(k); err != nil {
+			continue
+		}
+		keys = append(keys, strings.ToLower(k))
+	}
+	sort.Strings(keys)
+
+	conn := addressConnection{total: len(keys), edges: []addressEdge{}}
+	for _, k := range keys {
+		if k <= after {
+			continue
+		}
+		if len(conn.edges) == n {
+			conn.hasNext = true
+			break
+		}
+		addr, _ := eth.NormalizeAddress(k)
+		conn.edges = append(conn.edges, addressEdge{&addressResolver{parser: r.parser, address: addr}})
+	}
+	return conn, nil
+}
+
+// Transactions resolves the transactions subscription. The
+// subscription is closed when the client unsubscribes.
+func (r *resolver) Transactions(ctx context.Context, args struct {
+	Addresses *[]string
+	Filter    *TransactionFilter
+}) (<-chan *transactionResolver, error) {
+	var addresses []string
+	if args.Addresses != nil {
+		addresses = *args.Addresses
+	}
+	filter, err := args.Filter.parse(addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := r.parser.SubscribeFilter(filter, r.subscribe)
+	r.logger.Info("new graphql subscription", "subscription", sub.ID)
+
+	ch := make(chan *transactionResolver)
+	go func() {
+		defer close(ch)
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case msg, ok := <-sub.Ch:
+				if !ok {
+					return
+				}
+				var txn eth.Transaction
+				if err := json.Unmarshal(msg, &txn); err != nil {
+					r.logger.Error("decode transaction", "subscription", sub.ID, "err", err)
+					continue
+				}
+				select {
+				case ch <- &transactionResolver{parser: r.parser, txn: txn}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+type addressResolver struct {
+	parser  eth.Parser
+	address string
+}
+
+func (a *addressResolver) Address() string {
+	return a.address
+}
+
+func (a *addressResolver) Count() int32 {
+	return int32(a.parser.GetCount(strings.ToLower(a.address)))
+}
+
+func (a *addressResolver) Transactions(ctx context.Context, args struct {
+	First  int32
+	After  *string
+	Filter *TransactionFilter
+}) (transactionConnection, error) {
+	n, err := pageSize(args.First)
+	if err != nil {
+		return transactionConnection{}, err
+	}
+	var after *eth.Cursor
+	if args.After != nil {
+		c, err := eth.ParseCursor(*args.After)
+		if err != nil {
+			return transactionConnection{}, err
+		}
+		after = &c
+	}
+	filter, err := args.Filter.parse(nil)
+	if err != nil {
+		return transactionConnection{}, err
+	}
+	txns := []eth.Transaction{}
+	for _, txn := range a.parser.GetTransactions(ctx, strings.ToLower(a.address)) {
+		if filter.Match(txn) {
+			txns = append(txns, txn)
+		}
+	}
+	return transactionPage(a.parser, txns, n, after), nil
+}
+
+// transactionPage returns the n transactions in chain order
+// after the cursor, from the first if after is nil
+func transactionPage(parser eth.Parser, txns []eth.Transaction, n int, after *eth.Cursor) transactionConnection {
+	type event struct {
+		cursor eth.Cursor
+		txn    eth.Transaction
+	}
+	events := make([]event, 0, len(txns))
+	for _, txn := range txns {
+		c, _ := eth.TxCursor(txn)
+		events = append(events, event{cursor: c, txn: txn})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].cursor.Less(events[j].cursor)
+	})
+
+	conn := transactionConnection{total: len(events), edges: []transactionEdge{}}
+	for _, e := range events {
+		if after != nil && !after.Less(e.cursor) {
+			continue
+		}
+		if len(conn.edges) == n {
+			conn.hasNext = true
+			break
+		}
+		conn.edges = append(conn.edges, transactionEdge{
+			cursor: e.cursor.String(),
+			node:   &transactionResolver{parser: parser, txn: e.txn},
+		})
+	}
+	return conn
+}
+
+type pageInfo struct {
+	hasNext   bool
+	endCursor string
+}
+
+func (p pageInfo) HasNextPage() bool {
+	return p.hasNext
+}
+
+func (p pageInfo) EndCursor() *string {
+	if p.endCursor == "" {
+		return nil
+	}
+	return &p.endCursor
+}
+
+type transactionEdge struct {
+	cursor string
+	node   *transactionResolver
+}
+
+func (e transactionEdge) Cursor() string {
+	return e.cursor
+}
+
+func (e transactionEdge) Node() *transactionResolver {
+	return e.node
+}
+
+type transactionConnection struct {
+	edges   []transactionEdge
+	hasNext bool
+	total   int
+}
+
+func (c transactionConnection) Edges() []transactionEdge {
+	return c.edges
+}
+
+func (c transactionConnection) PageInfo() pageInfo {
+	p := pageInfo{hasNext: c.hasNext}
+	if len(c.edges) > 0 {
+		p.endCursor = c.edges[len(c.edges)-1].cursor
+	}
+	return p
+}
+
+func (c transactionConnection) TotalCount() int32 {
+	return int32(c.total)
+}
+
+type transactionResolver struct {
+	parser eth.Parser
+	txn    eth.Transaction
+}
+
+func (t *transactionResolver) EventId() *string {
+	c, ok := eth.TxCursor(t.txn)
+	if !ok {
+		return nil
+	}
+	id := c.String()
+	return &id
+}
+
+func (t *transactionResolver) Hash() string {
+	return t.txn.Hash
+}
+
+func (t *transactionResolver) From() *addressResolver {
+	return t.address(t.txn.From)
+}
+
+func (t *transactionResolver) To() *addressResolver {
+	if t.txn.To == "" {
+		return nil
+	}
+	return t.address(t.txn.To)
+}
+
+func (t *transactionResolver) address(s string) *addressResolver {
+	addr, err := eth.NormalizeAddress(s)
+	if err != nil {
+		addr = s
+	}
+	return &addressResolver{parser: t.parser, address: addr}
+}
+
+func (t *transactionResolver) Value() string {
+	return t.txn.Value
+}
+
+func (t *transactionResolver) Input() string {
+	return t.txn.Input
+}
+
+func (t *transactionResolver) Type() string {
+	return t.txn.Type
+}
+
+func (t *transactionResolver) Gas() string {
+	return t.txn.Gas
+}
+
+func (t *transactionResolver) GasPrice() string {
+	return t.txn.GasPrice
+}
+
+func (t *transactionResolver) MaxFeePerGas() string {
+	return t.txn.MaxFeePerGas
+}
+
+func (t *transactionResolver) MaxPriorityFeePerGas() string {
+	return t.txn.MaxPriorityFeePerGas
+}
+
+func (t *transactionResolver) TransactionIndex() string {
+	return t.txn.TransactionIndex
+}
+
+func (t *transactionResolver) Block() *blockResolver {
+	return &blockResolver{parser: t.parser, number: t.txn.Block, hash: t.txn.BlockHash}
+}
+
+// Block resolves a processed block, nil past the latest
+func (r *resolver) Block(ctx context.Context, args struct{ Number string }) (*blockResolver, error) {
+	number, err := strconv.ParseInt(args.Number, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w-%s", ErrInvalidBlock, args.Number)
+	}
+	b, err := getBlock(ctx, r.parser, number)
+	if errors.Is(err, eth.ErrBlockNotProcessed) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &blockResolver{parser: r.parser, number: fmt.Sprintf("0x%x", number), hash: b.Hash, txns: b.Txns}, nil
+}
+
+// getBlock reads a processed block if the parser serves blocks
+func getBlock(ctx context.Context, parser eth.Parser, number int64) (eth.BlockTxn, error) {
+	g, ok := parser.(eth.BlockGetter)
+	if !ok {
+		return eth.BlockTxn{}, ErrNoBlocks
+	}
+	return g.GetBlock(ctx, number)
+}
+
+type blockResolver struct {
+	parser eth.Parser
+	number string
+	hash   string
+	// txns are read on demand when nil
+	txns []eth.Transaction
+}
+
+func (b *blockResolver) Number() string {
+	return b.number
+}
+
+func (b *blockResolver) Hash() string {
+	return b.hash
+}
+
+func (b *blockResolver) Transactions(ctx context.Context, args struct {
+	First int32
+	After *string
+}) (transactionConnection, error) {
+	n, err := pageSize(args.First)
+	if err != nil {
+		return transactionConnection{}, err
+	}
+	var after *eth.Cursor
+	if args.After != nil {
+		c, err := eth.ParseCursor(*args.After)
+		if err != nil {
+			return transactionConnection{}, err
+		}
+		after = &c
+	}
+	if b.txns == nil {
+		number, err := strconv.ParseInt(b.number, 0, 64)
+		if err != nil {
+			return transactionConnection{}, fmt.Errorf("%w-%s", ErrInvalidBlock, b.number)
+		}
+		block, err := getBlock(ctx, b.parser, number)
+		if err != nil {
+			return transactionConnection{}, err
+		}
+		b.txns = block.Txns
+	}
+	return transactionPage(b.parser, b.txns, n, after), nil
+}
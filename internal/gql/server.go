@@ -0,0 +1,190 @@
+package gql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/auth"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/observer"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	qerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+//go:embed schema.graphql
+var Schema string
+
+// Error extension codes
+const (
+	CodeBadRequest      = "BAD_REQUEST"
+	CodeComplexityLimit = "COMPLEXITY_LIMIT"
+	// CodeTooManySubscriptions is returned when a WebSocket
+	// operation is above the cap of the connection or client
+	CodeTooManySubscriptions = "TOO_MANY_SUBSCRIPTIONS"
+)
+
+// maxBodySize bounds the body of a POST request
+const maxBodySize = 1 << 20
+
+var (
+	ErrParseSchema          = errors.New("parse schema error")
+	ErrTooManySubscriptions = errors.New("too many concurrent subscriptions")
+)
+
+// Config limits the cost of queries and sets the server logger
+type Config struct {
+	// MaxComplexity is the highest cost accepted, see Complexity
+	MaxComplexity int
+	// MaxDepth is the deepest selection accepted
+	MaxDepth int
+	// MaxParallelism is the number of resolvers run at once
+	// per request
+	MaxParallelism int
+	// MaxOperations bounds the running operations of a
+	// WebSocket, DefaultConfig.MaxOperations if zero
+	MaxOperations int
+	// SubscribeOptions sizes the transaction subscriptions,
+	// DefaultConfig.SubscribeOptions if zero
+	SubscribeOptions observer.Options
+	// Quotas, if set, counts every WebSocket operation against
+	// the subscription cap of the client of the request context
+	Quotas *auth.Quotas
+	// Logger receives the server logs; nil discards them
+	Logger *slog.Logger
+}
+
+// DefaultConfig is used by NewDefaultServer
+var DefaultConfig = Config{
+	MaxComplexity:  5000,
+	MaxDepth:       10,
+	MaxParallelism: 10,
+	MaxOperations:  100,
+	SubscribeOptions: observer.Options{
+		Buffer: 64,
+		Policy: observer.PolicyDropOldest,
+	},
+}
+
+// Server serves GraphQL queries over HTTP POST and GET, and
+// queries and subscriptions over WebSocket
+type Server struct {
+	schema *graphql.Schema
+	cfg    Config
+	logger *slog.Logger
+}
+
+// Request is the body of a GraphQL request
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// NewDefaultServer instantiate a server with DefaultConfig
+func NewDefaultServer(parser eth.Parser) *Server {
+	s, err := NewServer(parser, DefaultConfig)
+	if err != nil {
+		// The embedded schema is covered by tests
+		panic(err)
+	}
+	return s
+}
+
+// NewServer instantiate a server backed by the parser
+func NewServer(parser eth.Parser, cfg Config) (*Server, error) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	if cfg.MaxOperations <= 0 {
+		cfg.MaxOperations = DefaultConfig.MaxOperations
+	}
+	if cfg.SubscribeOptions == (observer.Options{}) {
+		cfg.SubscribeOptions = DefaultConfig.SubscribeOptions
+	}
+	r := &resolver{parser: parser, subscribe: cfg.SubscribeOptions, logger: logger}
+	schema, err := graphql.ParseSchema(Schema, r,
+		graphql.MaxDepth(cfg.MaxDepth),
+		graphql.MaxParallelism(cfg.MaxParallelism),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w-%v", ErrParseSchema, err)
+	}
+	return &Server{schema: schema, cfg: cfg, logger: logger}, nil
+}
+
+// ServeHTTP executes a query. WebSocket upgrades are served
+// with the graphql-transport-ws protocol.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
+		s.serveWS(w, req)
+		return
+	}
+
+	var gqlReq Request
+	switch req.Method {
+	case http.MethodGet:
+		q := req.URL.Query()
+		gqlReq.Query = q.Get("query")
+		gqlReq.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &gqlReq.Variables); err != nil {
+				s.writeErrors(w, http.StatusBadRequest, queryError(CodeBadRequest, err))
+				return
+			}
+		}
+	case http.MethodPost:
+		body := http.MaxBytesReader(w, req.Body, maxBodySize)
+		if err := json.NewDecoder(body).Decode(&gqlReq); err != nil {
+			s.writeErrors(w, http.StatusBadRequest, queryError(CodeBadRequest, err))
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		s.writeErrors(w, http.StatusMethodNotAllowed, queryError(CodeBadRequest, fmt.Errorf("method %s not allowed", req.Method)))
+		return
+	}
+
+	if qerr := s.check(gqlReq); qerr != nil {
+		s.writeErrors(w, http.StatusOK, qerr)
+		return
+	}
+	resp := s.schema.Exec(req.Context(), gqlReq.Query, gqlReq.OperationName, gqlReq.Variables)
+	s.writeResponse(w, http.StatusOK, resp)
+}
+
+// check rejects requests exceeding the complexity limit
+func (s *Server) check(req Request) *qerrors.QueryError {
+	if req.Query == "" {
+		return queryError(CodeBadRequest, errors.New("query required"))
+	}
+	if err := checkComplexity(s.cfg.MaxComplexity, req.Query, req.OperationName, req.Variables); err != nil {
+		return queryError(CodeComplexityLimit, err)
+	}
+	return nil
+}
+
+func queryError(code string, err error) *qerrors.QueryError {
+	return &qerrors.QueryError{
+		Err:        err,
+		Message:    err.Error(),
+		Extensions: map[string]interface{}{"code": code},
+	}
+}
+
+func (s *Server) writeErrors(w http.ResponseWriter, status int, errs ...*qerrors.QueryError) {
+	s.writeResponse(w, status, &graphql.Response{Errors: errs})
+}
+
+func (s *Server) writeResponse(w http.ResponseWriter, status int, resp *graphql.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.logger.Info("write graphql response", "err", err)
+	}
+}
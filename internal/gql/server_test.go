@@ -0,0 +1,342 @@
+package gql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/auth"
+	"paulwizviz/go-eth-app/internal/eth"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	testAddrA = "0x00000000000000000000000000000000000000aa"
+	testAddrB = "0x00000000000000000000000000000000000000bb"
+)
+
+func newTestServer(t *testing.T, cfg Config) (*httptest.Server, chan eth.BlockTxn) {
+	t.Helper()
+	ch := make(chan eth.BlockTxn)
+	t.Cleanup(func() { close(ch) })
+	blocks := map[int64]eth.BlockTxn{}
+	p, err := eth.NewParser(ch, eth.ParserConfig{
+		Blocks: func(_ context.Context, n *big.Int) (eth.BlockTxn, error) {
+			return blocks[n.Int64()], nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txn := func(block, index int, from, to string) eth.Transaction {
+		return eth.Transaction{
+			Hash:             "0x" + strings.Repeat("0", 62) + string(rune('0'+block)) + string(rune('0'+index)),
+			BlockHash:        "0xb" + string(rune('0'+block)),
+			From:             from,
+			To:               to,
+			Value:            "0x0",
+			Block:            "0x" + string(rune('0'+block)),
+			TransactionIndex: "0x" + string(rune('0'+index)),
+		}
+	}
+	blocks[1] = eth.BlockTxn{BlockNum: "1", Hash: "0xb1", Txns: []eth.Transaction{txn(1, 0, testAddrA, testAddrB), txn(1, 1, testAddrB, testAddrA)}}
+	blocks[2] = eth.BlockTxn{BlockNum: "2", Hash: "0xb2", Txns: []eth.Transaction{txn(2, 0, testAddrA, testAddrB)}}
+	ch <- blocks[1]
+	ch <- blocks[2]
+	deadline := time.Now().Add(time.Second)
+	for p.GetCurrentBlock() != "2" {
+		if time.Now().After(deadline) {
+			t.Fatal("parser did not reach block 2")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	s, err := NewServer(p, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	return srv, ch
+}
+
+func post(t *testing.T, url string, req Request) map[string]interface{} {
+	t.Helper()
+	body, _ := json.Marshal(req)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestQuery(t *testing.T) {
+	srv, _ := newTestServer(t, DefaultConfig)
+
+	query := `query($after: String) {
+		address(address: "` + testAddrA + `") {
+			count
+			transactions(first: 2, after: $after) {
+				totalCount
+				pageInfo { hasNextPage endCursor }
+				edges { cursor node { hash from { address } block { number hash } } }
+			}
+		}
+	}`
+	type page struct {
+		Data struct {
+			Address struct {
+				Count        int
+				Transactions struct {
+					TotalCount int
+					PageInfo   struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+					Edges []struct {
+						Cursor string
+						Node   struct {
+							Block struct{ Number, Hash string }
+						}
+					}
+				}
+			}
+		}
+		Errors []interface{}
+	}
+	fetch := func(vars map[string]interface{}) page {
+		var p page
+		b, _ := json.Marshal(post(t, srv.URL, Request{Query: query, Variables: vars}))
+		json.Unmarshal(b, &p)
+		if len(p.Errors) > 0 {
+			t.Fatalf("unexpected errors: %v", p.Errors)
+		}
+		return p
+	}
+
+	first := fetch(nil)
+	txns := first.Data.Address.Transactions
+	if first.Data.Address.Count != 3 || txns.TotalCount != 3 || len(txns.Edges) != 2 || !txns.PageInfo.HasNextPage {
+		t.Fatalf("unexpected first page: %+v", first.Data.Address)
+	}
+	if txns.Edges[0].Cursor != "1-0" || txns.PageInfo.EndCursor != "1-1" || txns.Edges[0].Node.Block.Hash != "0xb1" {
+		t.Errorf("unexpected edges: %+v", txns)
+	}
+
+	second := fetch(map[string]interface{}{"after": txns.PageInfo.EndCursor})
+	txns = second.Data.Address.Transactions
+	if len(txns.Edges) != 1 || txns.Edges[0].Cursor != "2-0" || txns.PageInfo.HasNextPage {
+		t.Errorf("unexpected second page: %+v", txns)
+	}
+
+	unknown := post(t, srv.URL, Request{Query: `{ address(address: "0x00000000000000000000000000000000000000cc") { count } }`})
+	if data := unknown["data"].(map[string]interface{}); data["address"] != nil {
+		t.Errorf("expected null for unknown address; got %v", data)
+	}
+}
+
+func TestBlockQuery(t *testing.T) {
+	srv, _ := newTestServer(t, DefaultConfig)
+
+	query := `query($number: String!) {
+		block(number: $number) {
+			number
+			hash
+			transactions(first: 1) {
+				totalCount
+				pageInfo { hasNextPage }
+				edges { node { hash block { number } } }
+			}
+		}
+	}`
+	result := post(t, srv.URL, Request{Query: query, Variables: map[string]interface{}{"number": "1"}})
+	if errs, ok := result["errors"]; ok {
+		t.Fatalf("errors: %v", errs)
+	}
+	block := result["data"].(map[string]interface{})["block"].(map[string]interface{})
+	if block["number"] != "0x1" || block["hash"] != "0xb1" {
+		t.Errorf("block: %v", block)
+	}
+	txns := block["transactions"].(map[string]interface{})
+	if txns["totalCount"] != float64(2) || !txns["pageInfo"].(map[string]interface{})["hasNextPage"].(bool) {
+		t.Errorf("transactions: %v", txns)
+	}
+	if edges := txns["edges"].([]interface{}); len(edges) != 1 {
+		t.Errorf("edges: %v", edges)
+	}
+
+	result = post(t, srv.URL, Request{Query: query, Variables: map[string]interface{}{"number": "0x3"}})
+	if errs, ok := result["errors"]; ok {
+		t.Fatalf("errors: %v", errs)
+	}
+	if block := result["data"].(map[string]interface{})["block"]; block != nil {
+		t.Errorf("unprocessed block: %v", block)
+	}
+
+	result = post(t, srv.URL, Request{Query: query, Variables: map[string]interface{}{"number": "one"}})
+	if _, ok := result["errors"]; !ok {
+		t.Errorf("invalid number: %v", result)
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	srv, _ := newTestServer(t, DefaultConfig)
+	body := `{"query":"` + strings.Repeat(" ", maxBodySize) + `{ status { block } }"}`
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 above the body limit; got %d", resp.StatusCode)
+	}
+}
+
+func TestComplexityLimit(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.MaxComplexity = 100
+	srv, _ := newTestServer(t, cfg)
+
+	// addresses + 50 * (edges + cursor)
+	cheap := `{ addresses(first: 50) { edges { cursor } } }`
+	if cost, _ := Complexity(cheap, "", nil); cost != 101 {
+		t.Errorf("expected cost 101; got %d", cost)
+	}
+
+	query := `query($n: Int) { addresses(first: $n) { edges { node { transactions { edges { cursor } } } } } }`
+	result := post(t, srv.URL, Request{Query: query, Variables: map[string]interface{}{"n": 10}})
+	errs, _ := result["errors"].([]interface{})
+	if len(errs) != 1 || !strings.Contains(errs[0].(map[string]interface{})["message"].(string), "too complex") {
+		t.Fatalf("expected complexity error; got %v", result)
+	}
+
+	result = post(t, srv.URL, Request{Query: `{ addresses(first: 1) { totalCount edges { cursor } } }`})
+	if result["errors"] != nil {
+		t.Errorf("unexpected errors: %v", result["errors"])
+	}
+}
+
+func TestSubscription(t *testing.T) {
+	srv, ch := newTestServer(t, DefaultConfig)
+
+	dialer := websocket.Dialer{Subprotocols: []string{Subprotocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	read := func() Message {
+		t.Helper()
+		var m Message
+		if err := conn.ReadJSON(&m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	conn.WriteJSON(Message{Type: MsgConnectionInit})
+	if m := read(); m.Type != MsgConnectionAck {
+		t.Fatalf("expected ack; got %+v", m)
+	}
+
+	payload, _ := json.Marshal(Request{Query: `subscription { transactions(addresses: ["` + testAddrB + `"]) { eventId to { address } } }`})
+	conn.WriteJSON(Message{ID: "1", Type: MsgSubscribe, Payload: payload})
+	payload, _ = json.Marshal(Request{Query: `{ status { block } }`})
+	conn.WriteJSON(Message{ID: "2", Type: MsgSubscribe, Payload: payload})
+	if m := read(); m.Type != MsgNext || m.ID != "2" || !strings.Contains(string(m.Payload), `"block":"2"`) {
+		t.Fatalf("expected query result; got %+v", m)
+	}
+	if m := read(); m.Type != MsgComplete || m.ID != "2" {
+		t.Fatalf("expected query completion; got %+v", m)
+	}
+
+	// The subscription is registered asynchronously
+	time.Sleep(50 * time.Millisecond)
+	ch <- eth.BlockTxn{BlockNum: "3", Txns: []eth.Transaction{{Hash: "0x3", From: testAddrA, To: testAddrB, Block: "0x3", TransactionIndex: "0x0"}}}
+	m := read()
+	if m.Type != MsgNext || m.ID != "1" || !strings.Contains(string(m.Payload), `"eventId":"3-0"`) {
+		t.Fatalf("expected transaction 3-0; got %+v", m)
+	}
+
+	conn.WriteJSON(Message{ID: "1", Type: MsgComplete})
+	conn.WriteJSON(Message{Type: MsgPing})
+	if m := read(); m.Type != MsgPong {
+		t.Errorf("expected pong; got %+v", m)
+	}
+}
+
+func TestSubscriptionCap(t *testing.T) {
+	quotas := auth.NewQuotas()
+	principal := auth.Principal{ID: "frontend", Limits: auth.Limits{MaxSubscriptions: 1}}
+	cfg := DefaultConfig
+	cfg.Quotas = quotas
+	srv, _ := newTestServer(t, cfg)
+	// The principal is set by the authentication middleware
+	authenticated := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req = req.WithContext(auth.NewContext(req.Context(), principal))
+		srv.Config.Handler.ServeHTTP(w, req)
+	}))
+	defer authenticated.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{Subprotocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(authenticated.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	read := func() Message {
+		t.Helper()
+		var m Message
+		if err := conn.ReadJSON(&m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	conn.WriteJSON(Message{Type: MsgConnectionInit})
+	read()
+
+	subscribe, _ := json.Marshal(Request{Query: `subscription { transactions(addresses: ["` + testAddrB + `"]) { eventId } }`})
+	conn.WriteJSON(Message{ID: "1", Type: MsgSubscribe, Payload: subscribe})
+	conn.WriteJSON(Message{ID: "2", Type: MsgSubscribe, Payload: subscribe})
+	if m := read(); m.Type != MsgError || m.ID != "2" || !strings.Contains(string(m.Payload), CodeTooManySubscriptions) {
+		t.Fatalf("expected an error above the cap; got %+v", m)
+	}
+	if got := quotas.Subscriptions(principal.ID); got != 1 {
+		t.Errorf("expected 1 operation counted; got %d", got)
+	}
+
+	// Completing the operation frees its slot
+	conn.WriteJSON(Message{ID: "1", Type: MsgComplete})
+	conn.WriteJSON(Message{ID: "3", Type: MsgSubscribe, Payload: subscribe})
+	conn.WriteJSON(Message{Type: MsgPing})
+	if m := read(); m.Type != MsgPong {
+		t.Fatalf("expected the operation accepted; got %+v", m)
+	}
+	if got := quotas.Subscriptions(principal.ID); got != 1 {
+		t.Errorf("expected 1 operation counted; got %d", got)
+	}
+
+	// Closing the connection frees the slots left
+	conn.Close()
+	deadline := time.Now().Add(time.Second)
+	for quotas.Subscriptions(principal.ID) != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := quotas.Subscriptions(principal.ID); got != 0 {
+		t.Errorf("expected the operations released; got %d", got)
+	}
+}
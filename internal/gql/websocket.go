@@ -0,0 +1,352 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/auth"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	graphql "github.com/graph-gophers/graphql-go"
+	qerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// Subprotocol is the graphql-transport-ws protocol name
+const Subprotocol = "graphql-transport-ws"
+
+// graphql-transport-ws message types
+const (
+	MsgConnectionInit = "connection_init"
+	MsgConnectionAck  = "connection_ack"
+	MsgPing           = "ping"
+	MsgPong           = "pong"
+	MsgSubscribe      = "subscribe"
+	MsgNext           = "next"
+	MsgError          = "error"
+	MsgComplete       = "complete"
+)
+
+// Close codes defined by the protocol
+const (
+	closeInvalidMessage      = 4400
+	closeUnauthorized        = 4401
+	closeInitTimeout         = 4408
+	closeSubscriberExists    = 4409
+	closeTooManyInitRequests = 4429
+)
+
+var (
+	// ConnectionInitTimeout is how long a client has to send
+	// connection_init
+	ConnectionInitTimeout = 10 * time.Second
+
+	wsWriteTimeout = 10 * time.Second
+	wsOutBuffer    = 256
+	// wsReadLimit bounds a message from the client
+	wsReadLimit int64 = 64 << 10
+)
+
+// Message is a graphql-transport-ws message
+type Message struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+var upgrader = websocket.Upgrader{
+	// Origins are checked by the CORS middleware
+	CheckOrigin:  func(*http.Request) bool { return true },
+	Subprotocols: []string{Subprotocol},
+}
+
+func (s *Server) serveWS(w http.ResponseWriter, req *http.Request) {
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		s.logger.Warn("graphql websocket upgrade", "err", err)
+		return
+	}
+	conn.SetReadLimit(wsReadLimit)
+
+	ctx, cancel := context.WithCancel(req.Context())
+	sess := &wsSession{
+		server:  s,
+		conn:    conn,
+		out:     make(chan interface{}, wsOutBuffer),
+		ctx:     ctx,
+		ops:     map[string]context.CancelFunc{},
+		written: make(chan struct{}),
+	}
+	if p, ok := auth.FromContext(req.Context()); ok && s.cfg.Quotas != nil {
+		sess.principal = &p
+	}
+
+	go sess.writeLoop(cancel)
+	sess.readLoop()
+
+	// Give the writer a chance to send a queued close frame
+	if sess.closing.Load() {
+		select {
+		case <-sess.written:
+		case <-time.After(wsWriteTimeout):
+		}
+	}
+	cancel()
+	sess.finishAll()
+	conn.Close()
+}
+
+type wsSession struct {
+	server *Server
+	conn   *websocket.Conn
+	// out holds a Message or a closeFrame
+	out chan interface{}
+	ctx context.Context
+	// closing is set once a close frame is queued
+	closing atomic.Bool
+	written chan struct{}
+	// principal, if set, has every operation counted against
+	// its subscription cap
+	principal *auth.Principal
+
+	mu   sync.Mutex
+	init bool
+	ops  map[string]context.CancelFunc
+}
+
+type closeFrame struct {
+	code   int
+	reason string
+}
+
+func (s *wsSession) readLoop() {
+	timer := time.AfterFunc(ConnectionInitTimeout, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.init {
+			s.close(closeInitTimeout, "Connection initialisation timeout")
+		}
+	})
+	defer timer.Stop()
+
+	for {
+		var msg Message
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				s.close(closeInvalidMessage, "Invalid message")
+			}
+			return
+		}
+
+		switch msg.Type {
+		case MsgConnectionInit:
+			s.mu.Lock()
+			done := s.init
+			s.init = true
+			s.mu.Unlock()
+			if done {
+				s.close(closeTooManyInitRequests, "Too many initialisation requests")
+				return
+			}
+			s.send(Message{Type: MsgConnectionAck})
+		case MsgPing:
+			s.send(Message{Type: MsgPong})
+		case MsgPong:
+		case MsgSubscribe:
+			if !s.subscribe(msg) {
+				return
+			}
+		case MsgComplete:
+			s.complete(msg.ID)
+		default:
+			s.close(closeInvalidMessage, "Unknown message type "+msg.Type)
+			return
+		}
+	}
+}
+
+// subscribe starts an operation and reports false if the
+// connection is closed
+func (s *wsSession) subscribe(msg Message) bool {
+	var req Request
+	if msg.ID == "" || json.Unmarshal(msg.Payload, &req) != nil {
+		s.close(closeInvalidMessage, "Invalid subscribe message")
+		return false
+	}
+
+	s.mu.Lock()
+	if !s.init {
+		s.mu.Unlock()
+		s.close(closeUnauthorized, "Unauthorized")
+		return false
+	}
+	if _, found := s.ops[msg.ID]; found {
+		s.mu.Unlock()
+		s.close(closeSubscriberExists, "Subscriber for "+msg.ID+" already exists")
+		return false
+	}
+	if err := s.acquire(); err != nil {
+		s.mu.Unlock()
+		s.sendErrors(msg.ID, []*qerrors.QueryError{queryError(CodeTooManySubscriptions, err)})
+		return true
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.ops[msg.ID] = cancel
+	s.mu.Unlock()
+
+	if qerr := s.server.check(req); qerr != nil {
+		s.finish(msg.ID)
+		s.sendErrors(msg.ID, []*qerrors.QueryError{qerr})
+		return true
+	}
+
+	responses, err := s.server.schema.Subscribe(ctx, req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		s.finish(msg.ID)
+		s.sendErrors(msg.ID, []*qerrors.QueryError{qerrors.Errorf("%s", err)})
+		return true
+	}
+	go s.forward(ctx, msg.ID, responses)
+	return true
+}
+
+// forward relays the responses of an operation until it ends
+// or the client completes it
+func (s *wsSession) forward(ctx context.Context, id string, responses <-chan interface{}) {
+	first := true
+	for r := range responses {
+		resp, ok := r.(*graphql.Response)
+		if !ok {
+			continue
+		}
+		// Validation errors end the operation before it starts
+		if first && resp.Data == nil && len(resp.Errors) > 0 {
+			s.finish(id)
+			s.sendErrors(id, resp.Errors)
+			return
+		}
+		first = false
+		payload, err := json.Marshal(resp)
+		if err != nil {
+			s.server.logger.Error("encode graphql result", "operation", id, "err", err)
+			continue
+		}
+		s.send(Message{ID: id, Type: MsgNext, Payload: payload})
+	}
+	// Only report completion the client did not ask for
+	if ctx.Err() == nil && s.finish(id) {
+		s.send(Message{ID: id, Type: MsgComplete})
+	}
+}
+
+// acquire takes an operation slot of the connection and of the
+// client; it is called with the lock held
+func (s *wsSession) acquire() error {
+	if n := len(s.ops); n >= s.server.cfg.MaxOperations {
+		return fmt.Errorf("%w-%d on the connection", ErrTooManySubscriptions, n)
+	}
+	if s.principal != nil && !s.server.cfg.Quotas.Acquire(*s.principal) {
+		return ErrTooManySubscriptions
+	}
+	return nil
+}
+
+// remove drops an operation and frees its slot; it is called
+// with the lock held
+func (s *wsSession) remove(id string) (context.CancelFunc, bool) {
+	cancel, found := s.ops[id]
+	if !found {
+		return nil, false
+	}
+	delete(s.ops, id)
+	if s.principal != nil {
+		s.server.cfg.Quotas.Release(*s.principal)
+	}
+	return cancel, true
+}
+
+// complete cancels an operation at the client's request
+func (s *wsSession) complete(id string) {
+	s.finish(id)
+}
+
+// finish removes an operation and reports whether it was running
+func (s *wsSession) finish(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cancel, found := s.remove(id)
+	if found {
+		cancel()
+	}
+	return found
+}
+
+// finishAll removes the operations left when the connection ends
+func (s *wsSession) finishAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id := range s.ops {
+		cancel, _ := s.remove(id)
+		cancel()
+	}
+}
+
+func (s *wsSession) sendErrors(id string, errs []*qerrors.QueryError) {
+	payload, err := json.Marshal(errs)
+	if err != nil {
+		s.server.logger.Error("encode graphql errors", "operation", id, "err", err)
+		return
+	}
+	s.send(Message{ID: id, Type: MsgError, Payload: payload})
+}
+
+// writeLoop is the only goroutine writing to the connection
+func (s *wsSession) writeLoop(cancel context.CancelFunc) {
+	defer close(s.written)
+	defer cancel()
+	for {
+		var frame interface{}
+		select {
+		case frame = <-s.out:
+		case <-s.ctx.Done():
+			return
+		}
+		s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		if c, ok := frame.(closeFrame); ok {
+			msg := websocket.FormatCloseMessage(c.code, c.reason)
+			s.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteTimeout))
+			// Unblock the reader
+			s.conn.Close()
+			return
+		}
+		if err := s.conn.WriteJSON(frame); err != nil {
+			s.server.logger.Info("graphql websocket write", "err", err)
+			s.conn.Close()
+			return
+		}
+	}
+}
+
+func (s *wsSession) send(msg Message) {
+	select {
+	case s.out <- msg:
+	case <-s.ctx.Done():
+	}
+}
+
+// close queues a close frame; it must not block as it is called
+// with the lock held
+func (s *wsSession) close(code int, reason string) {
+	s.closing.Store(true)
+	select {
+	case s.out <- closeFrame{code: code, reason: reason}:
+	default:
+		s.conn.Close()
+	}
+}
@@ -0,0 +1,101 @@
+package grpcserver
+
+import (
+	"context"
+	"paulwizviz/go-eth-app/internal/auth"
+	"paulwizviz/go-eth-app/pkg/parserpb"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyMetadata carries an API key as an alternative to the
+// authorization metadata
+const APIKeyMetadata = "x-api-key"
+
+// Authenticator resolves the client a token authenticates, e.g.
+// the REST server's Auth
+type Authenticator interface {
+	Principal(token string) (auth.Principal, error)
+}
+
+// authInterceptor authenticates the parser service calls and
+// enforces the quotas of the client. The health and reflection
+// services are left open for orchestrators and tooling.
+type authInterceptor struct {
+	auth   Authenticator
+	quotas *auth.Quotas
+}
+
+// protected reports whether a method belongs to the parser service
+func protected(method string) bool {
+	return strings.HasPrefix(method, "/"+parserpb.ParserService_ServiceDesc.ServiceName+"/")
+}
+
+// credentials returns the token presented in the call metadata
+func credentials(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		if bearer, found := strings.CutPrefix(v, "Bearer "); found {
+			return strings.TrimSpace(bearer)
+		}
+	}
+	if keys := md.Get(APIKeyMetadata); len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
+
+// authenticate returns the context of an authenticated call
+// within the client's rate limit
+func (a authInterceptor) authenticate(ctx context.Context) (context.Context, auth.Principal, error) {
+	p, err := a.auth.Principal(credentials(ctx))
+	if err != nil {
+		return nil, p, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if ok, wait := a.quotas.Allow(p); !ok {
+		return nil, p, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %v", wait)
+	}
+	return auth.NewContext(ctx, p), p, nil
+}
+
+func (a authInterceptor) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !protected(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	ctx, _, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// stream also counts the stream against the client's
+// subscription cap
+func (a authInterceptor) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !protected(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	ctx, p, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	if !a.quotas.Acquire(p) {
+		return status.Error(codes.ResourceExhausted, "too many concurrent subscriptions")
+	}
+	defer a.quotas.Release(p)
+	return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+}
+
+// authStream carries the principal in the stream context
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,11 @@
+// Package grpcserver serves the transaction parser over gRPC,
+// alongside the standard health checking and reflection services.
+// A server serves one chain, the primary one in the txparser
+// example.
+//
+// The parser service authenticates calls with an API key or a
+// JWT in the "authorization" or "x-api-key" metadata when
+// Config.Auth is set, and counts each Subscribe stream against
+// the client's subscription cap. Without it the server must only
+// be bound to an internal listener.
+package grpcserver
@@ -0,0 +1,33 @@
+package grpcserver
+
+import (
+	"context"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/pkg/parserpb"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// SyncPollInterval is how often the health of the parser
+// service is refreshed while it is syncing
+var SyncPollInterval = 100 * time.Millisecond
+
+// watchSyncing marks the parser service SERVING once the first
+// block is parsed. It gives up when ctx is done.
+func watchSyncing(ctx context.Context, parser eth.Parser, hs *health.Server) {
+	ticker := time.NewTicker(SyncPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if parser.GetCurrentBlock() != eth.NoBlock {
+				hs.SetServingStatus(parserpb.ParserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"paulwizviz/go-eth-app/internal/auth"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/observer"
+	"paulwizviz/go-eth-app/pkg/parserpb"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultSubscribeBuffer is the subscription buffer used when a
+// request does not set one. A slow client loses its oldest
+// undelivered transactions rather than stalling the parser.
+const DefaultSubscribeBuffer = 64
+
+// MaxSubscribeBuffer bounds the buffer a client may request
+const MaxSubscribeBuffer = 4096
+
+// Service implements parserpb.ParserServiceServer
+type Service struct {
+	parserpb.UnimplementedParserServiceServer
+	parser eth.Parser
+	// Logger receives the service logs; nil discards them
+	Logger *slog.Logger
+}
+
+func (s *Service) logger() *slog.Logger {
+	if s.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return s.Logger
+}
+
+// NewService instantiate a service backed by the parser
+func NewService(parser eth.Parser) *Service {
+	return &Service{parser: parser}
+}
+
+// Config are the settings of a server
+type Config struct {
+	// Logger receives the service logs; nil discards them
+	Logger *slog.Logger
+	// Auth authenticates the parser service calls; nil leaves
+	// them open, so the server must only be bound to an
+	// internal listener
+	Auth Authenticator
+	// Quotas rate limits the clients and caps their
+	// subscriptions; nil uses quotas of the server's own
+	Quotas *auth.Quotas
+}
+
+// NewServer instantiate a gRPC server with the parser, health
+// and reflection services registered. The parser service
+// reports SERVING once the first block is parsed, or until ctx
+// is done.
+func NewServer(ctx context.Context, parser eth.Parser, cfg Config, opts ...grpc.ServerOption) *grpc.Server {
+	if cfg.Auth != nil {
+		a := authInterceptor{auth: cfg.Auth, quotas: cfg.Quotas}
+		if a.quotas == nil {
+			a.quotas = auth.NewQuotas()
+		}
+		opts = append(opts, grpc.ChainUnaryInterceptor(a.unary), grpc.ChainStreamInterceptor(a.stream))
+	}
+	s := grpc.NewServer(opts...)
+	svc := NewService(parser)
+	svc.Logger = cfg.Logger
+	parserpb.RegisterParserServiceServer(s, svc)
+
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(s, hs)
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(parserpb.ParserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	go watchSyncing(ctx, parser, hs)
+
+	reflection.Register(s)
+	return s
+}
+
+func (s *Service) GetCurrentBlock(ctx context.Context, req *parserpb.GetCurrentBlockRequest) (*parserpb.GetCurrentBlockResponse, error) {
+	block := s.parser.GetCurrentBlock()
+	return &parserpb.GetCurrentBlockResponse{
+		Block:   block,
+		Syncing: block == eth.NoBlock,
+	}, nil
+}
+
+func (s *Service) GetAddresses(ctx context.Context, req *parserpb.GetAddressesRequest) (*parserpb.GetAddressesResponse, error) {
+	addresses := []string{}
+	for _, k := range s.parser.GetAddresses(ctx) {
+		// Skip the empty recipient of contract creations
+		addr, err := eth.NormalizeAddress(k)
+		if err != nil {
+			continue
+		}
+		addresses = append(addresses, addr)
+	}
+	sort.Strings(addresses)
+	return &parserpb.GetAddressesResponse{Addresses: addresses}, nil
+}
+
+func (s *Service) GetTransactions(ctx context.Context, req *parserpb.GetTransactionsRequest) (*parserpb.GetTransactionsResponse, error) {
+	addr, err := address(req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	txns := s.parser.GetTransactions(ctx, addr)
+	resp := &parserpb.GetTransactionsResponse{
+		Transactions: make([]*parserpb.Transaction, 0, len(txns)),
+	}
+	for _, txn := range txns {
+		resp.Transactions = append(resp.Transactions, Transaction(txn))
+	}
+	return resp, nil
+}
+
+func (s *Service) GetCount(ctx context.Context, req *parserpb.GetCountRequest) (*parserpb.GetCountResponse, error) {
+	addr, err := address(req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	return &parserpb.GetCountResponse{Count: s.parser.GetCount(addr)}, nil
+}
+
+// Subscribe streams the transactions matching the request until
+// the client cancels or the observer closes the subscription
+func (s *Service) Subscribe(req *parserpb.SubscribeRequest, stream grpc.ServerStreamingServer[parserpb.SubscribeResponse]) error {
+	spec := eth.FilterSpec{Addresses: req.GetAddresses()}
+	if f := req.GetFilter(); f != nil {
+		spec.MinValue = f.GetMinValue()
+		spec.Selector = f.GetSelector()
+		spec.Creation = f.GetCreation()
+		spec.Type = f.GetType()
+	}
+	filter, err := spec.Parse()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	opts := observer.Options{
+		Buffer: DefaultSubscribeBuffer,
+		Policy: observer.PolicyDropOldest,
+	}
+	if b := req.GetBuffer(); b > 0 {
+		if b > MaxSubscribeBuffer {
+			return status.Errorf(codes.InvalidArgument, "buffer exceeds %d", MaxSubscribeBuffer)
+		}
+		opts.Buffer = int(b)
+	}
+
+	sub := s.parser.SubscribeFilter(filter, opts)
+	defer sub.Unsubscribe()
+	s.logger().Info("new grpc subscription", "subscription", sub.ID)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case msg, ok := <-sub.Ch:
+			if !ok {
+				return status.Error(codes.Unavailable, "subscription closed")
+			}
+			var txn eth.Transaction
+			if err := json.Unmarshal(msg, &txn); err != nil {
+				s.logger().Error("decode transaction", "subscription", sub.ID, "err", err)
+				continue
+			}
+			resp := &parserpb.SubscribeResponse{
+				Transaction: Transaction(txn),
+				Dropped:     sub.Dropped(),
+			}
+			if c, ok := eth.TxCursor(txn); ok {
+				resp.EventId = c.String()
+			}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			s.logger().Info("unsubscribed", "subscription", sub.ID)
+			return nil
+		}
+	}
+}
+
+// address validates an address and returns the parser key
+func address(s string) (string, error) {
+	addr, err := eth.NormalizeAddress(s)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return strings.ToLower(addr), nil
+}
+
+// Transaction converts a parsed transaction to its message
+func Transaction(txn eth.Transaction) *parserpb.Transaction {
+	return &parserpb.Transaction{
+		BlockHash:            txn.BlockHash,
+		Hash:                 txn.Hash,
+		From:                 txn.From,
+		To:                   txn.To,
+		Input:                txn.Input,
+		Value:                txn.Value,
+		BlockNumber:          txn.Block,
+		TransactionIndex:     txn.TransactionIndex,
+		Type:                 txn.Type,
+		Gas:                  txn.Gas,
+		GasPrice:             txn.GasPrice,
+		MaxFeePerGas:         txn.MaxFeePerGas,
+		MaxPriorityFeePerGas: txn.MaxPriorityFeePerGas,
+	}
+}
@@ -0,0 +1,180 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"paulwizviz/go-eth-app/internal/auth"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/pkg/parserpb"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	testAddrA = "0x00000000000000000000000000000000000000aa"
+	testAddrB = "0x00000000000000000000000000000000000000bb"
+)
+
+// newTestClient serves a parser over an in-memory listener
+func newTestClient(t *testing.T, cfg Config) (*grpc.ClientConn, chan eth.BlockTxn) {
+	t.Helper()
+	ch := make(chan eth.BlockTxn)
+	p, err := eth.NewParser(ch, eth.ParserConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := NewServer(t.Context(), p, cfg)
+	go s.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+		close(ch)
+	})
+	return conn, ch
+}
+
+func TestService(t *testing.T) {
+	conn, ch := newTestClient(t, Config{})
+	client := parserpb.NewParserServiceClient(conn)
+	health := healthpb.NewHealthClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	check := func() healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: parserpb.ParserService_ServiceDesc.ServiceName})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.GetStatus()
+	}
+	if s := check(); s != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected NOT_SERVING while syncing; got %v", s)
+	}
+
+	stream, err := client.Subscribe(ctx, &parserpb.SubscribeRequest{Addresses: []string{testAddrB}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The stream is opened before the handler subscribes
+	time.Sleep(50 * time.Millisecond)
+
+	ch <- eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{
+		{Hash: "0x1", From: testAddrA, To: testAddrB, Block: "0x1", TransactionIndex: "0x0"},
+		{Hash: "0x2", From: testAddrA, To: "", Block: "0x1", TransactionIndex: "0x1"},
+	}}
+
+	msg, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.GetEventId() != "1-0" || msg.GetTransaction().GetHash() != "0x1" {
+		t.Errorf("unexpected event: %v", msg)
+	}
+
+	block, err := client.GetCurrentBlock(ctx, &parserpb.GetCurrentBlockRequest{})
+	if err != nil || block.GetBlock() != "1" || block.GetSyncing() {
+		t.Errorf("expected block 1; got %v %v", block, err)
+	}
+
+	addrs, err := client.GetAddresses(ctx, &parserpb.GetAddressesRequest{})
+	if err != nil || len(addrs.GetAddresses()) != 2 {
+		t.Errorf("expected 2 addresses; got %v %v", addrs, err)
+	}
+
+	count, err := client.GetCount(ctx, &parserpb.GetCountRequest{Address: testAddrA})
+	if err != nil || count.GetCount() != 2 {
+		t.Errorf("expected count 2; got %v %v", count, err)
+	}
+
+	txns, err := client.GetTransactions(ctx, &parserpb.GetTransactionsRequest{Address: testAddrB})
+	if err != nil || len(txns.GetTransactions()) != 1 || txns.GetTransactions()[0].GetBlockNumber() != "0x1" {
+		t.Errorf("expected 1 transaction; got %v %v", txns, err)
+	}
+
+	_, err = client.GetCount(ctx, &parserpb.GetCountRequest{Address: "bad"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument; got %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for check() != healthpb.HealthCheckResponse_SERVING {
+		if time.Now().After(deadline) {
+			t.Fatal("parser service did not become SERVING")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// testAuth accepts a single token
+type testAuth string
+
+func (a testAuth) Principal(token string) (auth.Principal, error) {
+	if token != string(a) {
+		return auth.Principal{}, auth.ErrInvalidKey
+	}
+	return auth.Principal{ID: "client", Limits: auth.Limits{MaxSubscriptions: 1}}, nil
+}
+
+func TestServiceAuth(t *testing.T) {
+	conn, _ := newTestClient(t, Config{Auth: testAuth("secret")})
+	client := parserpb.NewParserServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err := client.GetCurrentBlock(ctx, &parserpb.GetCurrentBlockRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without credentials; got %v", err)
+	}
+	bad := metadata.AppendToOutgoingContext(ctx, APIKeyMetadata, "wrong")
+	if _, err := client.GetCurrentBlock(bad, &parserpb.GetCurrentBlockRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated with a wrong key; got %v", err)
+	}
+
+	// Health checks are left open
+	health := healthpb.NewHealthClient(conn)
+	if _, err := health.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Errorf("expected an open health check; got %v", err)
+	}
+
+	authed := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+	if _, err := client.GetCurrentBlock(authed, &parserpb.GetCurrentBlockRequest{}); err != nil {
+		t.Errorf("expected an authenticated call; got %v", err)
+	}
+
+	first, err := client.Subscribe(authed, &parserpb.SubscribeRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.CloseSend()
+	// The stream is opened before the handler acquires its slot
+	time.Sleep(50 * time.Millisecond)
+
+	second, err := client.Subscribe(authed, &parserpb.SubscribeRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.Recv(); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted above the subscription cap; got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/eth"
+	"strconv"
+)
+
+// maxTopCounterparties bounds the top query parameter of the
+// stats of an address, and maxTopAddresses the limit of the most
+// active addresses
+const (
+	maxTopCounterparties = 100
+	maxTopAddresses      = 100
+)
+
+// defaultTopAddresses is the number of most active addresses
+// returned unless asked otherwise
+const defaultTopAddresses = 10
+
+type GetTopAddressesResponse struct {
+	Addresses []eth.ActiveAddress `json:"addresses"`
+}
+
+// GetAddressStats returns the analytics of an address. The
+// interval query parameter buckets its activity by hour or day,
+// the default, and top sets the number of counterparties.
+func (r RestServer) GetAddressStats(w http.ResponseWriter, req *http.Request) {
+	addr, ok := pathAddress(w, req)
+	if !ok {
+		return
+	}
+	q := req.URL.Query()
+	interval := q.Get("interval")
+	switch interval {
+	case "":
+		interval = eth.IntervalDay
+	case eth.IntervalHour, eth.IntervalDay:
+	default:
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Errorf("interval %q is neither %s nor %s", interval, eth.IntervalHour, eth.IntervalDay))
+		return
+	}
+	top := eth.DefaultTopCounterparties
+	if s := q.Get("top"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxTopCounterparties {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Errorf("top %q is not between 1 and %d", s, maxTopCounterparties))
+			return
+		}
+		top = n
+	}
+
+	analyzer, ok := r.Parser.(eth.Analyzer)
+	var stats eth.AddressStats
+	if ok {
+		stats, ok = analyzer.AddressStats(addr, interval, top)
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeNotFound, errors.New("no transactions indexed for "+addr))
+		return
+	}
+	writeJSONTraced(req.Context(), w, http.StatusOK, stats)
+}
+
+// GetTopAddresses returns the addresses with the most transactions,
+// tracked as they are indexed rather than sorted from every
+// address. The limit query parameter sets how many.
+func (r RestServer) GetTopAddresses(w http.ResponseWriter, req *http.Request) {
+	limit := defaultTopAddresses
+	if s := req.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxTopAddresses {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Errorf("limit %q is not between 1 and %d", s, maxTopAddresses))
+			return
+		}
+		limit = n
+	}
+	addrs := []eth.ActiveAddress{}
+	if analyzer, ok := r.Parser.(eth.Analyzer); ok {
+		addrs = analyzer.TopAddresses(limit)
+	}
+	writeJSONTraced(req.Context(), w, http.StatusOK, GetTopAddressesResponse{Addresses: addrs})
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/auth"
+	"strconv"
+	"strings"
+)
+
+// APIKeyHeader carries an API key as an alternative to the
+// Authorization header
+const APIKeyHeader = "X-API-Key"
+
+// AccessTokenParam carries an API key or a JWT for clients that
+// cannot set headers, e.g. EventSource and browser WebSockets
+const AccessTokenParam = "access_token"
+
+// adminPrincipal is authenticated by the admin secret
+const adminPrincipal = "admin"
+
+var (
+	ErrMissingCredentials   = errors.New("missing credentials")
+	ErrRateLimited          = errors.New("rate limit exceeded")
+	ErrTooManySubscriptions = errors.New("too many concurrent subscriptions")
+	ErrForbidden            = errors.New("admin role required")
+)
+
+// Auth authenticates requests with an API key or a JWT and
+// enforces the quotas of the client
+type Auth struct {
+	Keys *auth.KeyStore
+	// JWT verifies bearer tokens; nil disables JWTs
+	JWT *auth.JWTVerifier
+	// AdminSecret authenticates an admin without a key, e.g.
+	// to issue the first keys; empty disables it
+	AdminSecret string
+	Quotas      *auth.Quotas
+}
+
+// NewAuth instantiate an authenticator
+func NewAuth(keys *auth.KeyStore, jwt *auth.JWTVerifier, adminSecret string) *Auth {
+	return &Auth{
+		Keys:        keys,
+		JWT:         jwt,
+		AdminSecret: adminSecret,
+		Quotas:      auth.NewQuotas(),
+	}
+}
+
+// credentials returns the token presented by the request
+func credentials(req *http.Request) string {
+	if bearer, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); found {
+		return strings.TrimSpace(bearer)
+	}
+	if key := req.Header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+	return req.URL.Query().Get(AccessTokenParam)
+}
+
+// Principal returns the client a token authenticates
+func (a *Auth) Principal(token string) (auth.Principal, error) {
+	switch {
+	case token == "":
+		return auth.Principal{}, ErrMissingCredentials
+	case a.AdminSecret != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.AdminSecret)) == 1:
+		return auth.Principal{ID: adminPrincipal, Role: auth.RoleAdmin}, nil
+	case strings.HasPrefix(token, auth.KeyPrefix):
+		if a.Keys == nil {
+			return auth.Principal{}, auth.ErrInvalidKey
+		}
+		key, err := a.Keys.Authenticate(token)
+		if err != nil {
+			return auth.Principal{}, err
+		}
+		return key.Principal(), nil
+	case a.JWT != nil:
+		return a.JWT.Verify(token)
+	}
+	return auth.Principal{}, auth.ErrInvalidToken
+}
+
+// Authenticate rejects requests without valid credentials or
+// above the client's rate limit, and stores the principal in
+// the request context
+func (a *Auth) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		p, err := a.Principal(credentials(req))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="txparser"`)
+			writeError(w, http.StatusUnauthorized, CodeUnauthenticated, err)
+			return
+		}
+		if ok, wait := a.Quotas.Allow(p); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(w, http.StatusTooManyRequests, CodeRateLimited, ErrRateLimited)
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(auth.NewContext(req.Context(), p)))
+	})
+}
+
+// LimitSubscriptions caps the concurrent streams of a client.
+// It must be wrapped by Authenticate.
+func (a *Auth) LimitSubscriptions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		p, ok := auth.FromContext(req.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, CodeUnauthenticated, ErrMissingCredentials)
+			return
+		}
+		if !a.Quotas.Acquire(p) {
+			writeError(w, http.StatusTooManyRequests, CodeTooManySubscriptions, ErrTooManySubscriptions)
+			return
+		}
+		defer a.Quotas.Release(p)
+		next.ServeHTTP(w, req)
+	})
+}
+
+// RequireAdmin rejects clients without the admin role. It must
+// be wrapped by Authenticate.
+func (a *Auth) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		p, ok := auth.FromContext(req.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, CodeUnauthenticated, ErrMissingCredentials)
+			return
+		}
+		if !p.IsAdmin() {
+			writeError(w, http.StatusForbidden, CodeForbidden, ErrForbidden)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// Protect requires authentication when r.Auth is set
+func (r RestServer) Protect(h http.HandlerFunc) http.Handler {
+	if r.Auth == nil {
+		return h
+	}
+	return r.Auth.Authenticate(h)
+}
+
+// ProtectStream requires authentication and counts the
+// subscription against the client's cap when r.Auth is set
+func (r RestServer) ProtectStream(h http.HandlerFunc) http.Handler {
+	if r.Auth == nil {
+		return h
+	}
+	return r.Auth.Authenticate(r.Auth.LimitSubscriptions(h))
+}
+
+// ProtectAdmin requires the admin role when r.Auth is set
+func (r RestServer) ProtectAdmin(h http.HandlerFunc) http.Handler {
+	if r.Auth == nil {
+		return h
+	}
+	return r.Auth.Authenticate(r.Auth.RequireAdmin(h))
+}
@@ -0,0 +1,206 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/auth"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/store"
+	"paulwizviz/go-eth-app/internal/webhook"
+	"paulwizviz/go-eth-app/pkg/client"
+	"testing"
+	"time"
+)
+
+func TestAuth(t *testing.T) {
+	p, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{testTxn(1, 0, testAddrA, testAddrB)}})
+	const adminSecret = "admin-secret"
+	rest := RestServer{
+		Parser: p,
+		Auth:   NewAuth(auth.NewKeyStore(store.NewInMemoryStorage()), nil, adminSecret),
+	}
+	mux := http.NewServeMux()
+	rest.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	ctx := context.Background()
+
+	withKey := func(key string) client.ClientOption {
+		return client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set(APIKeyHeader, key)
+			return nil
+		})
+	}
+
+	anon, _ := client.NewClientWithResponses(srv.URL + V1Prefix)
+	resp, err := anon.GetStatusWithResponse(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode() != http.StatusUnauthorized || resp.JSON401 == nil || string(resp.JSON401.Error.Code) != CodeUnauthenticated {
+		t.Errorf("expected 401; got %d %s", resp.StatusCode(), resp.Body)
+	}
+	if spec, err := http.Get(srv.URL + V1Prefix + "/openapi.yaml"); err != nil || spec.StatusCode != http.StatusOK {
+		t.Errorf("expected the OpenAPI document to be public; got %v", err)
+	} else {
+		spec.Body.Close()
+	}
+
+	// Issue a key limited to 2 requests and one subscription
+	admin, _ := client.NewClientWithResponses(srv.URL+V1Prefix, withKey(adminSecret))
+	rate, burst, subs := float32(0.001), 2, 1
+	issued, err := admin.IssueKeyWithResponse(ctx, client.IssueKeyRequest{
+		Name:   "frontend",
+		Limits: &client.Limits{RateLimit: &rate, Burst: &burst, MaxSubscriptions: &subs},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issued.StatusCode() != http.StatusCreated {
+		t.Fatalf("expected 201; got %d %s", issued.StatusCode(), issued.Body)
+	}
+
+	user, _ := client.NewClientWithResponses(srv.URL+V1Prefix, withKey(issued.JSON201.Secret))
+	if resp, _ := user.ListKeysWithResponse(ctx); resp.StatusCode() != http.StatusForbidden {
+		t.Errorf("expected 403 for a user on admin routes; got %d", resp.StatusCode())
+	}
+	if resp, _ := user.GetStatusWithResponse(ctx); resp.StatusCode() != http.StatusOK {
+		t.Errorf("expected 200; got %d %s", resp.StatusCode(), resp.Body)
+	}
+	resp, _ = user.GetStatusWithResponse(ctx)
+	if resp.StatusCode() != http.StatusTooManyRequests || resp.HTTPResponse.Header.Get("Retry-After") == "" {
+		t.Errorf("expected 429 with Retry-After; got %d %s", resp.StatusCode(), resp.Body)
+	}
+
+	revoked, err := admin.RevokeKeyWithResponse(ctx, issued.JSON201.Id)
+	if err != nil || revoked.StatusCode() != http.StatusNoContent {
+		t.Fatalf("expected 204; got %v %v", revoked.StatusCode(), err)
+	}
+	keys, _ := admin.ListKeysWithResponse(ctx)
+	if keys.JSON200 == nil || len(keys.JSON200.Keys) != 1 || keys.JSON200.Keys[0].RevokedAt == nil {
+		t.Errorf("expected one revoked key; got %s", keys.Body)
+	}
+}
+
+func TestSubscriptionCap(t *testing.T) {
+	p, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1"})
+	keys := auth.NewKeyStore(store.NewInMemoryStorage())
+	_, secret, _ := keys.Issue("frontend", auth.RoleUser, auth.Limits{MaxSubscriptions: 1})
+	rest := RestServer{Parser: p, Auth: NewAuth(keys, nil, "")}
+	mux := http.NewServeMux()
+	rest.RegisterV1(mux)
+	srv := httptest.NewServer(CORS{AllowedOrigins: []string{"https://app.example.com"}}.Handler(mux))
+	defer srv.Close()
+
+	subscribe := func() *http.Response {
+		t.Helper()
+		// EventSource cannot set headers
+		resp, err := http.Get(srv.URL + V1Prefix + "/addresses/" + testAddrA + "/subscribe?" + AccessTokenParam + "=" + secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	first := subscribe()
+	if first.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200; got %d", first.StatusCode)
+	}
+	second := subscribe()
+	second.Body.Close()
+	if second.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected 429 above the cap; got %d", second.StatusCode)
+	}
+
+	// Closing the first stream frees the slot
+	first.Body.Close()
+	deadline := time.Now().Add(time.Second)
+	for {
+		resp := subscribe()
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("subscription slot was not released")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	preflight := func(origin string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodOptions, srv.URL+V1Prefix+"/addresses", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+	if resp := preflight("https://app.example.com"); resp.StatusCode != http.StatusNoContent || resp.Header.Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Errorf("expected allowed preflight; got %d %v", resp.StatusCode, resp.Header)
+	}
+	if resp := preflight("https://evil.example.com"); resp.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("expected no CORS headers for other origins; got %v", resp.Header)
+	}
+}
+
+func TestWebhookOwners(t *testing.T) {
+	p, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1"})
+	const adminSecret = "admin-secret"
+	keys := auth.NewKeyStore(store.NewInMemoryStorage())
+	_, aliceKey, _ := keys.Issue("alice", auth.RoleUser, auth.Limits{})
+	_, bobKey, _ := keys.Issue("bob", auth.RoleUser, auth.Limits{})
+	webhooks := webhook.NewDefaultDispatcher(p)
+	defer webhooks.Close()
+	rest := RestServer{Parser: p, Webhooks: webhooks, Auth: NewAuth(keys, nil, adminSecret)}
+	mux := http.NewServeMux()
+	rest.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	ctx := context.Background()
+
+	newClient := func(key string) *client.ClientWithResponses {
+		c, _ := client.NewClientWithResponses(srv.URL+V1Prefix, client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set(APIKeyHeader, key)
+			return nil
+		}))
+		return c
+	}
+	alice, bob, admin := newClient(aliceKey), newClient(bobKey), newClient(adminSecret)
+
+	addr := testAddrA
+	if resp, _ := alice.RegisterWebhookWithResponse(ctx, client.RegisterWebhookRequest{Url: "https://example.com/hook"}); resp.StatusCode() != http.StatusBadRequest {
+		t.Errorf("expected 400 without an address or filter; got %d", resp.StatusCode())
+	}
+	if resp, _ := alice.RegisterWebhookWithResponse(ctx, client.RegisterWebhookRequest{Url: "http://127.0.0.1/hook", Address: &addr}); resp.StatusCode() != http.StatusBadRequest {
+		t.Errorf("expected 400 for a loopback url; got %d", resp.StatusCode())
+	}
+	concurrency := 100000000
+	if resp, _ := alice.RegisterWebhookWithResponse(ctx, client.RegisterWebhookRequest{Url: "https://example.com/hook", Address: &addr, Concurrency: &concurrency}); resp.StatusCode() != http.StatusBadRequest {
+		t.Errorf("expected 400 for a concurrency above the maximum; got %d", resp.StatusCode())
+	}
+	registered, err := alice.RegisterWebhookWithResponse(ctx, client.RegisterWebhookRequest{Url: "https://example.com/hook", Address: &addr})
+	if err != nil || registered.JSON201 == nil {
+		t.Fatalf("expected 201; got %v %s", err, registered.Body)
+	}
+	id := registered.JSON201.Id
+
+	if resp, _ := bob.ListWebhooksWithResponse(ctx); resp.JSON200 == nil || len(resp.JSON200.Webhooks) != 0 {
+		t.Errorf("expected no webhook of bob; got %s", resp.Body)
+	}
+	if resp, _ := bob.ListWebhookDeliveriesWithResponse(ctx, id); resp.StatusCode() != http.StatusNotFound {
+		t.Errorf("expected 404 for the deliveries of another client; got %d", resp.StatusCode())
+	}
+	if resp, _ := bob.DeleteWebhookWithResponse(ctx, id); resp.StatusCode() != http.StatusNotFound {
+		t.Errorf("expected 404 deleting the webhook of another client; got %d", resp.StatusCode())
+	}
+	if resp, _ := admin.ListWebhooksWithResponse(ctx); resp.JSON200 == nil || len(resp.JSON200.Webhooks) != 1 {
+		t.Errorf("expected the admin to see every webhook; got %s", resp.Body)
+	}
+	if resp, _ := alice.DeleteWebhookWithResponse(ctx, id); resp.StatusCode() != http.StatusNoContent {
+		t.Errorf("expected 204; got %d", resp.StatusCode())
+	}
+}
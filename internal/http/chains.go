@@ -0,0 +1,67 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/eth"
+	"strconv"
+)
+
+// ChainsPath is the path, under the unversioned or V1Prefix
+// routes, of the chains ingested by the service
+const ChainsPath = "/chains"
+
+type Chain struct {
+	ChainID   int64  `json:"chainId"`
+	Block     string `json:"block"`
+	Syncing   bool   `json:"syncing"`
+	Addresses string `json:"addresses"`
+}
+type GetChainsResponse struct {
+	Chains []Chain `json:"chains"`
+}
+
+// RegisterChains registers the chain list and, for every chain,
+// the parser routes namespaced by its ID, e.g.
+// /v1/chains/8453/addresses/{address}. Both the unversioned and
+// the V1Prefix routes are registered. The server's own Parser is
+// not used.
+func (r RestServer) RegisterChains(mux *http.ServeMux, chains eth.Chains) {
+	for _, prefix := range []string{"", V1Prefix} {
+		base := r
+		base.BasePath = prefix
+		mux.Handle("GET "+prefix+ChainsPath, base.Protect(base.getChains(chains)))
+		for _, id := range chains.IDs() {
+			c := r
+			c.Parser = chains[id]
+			c.RegisterParser(mux, fmt.Sprintf("%s%s/%d", prefix, ChainsPath, id))
+		}
+		// Any other chain ID is unknown
+		mux.Handle("GET "+prefix+ChainsPath+"/{chainId}/", base.Protect(unknownChain))
+	}
+}
+
+func (r RestServer) getChains(chains eth.Chains) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		resp := GetChainsResponse{Chains: []Chain{}}
+		for _, id := range chains.IDs() {
+			block := chains[id].GetCurrentBlock()
+			resp.Chains = append(resp.Chains, Chain{
+				ChainID:   id,
+				Block:     block,
+				Syncing:   block == eth.NoBlock,
+				Addresses: fmt.Sprintf("%s%s/%d/addresses", r.baseURL(req), ChainsPath, id),
+			})
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func unknownChain(w http.ResponseWriter, req *http.Request) {
+	id := req.PathValue("chainId")
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Errorf("chain ID %q is not a number", id))
+		return
+	}
+	writeError(w, http.StatusNotFound, CodeNotFound, fmt.Errorf("chain %s is not ingested", id))
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/pkg/client"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestChains(t *testing.T) {
+	mainnet, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{testTxn(1, 0, testAddrA, testAddrB)}})
+	base, _ := newTestParser(t, eth.BlockTxn{BlockNum: "2", Txns: []eth.Transaction{
+		testTxn(2, 0, testAddrA, testAddrB),
+		testTxn(2, 1, testAddrB, testAddrA),
+	}})
+	mux := http.NewServeMux()
+	RestServer{}.RegisterChains(mux, eth.Chains{1: mainnet, 8453: base})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, _ := client.NewClientWithResponses(srv.URL + V1Prefix)
+	ctx := context.Background()
+
+	chains, err := c.ListChainsWithResponse(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chains.JSON200 == nil || len(chains.JSON200.Chains) != 2 {
+		t.Fatalf("expected 2 chains; got %d %s", chains.StatusCode(), chains.Body)
+	}
+	if got := chains.JSON200.Chains[1]; got.ChainId != 8453 || got.Block != "2" || got.Addresses != srv.URL+"/v1/chains/8453/addresses" {
+		t.Errorf("unexpected chain %+v", got)
+	}
+
+	// The same wallet is tracked apart on each chain
+	for id, want := range map[int64]int{1: 1, 8453: 2} {
+		resp, err := c.GetChainAddressWithResponse(ctx, id, testAddrA)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.JSON200 == nil || len(resp.JSON200.Transactions) != want {
+			t.Errorf("chain %d: expected %d transactions; got %d %s", id, want, resp.StatusCode(), resp.Body)
+			continue
+		}
+		subscribe := srv.URL + "/v1/chains/" + strconv.FormatInt(id, 10) + "/addresses/" + common.HexToAddress(testAddrA).Hex() + "/subscribe"
+		if resp.JSON200.Links.Subscribe != subscribe {
+			t.Errorf("chain %d: expected link %s; got %s", id, subscribe, resp.JSON200.Links.Subscribe)
+		}
+	}
+
+	resp, err := c.GetChainAddressWithResponse(ctx, 10, testAddrA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode() != http.StatusNotFound || resp.JSON404 == nil || string(resp.JSON404.Error.Code) != CodeNotFound {
+		t.Errorf("expected 404 for an unknown chain; got %d %s", resp.StatusCode(), resp.Body)
+	}
+
+	// The unversioned routes are registered too
+	status, err := http.Get(srv.URL + "/chains/1/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	status.Body.Close()
+	if status.StatusCode != http.StatusOK {
+		t.Errorf("expected 200; got %d", status.StatusCode)
+	}
+}
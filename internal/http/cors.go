@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// CORS answers preflight requests and sets the CORS headers of
+// responses to allowed origins
+type CORS struct {
+	// AllowedOrigins lists the origins allowed, e.g.
+	// https://app.example.com; "*" allows any origin and none
+	// allows no cross-origin requests
+	AllowedOrigins []string
+}
+
+func (c CORS) allowed(origin string) bool {
+	return slices.Contains(c.AllowedOrigins, "*") || slices.Contains(c.AllowedOrigins, origin)
+}
+
+// Handler wraps a handler. Requests from other origins are
+// served without CORS headers, so browsers block the response,
+// except WebSocket upgrades which are refused outright.
+func (c CORS) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		origin := req.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+
+		if !c.allowed(origin) {
+			if strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
+				http.Error(w, "origin not allowed", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Expose-Headers", "Content-Type, Retry-After, WWW-Authenticate")
+
+		if req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Last-Event-ID, "+APIKeyHeader)
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
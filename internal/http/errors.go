@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Error codes returned in ErrorResponse
+const (
+	CodeInvalidRequest  = "invalid_request"
+	CodeInvalidAddress  = "invalid_address"
+	CodeInvalidFilter   = "invalid_filter"
+	CodeInvalidCursor   = "invalid_cursor"
+	CodeNotFound        = "not_found"
+	CodeSyncing         = "syncing"
+	CodeInternalError   = "internal_error"
+	CodeUnauthenticated = "unauthenticated"
+	CodeForbidden       = "forbidden"
+	CodeRateLimited     = "rate_limited"
+	// CodeTooManySubscriptions is returned when a client holds
+	// its cap of concurrent streams
+	CodeTooManySubscriptions = "too_many_subscriptions"
+	// CodeBackfillRunning is returned when a watchlist backfill
+	// is started while another runs
+	CodeBackfillRunning = "backfill_running"
+	// CodeCursorExpired is returned when a stream resumes from
+	// further back than it replays
+	CodeCursorExpired = "cursor_expired"
+)
+
+// ErrorBody describes a failed request
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse is the envelope of every error returned
+// by the REST API
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, code string, err error) {
+	writeJSON(w, status, ErrorResponse{Error: ErrorBody{Code: code, Message: err.Error()}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		b = []byte(`{"error":{"code":"` + CodeInternalError + `","message":"unable to encode response"}}`)
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+var tracer = otel.Tracer("paulwizviz/go-eth-app/internal/http")
+
+// writeJSONTraced is writeJSON recorded as a span of ctx, used for
+// responses large enough for encoding to matter
+func writeJSONTraced(ctx context.Context, w http.ResponseWriter, status int, v any) {
+	_, span := tracer.Start(ctx, "http.writeJSON")
+	defer span.End()
+	writeJSON(w, status, v)
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"errors"
+	"paulwizviz/go-eth-app/internal/eth"
+)
+
+// filterErrorCode maps an eth.ParseFilter error to an error code
+func filterErrorCode(err error) string {
+	if errors.Is(err, eth.ErrInvalidAddress) || errors.Is(err, eth.ErrInvalidChecksum) {
+		return CodeInvalidAddress
+	}
+	return CodeInvalidFilter
+}
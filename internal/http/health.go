@@ -0,0 +1,73 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/metrics"
+)
+
+// CodeLagging is returned by the readiness probe when ingestion
+// falls behind the chain head
+const CodeLagging = "lagging"
+
+// DefaultMaxLag is the number of blocks ingestion may fall
+// behind before the service reports not ready
+const DefaultMaxLag = 10
+
+// Health serves the liveness and readiness probes
+type Health struct {
+	// Parser is probed when Chains is empty, under chain ID 0
+	Parser eth.Parser
+	// Chains are all probed; the service is ready once every
+	// chain is
+	Chains eth.Chains
+	// MaxLag is the number of blocks a parser may be behind
+	// its chain head while ready
+	MaxLag int64
+}
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type ChainReady struct {
+	ChainID int64 `json:"chainId"`
+	Block   int64 `json:"block"`
+	Head    int64 `json:"head"`
+	Lag     int64 `json:"lag"`
+}
+type ReadyResponse struct {
+	Status string       `json:"status"`
+	Chains []ChainReady `json:"chains"`
+}
+
+// Healthz reports the process is serving requests
+func (h Health) Healthz(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// Readyz reports whether every parser has processed a block and
+// is within MaxLag blocks of its chain head
+func (h Health) Readyz(w http.ResponseWriter, req *http.Request) {
+	chains := h.Chains
+	if len(chains) == 0 {
+		chains = eth.Chains{0: h.Parser}
+	}
+	resp := ReadyResponse{Status: "ready", Chains: []ChainReady{}}
+	for _, id := range chains.IDs() {
+		if chains[id].GetCurrentBlock() == eth.NoBlock {
+			writeError(w, http.StatusServiceUnavailable, CodeSyncing, fmt.Errorf("chain %d: %w", id, ErrSyncing))
+			return
+		}
+		head, parsed := metrics.Progress(id)
+		lag, _ := metrics.IngestionLag(id)
+		if lag > h.MaxLag {
+			writeError(w, http.StatusServiceUnavailable, CodeLagging,
+				fmt.Errorf("chain %d parser is %d blocks behind the chain head, more than %d", id, lag, h.MaxLag))
+			return
+		}
+		resp.Chains = append(resp.Chains, ChainReady{ChainID: id, Block: parsed, Head: head, Lag: lag})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/metrics"
+	"testing"
+)
+
+func TestReadyz(t *testing.T) {
+	ch := make(chan eth.BlockTxn)
+	defer close(ch)
+	p, _ := eth.NewParser(ch, eth.ParserConfig{})
+	h := Health{Parser: p, MaxLag: 2}
+
+	readyz := func() (int, ErrorResponse) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.Readyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var body ErrorResponse
+		json.NewDecoder(rec.Body).Decode(&body)
+		return rec.Code, body
+	}
+
+	if code, body := readyz(); code != http.StatusServiceUnavailable || body.Error.Code != CodeSyncing {
+		t.Errorf("expected 503 syncing; got %d %+v", code, body)
+	}
+
+	ch <- eth.BlockTxn{BlockNum: "5"}
+	waitForBlock(t, p, "5")
+	metrics.SetChainHead(0, 10)
+	if code, body := readyz(); code != http.StatusServiceUnavailable || body.Error.Code != CodeLagging {
+		t.Errorf("expected 503 lagging; got %d %+v", code, body)
+	}
+
+	metrics.SetChainHead(0, 7)
+	if code, _ := readyz(); code != http.StatusOK {
+		t.Errorf("expected 200; got %d", code)
+	}
+}
+
+func TestReadyzChains(t *testing.T) {
+	mainnet, _ := newTestParser(t, eth.BlockTxn{BlockNum: "5"})
+	ch := make(chan eth.BlockTxn)
+	defer close(ch)
+	base, _ := eth.NewParser(ch, eth.ParserConfig{ChainID: 8453})
+	h := Health{Chains: eth.Chains{1: mainnet, 8453: base}, MaxLag: 2}
+
+	rec := httptest.NewRecorder()
+	h.Readyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 while a chain is syncing; got %d", rec.Code)
+	}
+
+	ch <- eth.BlockTxn{BlockNum: "9"}
+	waitForBlock(t, base, "9")
+	rec = httptest.NewRecorder()
+	h.Readyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var body ReadyResponse
+	json.NewDecoder(rec.Body).Decode(&body)
+	if rec.Code != http.StatusOK || len(body.Chains) != 2 || body.Chains[1].Block != 9 {
+		t.Errorf("expected both chains ready; got %d %+v", rec.Code, body)
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Health{}.Healthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200; got %d", rec.Code)
+	}
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/eth"
+)
+
+type GetIndexingResponse struct {
+	eth.RangeProgress
+	// Percent of the range done
+	Percent float64 `json:"percent"`
+	// ETASeconds is the time left at the current rate, zero
+	// unless running
+	ETASeconds int64 `json:"etaSeconds"`
+}
+
+// GetIndexing returns the progress of the latest range indexing
+// job
+func (r RestServer) GetIndexing(w http.ResponseWriter, req *http.Request) {
+	indexer, ok := r.Parser.(eth.Indexer)
+	var p eth.RangeProgress
+	if ok {
+		p, ok = indexer.RangeProgress()
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeNotFound, errors.New("no range indexing job"))
+		return
+	}
+	resp := GetIndexingResponse{RangeProgress: p, ETASeconds: int64(p.ETA.Seconds())}
+	if p.Total > 0 {
+		resp.Percent = float64(p.Indexed+p.Skipped) / float64(p.Total) * 100
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/auth"
+)
+
+// IssueKeyRequest describes a key to issue. Unset limits are
+// unlimited.
+type IssueKeyRequest struct {
+	Name   string      `json:"name"`
+	Role   string      `json:"role,omitempty"`
+	Limits auth.Limits `json:"limits"`
+}
+
+// IssueKeyResponse returns the key secret. It is not
+// retrievable afterwards.
+type IssueKeyResponse struct {
+	auth.Key
+	Secret string `json:"secret"`
+}
+
+// maxKeyRequestSize bounds the body of a key request
+const maxKeyRequestSize = 64 << 10
+
+type GetKeysResponse struct {
+	Keys []auth.Key `json:"keys"`
+}
+
+func (r RestServer) IssueKey(w http.ResponseWriter, req *http.Request) {
+	var body IssueKeyRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxKeyRequestSize)).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err)
+		return
+	}
+	key, secret, err := r.Auth.Keys.Issue(body.Name, body.Role, body.Limits)
+	if err != nil {
+		keyError(w, err)
+		return
+	}
+	r.logger().Info("issued API key", "key", key.ID, "name", key.Name, "role", key.Role)
+	writeJSON(w, http.StatusCreated, IssueKeyResponse{Key: key, Secret: secret})
+}
+
+func (r RestServer) GetKeys(w http.ResponseWriter, req *http.Request) {
+	keys, err := r.Auth.Keys.Keys()
+	if err != nil {
+		keyError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, GetKeysResponse{Keys: keys})
+}
+
+func (r RestServer) RevokeKey(w http.ResponseWriter, req *http.Request) {
+	key, err := r.Auth.Keys.Revoke(req.PathValue("id"))
+	if err != nil {
+		keyError(w, err)
+		return
+	}
+	r.logger().Info("revoked API key", "key", key.ID, "name", key.Name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func keyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, auth.ErrKeyNotFound):
+		writeError(w, http.StatusNotFound, CodeNotFound, err)
+	case errors.Is(err, auth.ErrMissingKeyName), errors.Is(err, auth.ErrInvalidRole), errors.Is(err, auth.ErrInvalidLimits):
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err)
+	default:
+		writeError(w, http.StatusInternalServerError, CodeInternalError, err)
+	}
+}
@@ -1,21 +1,138 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/observer"
+	"paulwizviz/go-eth-app/internal/webhook"
 	"sort"
+	"strconv"
+	"strings"
 )
 
+// DefaultSubscribeOptions is used for SSE and WebSocket
+// subscriptions unless RestServer.SubscribeOptions is set. A slow
+// client loses its oldest undelivered transactions rather than
+// stalling the parser.
+var DefaultSubscribeOptions = observer.Options{
+	Buffer: 64,
+	Policy: observer.PolicyDropOldest,
+}
+
 // RestServer is an abstraction of a RESTFul server
 type RestServer struct {
-	Parser eth.Parser
+	Parser   eth.Parser
+	Webhooks *webhook.Dispatcher
+	// Auth protects the routes registered by RegisterV1; nil
+	// leaves them open
+	Auth *Auth
+	// BasePath prefixes the links in responses, e.g. /v1
+	BasePath string
+	// TrustProxy builds links from the X-Forwarded-Proto and
+	// X-Forwarded-Host headers; enable it only behind a reverse
+	// proxy that sets them
+	TrustProxy bool
+	// Logger receives the server logs; nil discards them
+	Logger *slog.Logger
+	// Context bounds the work requests leave running, e.g.
+	// watchlist backfills; nil never cancels it
+	Context context.Context
+	// ReplayWindow is the number of blocks behind the current
+	// block a stream resumes from, DefaultReplayWindow if zero
+	ReplayWindow uint64
+	// MaxReplay bounds the missed transactions a stream
+	// replays, DefaultMaxReplay if zero
+	MaxReplay int
+	// SubscribeOptions sizes the SSE and WebSocket
+	// subscriptions, DefaultSubscribeOptions if zero
+	SubscribeOptions observer.Options
+}
+
+// subscribeOptions returns the options of a subscription
+func (r RestServer) subscribeOptions() observer.Options {
+	if r.SubscribeOptions == (observer.Options{}) {
+		return DefaultSubscribeOptions
+	}
+	return r.SubscribeOptions
+}
+
+// Defaults of the replay of a resumed stream
+const (
+	DefaultReplayWindow = 128
+	DefaultMaxReplay    = 1000
+)
+
+var (
+	// ErrCursorExpired is returned when a stream resumes from a
+	// cursor older than the replay window
+	ErrCursorExpired = errors.New("cursor older than the replay window")
+	// ErrReplayTooLarge is returned when a stream misses more
+	// transactions than MaxReplay
+	ErrReplayTooLarge = errors.New("too many missed transactions to replay")
+)
+
+// context returns the context of the work requests leave running
+func (r RestServer) context() context.Context {
+	if r.Context == nil {
+		return context.Background()
+	}
+	return r.Context
+}
+
+func (r RestServer) logger() *slog.Logger {
+	if r.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return r.Logger
+}
+
+// ErrSyncing is returned while no block has been parsed
+var ErrSyncing = errors.New("no block parsed yet")
+
+// syncing reports whether the parser has yet to process a block
+func (r RestServer) syncing() bool {
+	return r.Parser.GetCurrentBlock() == eth.NoBlock
+}
+
+// baseURL returns the scheme, host and base path used to build
+// links, honouring a TLS connection or, with TrustProxy, a
+// reverse proxy
+func (r RestServer) baseURL(req *http.Request) string {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	host := req.Host
+	if r.TrustProxy {
+		if proto := req.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if fwd := req.Header.Get("X-Forwarded-Host"); fwd != "" {
+			host = fwd
+		}
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, host, r.BasePath)
+}
+
+// pathAddress returns the EIP-55 form of the address path value
+// or writes a 400 response
+func pathAddress(w http.ResponseWriter, req *http.Request) (string, bool) {
+	addr, err := eth.NormalizeAddress(req.PathValue("address"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidAddress, err)
+		return "", false
+	}
+	return addr, true
 }
 
 type GetCurrentBlockResponse struct {
 	Block     string `json:"block"`
+	Syncing   bool   `json:"syncing"`
 	Addresses string `json:"addresses"`
 }
 
@@ -24,75 +141,384 @@ func (r RestServer) GetCurrentBlock(w http.ResponseWriter, req *http.Request) {
 
 	resp := GetCurrentBlockResponse{
 		Block:     blockNum,
-		Addresses: fmt.Sprintf("http://%s/addresses", req.Host),
+		Syncing:   r.syncing(),
+		Addresses: r.baseURL(req) + "/addresses",
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (r RestServer) Subscribe(w http.ResponseWriter, req *http.Request) {
+	addr, ok := pathAddress(w, req)
+	if !ok {
+		return
 	}
-	json, err := json.Marshal(resp)
+
+	from, inclusive, err := resumeCursor(req)
 	if err != nil {
-		panic(err)
+		writeError(w, http.StatusBadRequest, CodeInvalidCursor, err)
+		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(json))
+	sub := r.Parser.Subscribe(addr, r.subscribeOptions())
+
+	r.logger().Info("new subscription", "address", addr, "subscription", sub.ID)
+
+	var missed []eth.Transaction
+	if from != nil {
+		missed, err = r.replay(req.Context(), eth.Filter{Addresses: []string{strings.ToLower(addr)}}, *from, inclusive)
+		if err != nil {
+			sub.Unsubscribe()
+			writeReplayError(w, err)
+			return
+		}
+	}
+	r.stream(w, req, sub, missed)
 }
 
-func (r RestServer) Subscribe(w http.ResponseWriter, req *http.Request) {
-	addr := req.PathValue("address")
+// SubscribeFilter streams transactions matching the filter given
+// in the query parameters. See eth.ParseFilter for the parameters.
+func (r RestServer) SubscribeFilter(w http.ResponseWriter, req *http.Request) {
+	filter, err := eth.ParseFilter(req.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, filterErrorCode(err), err)
+		return
+	}
+	from, inclusive, err := resumeCursor(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidCursor, err)
+		return
+	}
+
+	sub := r.Parser.SubscribeFilter(filter, r.subscribeOptions())
+
+	r.logger().Info("new filter subscription", "filter", req.URL.RawQuery, "subscription", sub.ID)
 
-	sub := r.Parser.Subscribe(addr)
+	var missed []eth.Transaction
+	if from != nil {
+		missed, err = r.replay(req.Context(), filter, *from, inclusive)
+		if err != nil {
+			sub.Unsubscribe()
+			writeReplayError(w, err)
+			return
+		}
+	}
+	r.stream(w, req, sub, missed)
+}
 
-	log.Printf("New subscription for %s; ID: %s\n", addr, sub.ID)
+// resumeCursor returns the position a client resumes from. The
+// EventSource Last-Event-ID header takes precedence and resumes
+// after the event; the since parameter, an event ID or block
+// number, resumes at that position.
+func resumeCursor(req *http.Request) (*eth.Cursor, bool, error) {
+	if id := req.Header.Get("Last-Event-ID"); id != "" {
+		c, err := eth.ParseCursor(id)
+		if err != nil {
+			return nil, false, err
+		}
+		return &c, false, nil
+	}
+	if since := req.URL.Query().Get("since"); since != "" {
+		c, err := eth.ParseCursor(since)
+		if err != nil {
+			return nil, false, err
+		}
+		return &c, true, nil
+	}
+	return nil, false, nil
+}
 
-	// Set CORS headers to allow all origins. You may want to restrict this to specific origins in a production environment.
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Expose-Headers", "Content-Type")
+// replay returns the transactions matching the filter from the
+// cursor onwards, in chain order. The cursor must be within the
+// replay window. The transactions of the filter addresses are
+// read from the store, those of a filter without addresses from
+// the blocks of the window.
+func (r RestServer) replay(ctx context.Context, filter eth.Filter, from eth.Cursor, inclusive bool) ([]eth.Transaction, error) {
+	current, err := strconv.ParseUint(r.Parser.GetCurrentBlock(), 10, 64)
+	if err != nil {
+		// Nothing processed to miss
+		return nil, nil
+	}
+	window := r.ReplayWindow
+	if window == 0 {
+		window = DefaultReplayWindow
+	}
+	if current > window && from.Block < current-window {
+		return nil, fmt.Errorf("%w-%d blocks", ErrCursorExpired, window)
+	}
+	limit := r.MaxReplay
+	if limit == 0 {
+		limit = DefaultMaxReplay
+	}
 
+	type event struct {
+		cursor eth.Cursor
+		txn    eth.Transaction
+	}
+	seen := map[eth.Cursor]bool{}
+	events := []event{}
+	add := func(txns []eth.Transaction) error {
+		for _, txn := range txns {
+			c, ok := eth.TxCursor(txn)
+			if !ok || seen[c] || !filter.Match(txn) {
+				continue
+			}
+			if c.Less(from) || (!inclusive && c == from) {
+				continue
+			}
+			if len(events) == limit {
+				return fmt.Errorf("%w-more than %d", ErrReplayTooLarge, limit)
+			}
+			seen[c] = true
+			events = append(events, event{cursor: c, txn: txn})
+		}
+		return nil
+	}
+
+	if len(filter.Addresses) > 0 {
+		for _, addr := range filter.Addresses {
+			if err := add(r.Parser.GetTransactions(ctx, addr)); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		getter, ok := r.Parser.(eth.BlockGetter)
+		if !ok {
+			return nil, eth.ErrNoBlockReader
+		}
+		for n := from.Block; n <= current; n++ {
+			b, err := getter.GetBlock(ctx, int64(n))
+			if err != nil {
+				return nil, err
+			}
+			if err := add(b.Txns); err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].cursor.Less(events[j].cursor)
+	})
+
+	txns := make([]eth.Transaction, 0, len(events))
+	for _, e := range events {
+		txns = append(txns, e.txn)
+	}
+	return txns, nil
+}
+
+// writeReplayError writes the error of a replay
+func writeReplayError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrCursorExpired), errors.Is(err, ErrReplayTooLarge):
+		writeError(w, http.StatusGone, CodeCursorExpired, err)
+	case errors.Is(err, eth.ErrNoBlockReader):
+		writeError(w, http.StatusBadRequest, CodeInvalidCursor, err)
+	default:
+		writeError(w, http.StatusBadGateway, CodeInternalError, err)
+	}
+}
+
+// stream writes the missed transactions followed by the
+// subscription messages as Server-Sent Events until either the
+// client or the observer closes it. The subscription must be
+// opened before missed is read so nothing falls in between;
+// live messages already covered by the replay are skipped.
+func (r RestServer) stream(w http.ResponseWriter, req *http.Request, sub *observer.Subscription, missed []eth.Transaction) {
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	w.(http.Flusher).Flush()
 
+	var last *eth.Cursor
+	for _, txn := range missed {
+		b, err := json.Marshal(txn)
+		if err != nil {
+			r.logger().Error("encode missed transaction", "subscription", sub.ID, "err", err)
+			continue
+		}
+		c, _ := eth.TxCursor(txn)
+		last = &c
+		writeEvent(w, c.String(), b)
+	}
+	w.(http.Flusher).Flush()
+
+	var dropped uint64
 outer:
 	for {
 		select {
-		case txn := <-sub.Ch:
-			fmt.Fprintf(w, "data: %s\n\n", string(txn))
+		case txn, ok := <-sub.Ch:
+			if !ok {
+				r.logger().Warn("subscription closed by observer", "subscription", sub.ID, "dropped", sub.Dropped())
+				break outer
+			}
+			var id string
+			var t eth.Transaction
+			if err := json.Unmarshal(txn, &t); err == nil {
+				if c, ok := eth.TxCursor(t); ok {
+					if last != nil && !last.Less(c) {
+						continue
+					}
+					id = c.String()
+				}
+			}
+			// Let the client know it missed transactions
+			if d := sub.Dropped(); d != dropped {
+				dropped = d
+				fmt.Fprintf(w, "event: dropped\ndata: {\"dropped\":%d}\n\n", dropped)
+			}
+			writeEvent(w, id, txn)
 			w.(http.Flusher).Flush()
 
 		case <-req.Context().Done():
 			sub.Unsubscribe()
-			log.Printf("Unsubscribing %s", sub.ID)
+			r.logger().Info("unsubscribed", "subscription", sub.ID)
 			break outer
 		}
 	}
 }
 
+func writeEvent(w http.ResponseWriter, id string, data []byte) {
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", string(data))
+}
+
 type GetTransactionsLinks struct {
 	Addresses string `json:"addresses"`
 	Subscribe string `json:"subscribe"`
 }
 type GetTransactionsResponse struct {
+	Address      string               `json:"address"`
+	Count        int64                `json:"count"`
 	Links        GetTransactionsLinks `json:"links"`
 	Transactions []eth.Transaction    `json:"transactions"`
 }
 
 func (r RestServer) GetTransactions(w http.ResponseWriter, req *http.Request) {
-	addr := req.PathValue("address")
-	result := r.Parser.GetTransactions(addr)
-	w.WriteHeader(http.StatusOK)
+	addr, ok := pathAddress(w, req)
+	if !ok {
+		return
+	}
+	if r.syncing() {
+		writeError(w, http.StatusServiceUnavailable, CodeSyncing, ErrSyncing)
+		return
+	}
+
+	result := r.Parser.GetTransactions(req.Context(), strings.ToLower(addr))
+	if len(result) == 0 {
+		writeError(w, http.StatusNotFound, CodeNotFound, fmt.Errorf("no transactions for %s", addr))
+		return
+	}
 
+	base := r.baseURL(req)
 	resp := GetTransactionsResponse{
+		Address:      addr,
+		Count:        r.Parser.GetCount(strings.ToLower(addr)),
 		Transactions: result,
 		Links: GetTransactionsLinks{
-			Addresses: fmt.Sprintf("http://%s/addresses", req.Host),
-			Subscribe: fmt.Sprintf("http://%s/addresses/%s/subscribe", req.Host, addr),
+			Addresses: base + "/addresses",
+			Subscribe: fmt.Sprintf("%s/addresses/%s/subscribe", base, addr),
 		},
 	}
-	json, err := json.Marshal(resp)
-	if err != nil {
-		panic(err)
+	writeJSONTraced(req.Context(), w, http.StatusOK, resp)
+}
+
+type GetPendingResponse struct {
+	Address      string                   `json:"address"`
+	Transactions []eth.PendingTransaction `json:"transactions"`
+}
+
+// GetPending returns the in-flight transactions of an address
+func (r RestServer) GetPending(w http.ResponseWriter, req *http.Request) {
+	addr, ok := pathAddress(w, req)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, GetPendingResponse{
+		Address:      addr,
+		Transactions: r.Parser.GetPending(addr),
+	})
+}
+
+// SubscribePending streams the pending transactions of an address
+// and their outcomes as Server-Sent Events
+func (r RestServer) SubscribePending(w http.ResponseWriter, req *http.Request) {
+	addr, ok := pathAddress(w, req)
+	if !ok {
+		return
 	}
+	sub := r.Parser.SubscribePending(addr, r.subscribeOptions())
+	r.logger().Info("new pending subscription", "address", addr, "subscription", sub.ID)
+	r.stream(w, req, sub, nil)
+}
 
-	w.Write([]byte(json))
+type GetTokenTransfersResponse struct {
+	Address   string              `json:"address"`
+	Transfers []eth.TokenTransfer `json:"transfers"`
+}
+
+// GetTokenTransfers returns the ERC-20, ERC-721 and ERC-1155
+// transfers from or to an address
+func (r RestServer) GetTokenTransfers(w http.ResponseWriter, req *http.Request) {
+	addr, ok := pathAddress(w, req)
+	if !ok {
+		return
+	}
+	transfers := r.Parser.GetTokenTransfers(req.Context(), strings.ToLower(addr))
+	if transfers == nil {
+		transfers = []eth.TokenTransfer{}
+	}
+	writeJSONTraced(req.Context(), w, http.StatusOK, GetTokenTransfersResponse{
+		Address:   addr,
+		Transfers: transfers,
+	})
+}
+
+// SubscribeTokenTransfers streams the token transfers of an
+// address as Server-Sent Events
+func (r RestServer) SubscribeTokenTransfers(w http.ResponseWriter, req *http.Request) {
+	addr, ok := pathAddress(w, req)
+	if !ok {
+		return
+	}
+	sub := r.Parser.SubscribeTokenTransfers(addr, r.subscribeOptions())
+	r.logger().Info("new token transfer subscription", "address", addr, "subscription", sub.ID)
+	r.stream(w, req, sub, nil)
+}
+
+type GetInternalTransfersResponse struct {
+	Address   string                 `json:"address"`
+	Transfers []eth.InternalTransfer `json:"transfers"`
+}
+
+// GetInternalTransfers returns the value moved from or to an
+// address by contract calls
+func (r RestServer) GetInternalTransfers(w http.ResponseWriter, req *http.Request) {
+	addr, ok := pathAddress(w, req)
+	if !ok {
+		return
+	}
+	transfers := r.Parser.GetInternalTransfers(req.Context(), strings.ToLower(addr))
+	if transfers == nil {
+		transfers = []eth.InternalTransfer{}
+	}
+	writeJSONTraced(req.Context(), w, http.StatusOK, GetInternalTransfersResponse{
+		Address:   addr,
+		Transfers: transfers,
+	})
+}
+
+// SubscribeInternalTransfers streams the internal transfers of an
+// address as Server-Sent Events
+func (r RestServer) SubscribeInternalTransfers(w http.ResponseWriter, req *http.Request) {
+	addr, ok := pathAddress(w, req)
+	if !ok {
+		return
+	}
+	sub := r.Parser.SubscribeInternalTransfers(addr, r.subscribeOptions())
+	r.logger().Info("new internal transfer subscription", "address", addr, "subscription", sub.ID)
+	r.stream(w, req, sub, nil)
 }
 
 type Address struct {
@@ -105,14 +531,23 @@ type GetAddressesResponse struct {
 }
 
 func (r RestServer) GetAddresses(w http.ResponseWriter, req *http.Request) {
-	keys := r.Parser.GetAddresses()
-	w.WriteHeader(http.StatusOK)
+	if r.syncing() {
+		writeError(w, http.StatusServiceUnavailable, CodeSyncing, ErrSyncing)
+		return
+	}
+	keys := r.Parser.GetAddresses(req.Context())
 
+	base := r.baseURL(req)
 	addresses := []Address{}
 	for _, k := range keys {
+		// Skip the empty recipient of contract creations
+		addr, err := eth.NormalizeAddress(k)
+		if err != nil {
+			continue
+		}
 		address := Address{
-			Address:         k,
-			TransactionsURL: fmt.Sprintf("http://%s/addresses/%s", req.Host, k),
+			Address:         addr,
+			TransactionsURL: fmt.Sprintf("%s/addresses/%s", base, addr),
 			Count:           r.Parser.GetCount(k),
 		}
 		addresses = append(addresses, address)
@@ -122,11 +557,5 @@ func (r RestServer) GetAddresses(w http.ResponseWriter, req *http.Request) {
 		return addresses[i].Count > addresses[j].Count
 	})
 
-	resp := GetAddressesResponse{addresses}
-	json, err := json.Marshal(&resp)
-	if err != nil {
-		panic(err)
-	}
-
-	w.Write([]byte(json))
+	writeJSONTraced(req.Context(), w, http.StatusOK, GetAddressesResponse{addresses})
 }
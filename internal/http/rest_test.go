@@ -0,0 +1,193 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/eth"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testAddrA = "0x00000000000000000000000000000000000000aa"
+	testAddrB = "0x00000000000000000000000000000000000000bb"
+)
+
+// newTestParser returns a parser that has processed the given
+// blocks, and the channel to feed it further blocks. Its block
+// reader serves the given blocks.
+func newTestParser(t *testing.T, blocks ...eth.BlockTxn) (eth.Parser, chan eth.BlockTxn) {
+	t.Helper()
+	ch := make(chan eth.BlockTxn)
+	t.Cleanup(func() { close(ch) })
+	p, err := eth.NewParser(ch, eth.ParserConfig{
+		Blocks: func(_ context.Context, n *big.Int) (eth.BlockTxn, error) {
+			for _, b := range blocks {
+				if b.BlockNum == n.String() {
+					return b, nil
+				}
+			}
+			return eth.BlockTxn{BlockNum: n.String()}, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range blocks {
+		ch <- b
+	}
+	waitForBlock(t, p, blocks[len(blocks)-1].BlockNum)
+	return p, ch
+}
+
+func waitForBlock(t *testing.T, p eth.Parser, block string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for p.GetCurrentBlock() != block {
+		if time.Now().After(deadline) {
+			t.Fatalf("parser did not reach block %s", block)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func testTxn(block, index int, from, to string) eth.Transaction {
+	return eth.Transaction{
+		Hash:             "0x" + strings.Repeat("0", 60) + string(rune('a'+block)) + string(rune('a'+index)),
+		From:             from,
+		To:               to,
+		Value:            "0x0",
+		Block:            "0x" + string(rune('0'+block)),
+		TransactionIndex: "0x" + string(rune('0'+index)),
+	}
+}
+
+// readEventIDs reads n SSE event IDs from the response
+func readEventIDs(t *testing.T, resp *http.Response, n int) []string {
+	t.Helper()
+	ids := []string{}
+	scanner := bufio.NewScanner(resp.Body)
+	for len(ids) < n && scanner.Scan() {
+		if id, found := strings.CutPrefix(scanner.Text(), "id: "); found {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestSubscribeResume(t *testing.T) {
+	testcases := []struct {
+		name   string
+		header string
+		query  string
+		want   []string
+	}{
+		{"last event id", "1-0", "", []string{"1-1", "2-0", "3-0"}},
+		{"since block", "", "?since=2", []string{"2-0", "3-0"}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, ch := newTestParser(t,
+				eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{testTxn(1, 0, testAddrA, testAddrB), testTxn(1, 1, testAddrB, testAddrA)}},
+				eth.BlockTxn{BlockNum: "2", Txns: []eth.Transaction{testTxn(2, 0, testAddrA, testAddrB)}},
+			)
+			rest := RestServer{Parser: p}
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /addresses/{address}/subscribe", rest.Subscribe)
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/addresses/"+testAddrA+"/subscribe"+tc.query, nil)
+			if tc.header != "" {
+				req.Header.Set("Last-Event-ID", tc.header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			// Live block published after the replay
+			ch <- eth.BlockTxn{BlockNum: "3", Txns: []eth.Transaction{testTxn(3, 0, testAddrB, testAddrA)}}
+
+			got := readEventIDs(t, resp, len(tc.want))
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("expected %v; got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSubscribeFilterResume(t *testing.T) {
+	p, _ := newTestParser(t,
+		eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{testTxn(1, 0, testAddrA, testAddrB), testTxn(1, 1, testAddrB, testAddrA)}},
+		eth.BlockTxn{BlockNum: "2", Txns: []eth.Transaction{testTxn(2, 0, testAddrA, testAddrB)}},
+		eth.BlockTxn{BlockNum: "3", Txns: []eth.Transaction{testTxn(3, 0, testAddrB, testAddrA)}},
+	)
+	testcases := []struct {
+		name   string
+		rest   RestServer
+		since  string
+		status int
+		want   []string
+	}{
+		{"all addresses", RestServer{Parser: p}, "1", http.StatusOK, []string{"1-0", "1-1", "2-0", "3-0"}},
+		{"within window", RestServer{Parser: p, ReplayWindow: 1}, "2", http.StatusOK, []string{"2-0", "3-0"}},
+		{"expired cursor", RestServer{Parser: p, ReplayWindow: 1}, "1", http.StatusGone, nil},
+		{"too many", RestServer{Parser: p, MaxReplay: 2}, "1", http.StatusGone, nil},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /subscribe", tc.rest.SubscribeFilter)
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/subscribe?since="+tc.since, nil)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.status {
+				t.Fatalf("expected %d; got %d", tc.status, resp.StatusCode)
+			}
+			if tc.want == nil {
+				return
+			}
+			got := readEventIDs(t, resp, len(tc.want))
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("expected %v; got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	testcases := []struct {
+		name       string
+		trustProxy bool
+		expected   string
+	}{
+		{"untrusted proxy", false, "http://example.com/v1"},
+		{"trusted proxy", true, "https://proxy.example.com/v1"},
+	}
+	for _, tc := range testcases {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/v1/addresses", nil)
+		req.Header.Set("X-Forwarded-Proto", "https")
+		req.Header.Set("X-Forwarded-Host", "proxy.example.com")
+		r := RestServer{BasePath: V1Prefix, TrustProxy: tc.trustProxy}
+		if got := r.baseURL(req); got != tc.expected {
+			t.Errorf("%s: expected %s; got %s", tc.name, tc.expected, got)
+		}
+	}
+}
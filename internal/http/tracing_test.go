@@ -0,0 +1,47 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/tracing"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestLookupTrace(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(tp)
+	defer tp.Shutdown(context.Background())
+
+	p, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{testTxn(1, 0, testAddrA, testAddrB)}})
+	exporter.Reset()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /addresses/{address}", RestServer{Parser: p}.GetTransactions)
+	rec := httptest.NewRecorder()
+	tracing.Handler(mux).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/addresses/"+testAddrA, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200; got %d", rec.Code)
+	}
+
+	// Every span of the lookup belongs to the request trace
+	spans := exporter.GetSpans()
+	names := map[string]bool{}
+	for _, s := range spans {
+		names[s.Name] = true
+		if s.SpanContext.TraceID() != spans[len(spans)-1].SpanContext.TraceID() {
+			t.Errorf("span %s is not part of the request trace", s.Name)
+		}
+	}
+	for _, name := range []string{"GET /addresses/{address}", "eth.GetTransactions", "store.Get", "http.writeJSON"} {
+		if !names[name] {
+			t.Errorf("expected span %s; got %v", name, names)
+		}
+	}
+}
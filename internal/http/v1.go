@@ -0,0 +1,69 @@
+package http
+
+import (
+	"net/http"
+	"paulwizviz/go-eth-app/api"
+)
+
+// V1Prefix is the path prefix of version 1 of the API
+const V1Prefix = "/v1"
+
+// RegisterV1 registers the version 1 routes described by
+// api/openapi.yaml on mux
+func (r RestServer) RegisterV1(mux *http.ServeMux) {
+	v1 := r
+	v1.BasePath = V1Prefix
+
+	r.RegisterParser(mux, V1Prefix)
+	r.RegisterWebhooks(mux, V1Prefix)
+	mux.HandleFunc("GET "+V1Prefix+"/openapi.yaml", GetOpenAPI)
+	if r.Auth != nil && r.Auth.Keys != nil {
+		mux.Handle("POST "+V1Prefix+"/admin/keys", v1.ProtectAdmin(v1.IssueKey))
+		mux.Handle("GET "+V1Prefix+"/admin/keys", v1.ProtectAdmin(v1.GetKeys))
+		mux.Handle("DELETE "+V1Prefix+"/admin/keys/{id}", v1.ProtectAdmin(v1.RevokeKey))
+	}
+}
+
+// RegisterParser registers the routes served from the parser
+// under prefix, "" for the unversioned routes, with the watchlist
+// routes when the parser indexes a watchlist
+func (r RestServer) RegisterParser(mux *http.ServeMux, prefix string) {
+	r.BasePath = prefix
+	mux.Handle("GET "+prefix+"/{$}", r.Protect(r.GetCurrentBlock))
+	mux.Handle("GET "+prefix+"/addresses", r.Protect(r.GetAddresses))
+	mux.Handle("GET "+prefix+"/addresses/top", r.Protect(r.GetTopAddresses))
+	mux.Handle("GET "+prefix+"/addresses/{address}", r.Protect(r.GetTransactions))
+	mux.Handle("GET "+prefix+"/addresses/{address}/subscribe", r.ProtectStream(r.Subscribe))
+	mux.Handle("GET "+prefix+"/addresses/{address}/pending", r.Protect(r.GetPending))
+	mux.Handle("GET "+prefix+"/addresses/{address}/pending/subscribe", r.ProtectStream(r.SubscribePending))
+	mux.Handle("GET "+prefix+"/addresses/{address}/tokens", r.Protect(r.GetTokenTransfers))
+	mux.Handle("GET "+prefix+"/addresses/{address}/tokens/subscribe", r.ProtectStream(r.SubscribeTokenTransfers))
+	mux.Handle("GET "+prefix+"/addresses/{address}/internal", r.Protect(r.GetInternalTransfers))
+	mux.Handle("GET "+prefix+"/addresses/{address}/internal/subscribe", r.ProtectStream(r.SubscribeInternalTransfers))
+	mux.Handle("GET "+prefix+"/addresses/{address}/stats", r.Protect(r.GetAddressStats))
+	mux.Handle("GET "+prefix+"/subscribe", r.ProtectStream(r.SubscribeFilter))
+	mux.Handle("GET "+prefix+"/ws", r.Protect(r.WebSocket))
+	mux.Handle("GET "+prefix+"/indexing", r.Protect(r.GetIndexing))
+	r.registerWatchlist(mux, prefix)
+}
+
+// RegisterWebhooks registers the webhook routes under prefix, ""
+// for the unversioned routes, when r.Webhooks is set
+func (r RestServer) RegisterWebhooks(mux *http.ServeMux, prefix string) {
+	if r.Webhooks == nil {
+		return
+	}
+	r.BasePath = prefix
+	mux.Handle("POST "+prefix+"/webhooks", r.Protect(r.RegisterWebhook))
+	mux.Handle("GET "+prefix+"/webhooks", r.Protect(r.GetWebhooks))
+	mux.Handle("GET "+prefix+"/webhooks/deadletters", r.Protect(r.GetDeadLetters))
+	mux.Handle("DELETE "+prefix+"/webhooks/{id}", r.Protect(r.DeleteWebhook))
+	mux.Handle("GET "+prefix+"/webhooks/{id}/deliveries", r.Protect(r.GetWebhookDeliveries))
+}
+
+// GetOpenAPI serves the OpenAPI document
+func GetOpenAPI(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(api.OpenAPI)
+}
@@ -0,0 +1,297 @@
+package http
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/pkg/client"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestV1Syncing(t *testing.T) {
+	ch := make(chan eth.BlockTxn)
+	defer close(ch)
+	p, _ := eth.NewParser(ch, eth.ParserConfig{})
+	mux := http.NewServeMux()
+	RestServer{Parser: p}.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, _ := client.NewClientWithResponses(srv.URL + V1Prefix)
+	resp, err := c.ListAddressesWithResponse(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode() != http.StatusServiceUnavailable || resp.JSON503 == nil || string(resp.JSON503.Error.Code) != CodeSyncing {
+		t.Errorf("expected 503 syncing; got %d %s", resp.StatusCode(), resp.Body)
+	}
+}
+
+func TestV1Addresses(t *testing.T) {
+	checksumA := common.HexToAddress(testAddrA).Hex()
+	p, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{
+		testTxn(1, 0, testAddrA, testAddrB),
+		{Hash: "0xc", From: testAddrA, Block: "0x1", TransactionIndex: "0x1"}, // contract creation
+	}})
+	mux := http.NewServeMux()
+	RestServer{Parser: p, TrustProxy: true}.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, _ := client.NewClientWithResponses(srv.URL+V1Prefix, client.WithRequestEditorFn(
+		func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("X-Forwarded-Proto", "https")
+			return nil
+		}))
+	ctx := context.Background()
+
+	testcases := []struct {
+		name    string
+		address string
+		status  int
+		code    string
+	}{
+		{"lowercase", testAddrA, http.StatusOK, ""},
+		{"checksum", checksumA, http.StatusOK, ""},
+		{"bad checksum", "0x00000000000000000000000000000000000000aA", http.StatusBadRequest, CodeInvalidAddress},
+		{"malformed", "0x1234", http.StatusBadRequest, CodeInvalidAddress},
+		{"unknown", "0x00000000000000000000000000000000000000cc", http.StatusNotFound, CodeNotFound},
+	}
+	for _, tc := range testcases {
+		resp, err := c.GetAddressWithResponse(ctx, tc.address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode() != tc.status {
+			t.Errorf("%s: expected %d; got %d", tc.name, tc.status, resp.StatusCode())
+			continue
+		}
+		if ct := resp.HTTPResponse.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: expected application/json; got %s", tc.name, ct)
+		}
+		switch tc.status {
+		case http.StatusOK:
+			if resp.JSON200.Address != checksumA || len(resp.JSON200.Transactions) != 2 {
+				t.Errorf("%s: unexpected body %s", tc.name, resp.Body)
+			}
+			if !strings.HasPrefix(resp.JSON200.Links.Subscribe, "https://") || !strings.Contains(resp.JSON200.Links.Subscribe, V1Prefix+"/addresses/"+checksumA) {
+				t.Errorf("%s: unexpected link %s", tc.name, resp.JSON200.Links.Subscribe)
+			}
+		case http.StatusBadRequest:
+			if string(resp.JSON400.Error.Code) != tc.code {
+				t.Errorf("%s: expected code %s; got %s", tc.name, tc.code, resp.JSON400.Error.Code)
+			}
+		case http.StatusNotFound:
+			if string(resp.JSON404.Error.Code) != tc.code {
+				t.Errorf("%s: expected code %s; got %s", tc.name, tc.code, resp.JSON404.Error.Code)
+			}
+		}
+	}
+
+	list, err := c.ListAddressesWithResponse(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The empty recipient of the contract creation is not listed
+	if list.JSON200 == nil || len(list.JSON200.Addresses) != 2 || list.JSON200.Addresses[0].Address != checksumA {
+		t.Errorf("unexpected addresses %s", list.Body)
+	}
+}
+
+func TestV1Pending(t *testing.T) {
+	blocks := make(chan eth.BlockTxn)
+	defer close(blocks)
+	pending := make(chan eth.Transaction)
+	defer close(pending)
+	p, _ := eth.NewParser(blocks, eth.ParserConfig{Pending: pending})
+	blocks <- eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{testTxn(1, 0, testAddrA, testAddrB)}}
+	waitForBlock(t, p, "1")
+	pending <- eth.Transaction{Hash: "0xp", From: testAddrA, To: testAddrB, Nonce: "0x1"}
+
+	mux := http.NewServeMux()
+	RestServer{Parser: p}.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	c, _ := client.NewClientWithResponses(srv.URL + V1Prefix)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		resp, err := c.GetPendingWithResponse(context.Background(), testAddrB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.JSON200 == nil {
+			t.Fatalf("expected 200; got %d %s", resp.StatusCode(), resp.Body)
+		}
+		if txns := resp.JSON200.Transactions; len(txns) == 1 {
+			if txns[0].Status != client.Pending {
+				t.Errorf("expected a pending transaction; got %+v", txns[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the pending transaction; got %s", resp.Body)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestV1Receipt(t *testing.T) {
+	txn := testTxn(1, 0, testAddrA, testAddrB)
+	txn.Receipt = &eth.Receipt{Status: eth.ReceiptStatusSuccess, GasUsed: "0x5208", EffectiveGasPrice: "0x1", Fee: "0x5208", Logs: []eth.Log{}}
+	p, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{txn}})
+	mux := http.NewServeMux()
+	RestServer{Parser: p}.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, _ := client.NewClientWithResponses(srv.URL + V1Prefix)
+	resp, err := c.GetAddressWithResponse(context.Background(), testAddrA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.JSON200 == nil || resp.JSON200.Transactions[0].Receipt == nil {
+		t.Fatalf("expected a transaction with its receipt; got %d %s", resp.StatusCode(), resp.Body)
+	}
+	if r := resp.JSON200.Transactions[0].Receipt; r.Status != eth.ReceiptStatusSuccess || r.Fee != "0x5208" {
+		t.Errorf("unexpected receipt %+v", r)
+	}
+}
+
+func TestV1TokenTransfers(t *testing.T) {
+	txn := testTxn(1, 0, testAddrA, testAddrB)
+	txn.Receipt = &eth.Receipt{Status: eth.ReceiptStatusSuccess, Logs: []eth.Log{{
+		Address: testAddrB,
+		Topics: []string{
+			eth.TopicTransfer,
+			"0x000000000000000000000000" + testAddrA[2:],
+			"0x000000000000000000000000" + "00000000000000000000000000000000000000cc",
+		},
+		Data:     "0x0000000000000000000000000000000000000000000000000000000000000064",
+		LogIndex: "0x0",
+	}}}
+	p, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{txn}})
+	mux := http.NewServeMux()
+	RestServer{Parser: p}.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, _ := client.NewClientWithResponses(srv.URL + V1Prefix)
+	resp, err := c.GetTokenTransfersWithResponse(context.Background(), "0x00000000000000000000000000000000000000cc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.JSON200 == nil || len(resp.JSON200.Transfers) != 1 {
+		t.Fatalf("expected a token transfer; got %d %s", resp.StatusCode(), resp.Body)
+	}
+	if tt := resp.JSON200.Transfers[0]; tt.Amount != "0x64" || tt.Standard != client.Erc20 {
+		t.Errorf("unexpected transfer %+v", tt)
+	}
+}
+
+func TestV1Indexing(t *testing.T) {
+	block := eth.BlockTxn{BlockNum: "2", Txns: []eth.Transaction{testTxn(2, 0, testAddrA, testAddrB)}}
+	ch := make(chan eth.BlockTxn)
+	defer close(ch)
+	p, _ := eth.NewParser(ch, eth.ParserConfig{
+		Blocks: func(ctx context.Context, number *big.Int) (eth.BlockTxn, error) {
+			return eth.BlockTxn{BlockNum: number.String()}, nil
+		},
+	})
+	ch <- block
+	waitForBlock(t, p, "2")
+
+	mux := http.NewServeMux()
+	RestServer{Parser: p}.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	c, _ := client.NewClientWithResponses(srv.URL + V1Prefix)
+
+	if resp, _ := c.GetIndexingWithResponse(context.Background()); resp.StatusCode() != http.StatusNotFound {
+		t.Errorf("expected 404 before any job; got %d", resp.StatusCode())
+	}
+	if err := p.(eth.Indexer).IndexRange(context.Background(), eth.RangeConfig{From: 0}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.GetIndexingWithResponse(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.JSON200 == nil || resp.JSON200.State != client.IndexingProgressStateDone || resp.JSON200.Percent != 100 || resp.JSON200.Skipped != 1 {
+		t.Errorf("unexpected progress %d %s", resp.StatusCode(), resp.Body)
+	}
+}
+
+func TestV1AddressStats(t *testing.T) {
+	txn := testTxn(1, 0, testAddrA, testAddrB)
+	txn.Value = "0x64"
+	txn.Receipt = &eth.Receipt{Status: eth.ReceiptStatusSuccess, Fee: "0x5"}
+	p, _ := newTestParser(t, eth.BlockTxn{
+		BlockNum: "1",
+		Time:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Txns:     []eth.Transaction{txn},
+	})
+	mux := http.NewServeMux()
+	RestServer{Parser: p}.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	c, _ := client.NewClientWithResponses(srv.URL + V1Prefix)
+
+	interval := client.GetAddressStatsParamsIntervalHour
+	resp, err := c.GetAddressStatsWithResponse(context.Background(), testAddrA, &client.GetAddressStatsParams{Interval: &interval})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := resp.JSON200
+	if stats == nil || stats.Out != "0x64" || stats.Net != "-0x64" || stats.Fees != "0x5" || stats.FirstBlock != 1 {
+		t.Fatalf("unexpected stats %d %s", resp.StatusCode(), resp.Body)
+	}
+	if len(stats.Counterparties) != 1 || stats.Counterparties[0].Address != testAddrB {
+		t.Errorf("unexpected counterparties %+v", stats.Counterparties)
+	}
+	if len(stats.Activity) != 1 || !stats.Activity[0].Start.Equal(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected activity %+v", stats.Activity)
+	}
+
+	top := 0
+	if resp, _ := c.GetAddressStatsWithResponse(context.Background(), testAddrA, &client.GetAddressStatsParams{Top: &top}); resp.StatusCode() != http.StatusBadRequest {
+		t.Errorf("expected 400 for top 0; got %d", resp.StatusCode())
+	}
+	if resp, _ := c.GetAddressStatsWithResponse(context.Background(), "0x00000000000000000000000000000000000000cc", nil); resp.StatusCode() != http.StatusNotFound {
+		t.Errorf("expected 404 for an unseen address; got %d", resp.StatusCode())
+	}
+}
+
+func TestV1TopAddresses(t *testing.T) {
+	p, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{
+		testTxn(1, 0, testAddrA, testAddrB),
+		testTxn(1, 1, testAddrA, testAddrA),
+	}})
+	mux := http.NewServeMux()
+	RestServer{Parser: p}.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	c, _ := client.NewClientWithResponses(srv.URL + V1Prefix)
+
+	limit := 1
+	resp, err := c.GetTopAddressesWithResponse(context.Background(), &client.GetTopAddressesParams{Limit: &limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.JSON200 == nil || len(resp.JSON200.Addresses) != 1 {
+		t.Fatalf("expected an address; got %d %s", resp.StatusCode(), resp.Body)
+	}
+	if top := resp.JSON200.Addresses[0]; top.Address != testAddrA || top.Transactions != 2 {
+		t.Errorf("expected %s with 2 transactions; got %+v", testAddrA, top)
+	}
+	limit = 101
+	if resp, _ := c.GetTopAddressesWithResponse(context.Background(), &client.GetTopAddressesParams{Limit: &limit}); resp.StatusCode() != http.StatusBadRequest {
+		t.Errorf("expected 400 for limit 101; got %d", resp.StatusCode())
+	}
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/eth"
+	"strconv"
+	"strings"
+)
+
+// maxWatchlistSize bounds the body of a watchlist request
+const maxWatchlistSize = 8 << 20
+
+// BackfillRange is the inclusive block range indexed for the
+// addresses added to a watchlist
+type BackfillRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+type GetWatchlistResponse struct {
+	Addresses []string `json:"addresses"`
+	Count     int      `json:"count"`
+}
+
+type AddWatchlistRequest struct {
+	Addresses []string       `json:"addresses"`
+	Backfill  *BackfillRange `json:"backfill,omitempty"`
+}
+
+type AddWatchlistResponse struct {
+	// Added are the addresses not already watched
+	Added    []string       `json:"added"`
+	Backfill *BackfillRange `json:"backfill,omitempty"`
+}
+
+// watcher returns the parser as a Watcher if it indexes a
+// watchlist
+func (r RestServer) watcher() (eth.Watcher, bool) {
+	w, ok := r.Parser.(eth.Watcher)
+	if !ok || w.Watchlist() == nil {
+		return nil, false
+	}
+	return w, true
+}
+
+// registerWatchlist registers the watchlist routes under prefix
+// when the parser indexes a watchlist
+func (r RestServer) registerWatchlist(mux *http.ServeMux, prefix string) {
+	if _, ok := r.watcher(); !ok {
+		return
+	}
+	mux.Handle("GET "+prefix+"/watchlist", r.Protect(r.GetWatchlist))
+	mux.Handle("POST "+prefix+"/watchlist", r.Protect(r.AddWatchlist))
+	mux.Handle("POST "+prefix+"/watchlist/import", r.Protect(r.ImportWatchlist))
+	mux.Handle("DELETE "+prefix+"/watchlist/{address}", r.Protect(r.RemoveWatchlist))
+}
+
+// GetWatchlist returns the watched addresses
+func (r RestServer) GetWatchlist(w http.ResponseWriter, req *http.Request) {
+	watcher, _ := r.watcher()
+	addrs := watcher.Watchlist().List()
+	writeJSON(w, http.StatusOK, GetWatchlistResponse{Addresses: addrs, Count: len(addrs)})
+}
+
+// AddWatchlist watches the addresses of a JSON body, backfilling
+// them over a block range if one is given
+func (r RestServer) AddWatchlist(w http.ResponseWriter, req *http.Request) {
+	var body AddWatchlistRequest
+	req.Body = http.MaxBytesReader(w, req.Body, maxWatchlistSize)
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err)
+		return
+	}
+	r.addWatchlist(w, req, body.Addresses, body.Backfill)
+}
+
+// ImportWatchlist watches the addresses of a text body, separated
+// by new lines, commas or spaces. The from and to query
+// parameters backfill them.
+func (r RestServer) ImportWatchlist(w http.ResponseWriter, req *http.Request) {
+	b, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxWatchlistSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err)
+		return
+	}
+	addrs := strings.FieldsFunc(string(b), func(c rune) bool {
+		return c == ',' || c == ';' || c == ' ' || c == '\t' || c == '\r' || c == '\n'
+	})
+	backfill, err := queryBackfill(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err)
+		return
+	}
+	r.addWatchlist(w, req, addrs, backfill)
+}
+
+func (r RestServer) addWatchlist(w http.ResponseWriter, req *http.Request, addrs []string, backfill *BackfillRange) {
+	if len(addrs) == 0 {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, errors.New("no addresses"))
+		return
+	}
+	if backfill != nil && (backfill.From < 0 || backfill.To < backfill.From) {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Errorf("%w-%d to %d", eth.ErrBackfillRange, backfill.From, backfill.To))
+		return
+	}
+	for i, a := range addrs {
+		addr, err := eth.NormalizeAddress(a)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, CodeInvalidAddress, err)
+			return
+		}
+		addrs[i] = strings.ToLower(addr)
+	}
+
+	watcher, _ := r.watcher()
+	added, err := watcher.Watchlist().Add(addrs...)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, CodeInternalError, err)
+		return
+	}
+	r.logger().Info("watchlist addresses added", "added", len(added), "requested", len(addrs))
+
+	if backfill == nil || len(added) == 0 {
+		writeJSON(w, http.StatusOK, AddWatchlistResponse{Added: added})
+		return
+	}
+	// The backfill outlives the request but not the server
+	if err := watcher.Backfill(r.context(), added, backfill.From, backfill.To); err != nil {
+		// The addresses are only watched along with their backfill
+		for _, a := range added {
+			if _, rerr := watcher.Watchlist().Remove(a); rerr != nil {
+				r.logger().Error("remove watchlist address", "address", a, "err", rerr)
+			}
+		}
+		switch {
+		case errors.Is(err, eth.ErrBackfillRange):
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, err)
+		case errors.Is(err, eth.ErrBackfillRunning):
+			writeError(w, http.StatusConflict, CodeBackfillRunning, err)
+		default:
+			writeError(w, http.StatusInternalServerError, CodeInternalError, err)
+		}
+		return
+	}
+	writeJSON(w, http.StatusAccepted, AddWatchlistResponse{Added: added, Backfill: backfill})
+}
+
+// RemoveWatchlist stops watching an address
+func (r RestServer) RemoveWatchlist(w http.ResponseWriter, req *http.Request) {
+	addr, ok := pathAddress(w, req)
+	if !ok {
+		return
+	}
+	watcher, _ := r.watcher()
+	removed, err := watcher.Watchlist().Remove(addr)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, CodeInternalError, err)
+		return
+	}
+	if !removed {
+		writeError(w, http.StatusNotFound, CodeNotFound, fmt.Errorf("%s is not watched", addr))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// queryBackfill returns the range of the from and to query
+// parameters, nil if neither is set
+func queryBackfill(req *http.Request) (*BackfillRange, error) {
+	q := req.URL.Query()
+	if !q.Has("from") && !q.Has("to") {
+		return nil, nil
+	}
+	from, err := strconv.ParseInt(q.Get("from"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w-from: %v", eth.ErrBackfillRange, err)
+	}
+	to, err := strconv.ParseInt(q.Get("to"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w-to: %v", eth.ErrBackfillRange, err)
+	}
+	return &BackfillRange{From: from, To: to}, nil
+}
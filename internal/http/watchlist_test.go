@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/pkg/client"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestV1Watchlist(t *testing.T) {
+	const testAddrC = "0x00000000000000000000000000000000000000cc"
+	block := eth.BlockTxn{BlockNum: "1", Txns: []eth.Transaction{testTxn(1, 0, testAddrA, testAddrB), testTxn(1, 1, testAddrB, testAddrC)}}
+	watchlist, _ := eth.NewWatchlist("")
+	watchlist.Add(testAddrA)
+	ch := make(chan eth.BlockTxn)
+	defer close(ch)
+	p, _ := eth.NewParser(ch, eth.ParserConfig{
+		Watchlist: watchlist,
+		Blocks: func(ctx context.Context, number *big.Int) (eth.BlockTxn, error) {
+			return block, nil
+		},
+	})
+	ch <- block
+	waitForBlock(t, p, "1")
+
+	mux := http.NewServeMux()
+	RestServer{Parser: p}.RegisterV1(mux)
+	RestServer{Parser: p}.RegisterParser(mux, "")
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	c, _ := client.NewClientWithResponses(srv.URL + V1Prefix)
+	ctx := context.Background()
+
+	// The unversioned routes include the watchlist
+	if resp, err := http.Get(srv.URL + "/watchlist"); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the unversioned watchlist; got %v %v", resp, err)
+	} else {
+		resp.Body.Close()
+	}
+
+	if resp, _ := c.GetAddressWithResponse(ctx, testAddrB); resp.StatusCode() != http.StatusNotFound {
+		t.Errorf("expected no transactions of an unwatched address; got %d %s", resp.StatusCode(), resp.Body)
+	}
+
+	add, err := c.AddWatchlistWithResponse(ctx, client.AddWatchlistRequest{Addresses: []string{testAddrA, "0x00000000000000000000000000000000000000BB"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if add.JSON200 == nil || !slices.Equal(add.JSON200.Added, []string{testAddrB}) {
+		t.Errorf("expected bob added; got %d %s", add.StatusCode(), add.Body)
+	}
+	if resp, _ := c.AddWatchlistWithResponse(ctx, client.AddWatchlistRequest{Addresses: []string{"0xbad"}}); resp.StatusCode() != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid address; got %d", resp.StatusCode())
+	}
+	// An address is not watched when its backfill is refused
+	tooLong := client.BackfillRange{From: 0, To: eth.DefaultMaxBackfill}
+	if resp, _ := c.AddWatchlistWithResponse(ctx, client.AddWatchlistRequest{Addresses: []string{testAddrC}, Backfill: &tooLong}); resp.StatusCode() != http.StatusBadRequest {
+		t.Errorf("expected 400 for a backfill over the cap; got %d %s", resp.StatusCode(), resp.Body)
+	}
+	if watchlist.Contains(testAddrC) {
+		t.Error("expected carol not watched after a refused backfill")
+	}
+
+	// An import backfills the added addresses in the background
+	from, to := int64(1), int64(1)
+	imp, err := c.ImportWatchlistWithTextBodyWithResponse(ctx, &client.ImportWatchlistParams{From: &from, To: &to}, testAddrB+",\n"+testAddrC+"\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imp.JSON202 == nil || !slices.Equal(imp.JSON202.Added, []string{testAddrC}) {
+		t.Fatalf("expected carol added and backfilled; got %d %s", imp.StatusCode(), imp.Body)
+	}
+	deadline := time.Now().Add(time.Second)
+	for p.GetCount(testAddrC) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := p.GetTransactions(ctx, testAddrC); len(got) != 1 {
+		t.Errorf("expected the backfilled transaction of carol; got %+v", got)
+	}
+
+	list, _ := c.GetWatchlistWithResponse(ctx)
+	if list.JSON200 == nil || list.JSON200.Count != 3 {
+		t.Errorf("expected 3 watched addresses; got %s", list.Body)
+	}
+	if resp, _ := c.RemoveWatchlistWithResponse(ctx, testAddrA); resp.StatusCode() != http.StatusNoContent {
+		t.Errorf("expected 204; got %d", resp.StatusCode())
+	}
+	if resp, _ := c.RemoveWatchlistWithResponse(ctx, testAddrA); resp.StatusCode() != http.StatusNotFound {
+		t.Errorf("expected 404 for an unwatched address; got %d", resp.StatusCode())
+	}
+}
+
+func TestWatchlistNotServed(t *testing.T) {
+	p, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1"})
+	mux := http.NewServeMux()
+	RestServer{Parser: p}.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + V1Prefix + "/watchlist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected no watchlist routes without a watchlist; got %d", resp.StatusCode)
+	}
+}
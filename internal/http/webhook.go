@@ -0,0 +1,116 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/auth"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/webhook"
+)
+
+type RegisterWebhookRequest struct {
+	URL         string         `json:"url"`
+	Secret      string         `json:"secret,omitempty"`
+	Address     string         `json:"address,omitempty"`
+	Filter      eth.FilterSpec `json:"filter"`
+	Concurrency int            `json:"concurrency,omitempty"`
+}
+
+// RegisterWebhookResponse returns the signing secret. It is
+// not retrievable afterwards.
+type RegisterWebhookResponse struct {
+	webhook.Endpoint
+	Secret string `json:"secret"`
+}
+
+type GetWebhooksResponse struct {
+	Webhooks []webhook.Endpoint `json:"webhooks"`
+}
+
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []webhook.Delivery `json:"deliveries"`
+}
+
+type GetDeadLettersResponse struct {
+	DeadLetters []webhook.DeadLetter `json:"deadLetters"`
+}
+
+func (r RestServer) RegisterWebhook(w http.ResponseWriter, req *http.Request) {
+	var body RegisterWebhookRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err)
+		return
+	}
+	spec := body.Filter
+	if body.Address != "" {
+		spec.Addresses = append(spec.Addresses, body.Address)
+	}
+	filter, err := spec.Parse()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, filterErrorCode(err), err)
+		return
+	}
+
+	var owner string
+	if p, ok := auth.FromContext(req.Context()); ok {
+		owner = p.ID
+	}
+	ep, err := r.Webhooks.Register(webhook.Endpoint{
+		Owner:       owner,
+		URL:         body.URL,
+		Secret:      body.Secret,
+		Filter:      filter,
+		Concurrency: body.Concurrency,
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err)
+		return
+	}
+	r.logger().Info("new webhook", "webhook", ep.ID, "url", ep.URL)
+
+	writeJSON(w, http.StatusCreated, RegisterWebhookResponse{Endpoint: ep, Secret: ep.Secret})
+}
+
+func (r RestServer) GetWebhooks(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusOK, GetWebhooksResponse{Webhooks: r.Webhooks.Endpoints(webhookOwner(req))})
+}
+
+func (r RestServer) DeleteWebhook(w http.ResponseWriter, req *http.Request) {
+	if err := r.Webhooks.Remove(webhookOwner(req), req.PathValue("id")); err != nil {
+		webhookError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r RestServer) GetWebhookDeliveries(w http.ResponseWriter, req *http.Request) {
+	deliveries, err := r.Webhooks.Deliveries(webhookOwner(req), req.PathValue("id"))
+	if err != nil {
+		webhookError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, GetWebhookDeliveriesResponse{Deliveries: deliveries})
+}
+
+func (r RestServer) GetDeadLetters(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusOK, GetDeadLettersResponse{DeadLetters: r.Webhooks.DeadLetters(webhookOwner(req))})
+}
+
+// webhookOwner returns the client the webhooks of a request are
+// scoped to, none for an admin or without authentication
+func webhookOwner(req *http.Request) string {
+	p, ok := auth.FromContext(req.Context())
+	if !ok || p.IsAdmin() {
+		return ""
+	}
+	return p.ID
+}
+
+func webhookError(w http.ResponseWriter, err error) {
+	if errors.Is(err, webhook.ErrEndpointNotFound) {
+		writeError(w, http.StatusNotFound, CodeNotFound, err)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, CodeInternalError, err)
+}
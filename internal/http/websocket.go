@@ -0,0 +1,287 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"paulwizviz/go-eth-app/internal/auth"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/observer"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket control protocol operations sent by the client
+const (
+	WSOpSubscribe   = "subscribe"
+	WSOpUnsubscribe = "unsubscribe"
+	WSOpPing        = "ping"
+)
+
+// WebSocket frame types sent by the server
+const (
+	WSTypeAck       = "ack"
+	WSTypeEvent     = "event"
+	WSTypeDropped   = "dropped"
+	WSTypeHeartbeat = "heartbeat"
+	WSTypeError     = "error"
+)
+
+var (
+	// WSHeartbeatInterval is the period between heartbeat frames
+	WSHeartbeatInterval = 30 * time.Second
+
+	// WSMaxSubscriptions bounds the subscriptions of a connection
+	WSMaxSubscriptions = 100
+
+	wsWriteTimeout = 10 * time.Second
+	wsOutBuffer    = 256
+	// wsReadLimit bounds a message from the client
+	wsReadLimit int64 = 64 << 10
+)
+
+// WSRequest is a control message from the client. ID is chosen
+// by the client and echoed in the matching ack or error frame.
+type WSRequest struct {
+	Op           string          `json:"op"`
+	ID           string          `json:"id,omitempty"`
+	Address      string          `json:"address,omitempty"`
+	Filter       *eth.FilterSpec `json:"filter,omitempty"`
+	Subscription string          `json:"subscription,omitempty"`
+}
+
+// WSFrame is a message from the server
+type WSFrame struct {
+	Type         string          `json:"type"`
+	ID           string          `json:"id,omitempty"`
+	Subscription string          `json:"subscription,omitempty"`
+	EventID      string          `json:"eventId,omitempty"`
+	Data         json.RawMessage `json:"data,omitempty"`
+	Dropped      uint64          `json:"dropped,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	Time         *time.Time      `json:"time,omitempty"`
+}
+
+var upgrader = websocket.Upgrader{
+	// Origins are checked by the CORS middleware
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// WebSocket serves a bidirectional subscription API. A single
+// connection can hold up to WSMaxSubscriptions address or filter
+// subscriptions, managed with WSRequest control messages. With
+// r.Auth set each subscription counts against the client's cap.
+func (r RestServer) WebSocket(w http.ResponseWriter, req *http.Request) {
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		r.logger().Warn("websocket upgrade", "err", err)
+		return
+	}
+	conn.SetReadLimit(wsReadLimit)
+
+	ctx, cancel := context.WithCancel(req.Context())
+	s := &wsSession{
+		parser: r.Parser,
+		opts:   r.subscribeOptions(),
+		logger: r.logger(),
+		conn:   conn,
+		out:    make(chan WSFrame, wsOutBuffer),
+		ctx:    ctx,
+		subs:   map[string]*observer.Subscription{},
+	}
+	if p, ok := auth.FromContext(req.Context()); ok && r.Auth != nil {
+		s.quotas, s.principal = r.Auth.Quotas, p
+	}
+
+	go s.writeLoop(cancel)
+	s.readLoop()
+
+	cancel()
+	s.closeAll()
+	conn.Close()
+}
+
+type wsSession struct {
+	parser eth.Parser
+	opts   observer.Options
+	logger *slog.Logger
+	conn   *websocket.Conn
+	out    chan WSFrame
+	ctx    context.Context
+	// quotas, if set, count the subscriptions of principal
+	quotas    *auth.Quotas
+	principal auth.Principal
+
+	mu   sync.Mutex
+	subs map[string]*observer.Subscription
+}
+
+// acquire takes a subscription slot of the connection and of the
+// client, returning the error to send if none is left
+func (s *wsSession) acquire() error {
+	s.mu.Lock()
+	n := len(s.subs)
+	s.mu.Unlock()
+	if n >= WSMaxSubscriptions {
+		return fmt.Errorf("%w-%d on the connection", ErrTooManySubscriptions, n)
+	}
+	if s.quotas != nil && !s.quotas.Acquire(s.principal) {
+		return ErrTooManySubscriptions
+	}
+	return nil
+}
+
+// release frees the slot of a subscription of the client
+func (s *wsSession) release() {
+	if s.quotas != nil {
+		s.quotas.Release(s.principal)
+	}
+}
+
+func (s *wsSession) readLoop() {
+	for {
+		var msg WSRequest
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				s.send(WSFrame{Type: WSTypeError, Error: err.Error()})
+				continue
+			}
+			return
+		}
+
+		switch msg.Op {
+		case WSOpSubscribe:
+			s.subscribe(msg)
+		case WSOpUnsubscribe:
+			s.unsubscribe(msg)
+		case WSOpPing:
+			now := time.Now().UTC()
+			s.send(WSFrame{Type: WSTypeHeartbeat, ID: msg.ID, Time: &now})
+		default:
+			s.send(WSFrame{Type: WSTypeError, ID: msg.ID, Error: fmt.Sprintf("unknown op %q", msg.Op)})
+		}
+	}
+}
+
+// writeLoop is the only goroutine writing to the connection
+func (s *wsSession) writeLoop(cancel context.CancelFunc) {
+	defer cancel()
+	ticker := time.NewTicker(WSHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		var frame WSFrame
+		select {
+		case frame = <-s.out:
+		case t := <-ticker.C:
+			t = t.UTC()
+			frame = WSFrame{Type: WSTypeHeartbeat, Time: &t}
+		case <-s.ctx.Done():
+			return
+		}
+		s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		if err := s.conn.WriteJSON(frame); err != nil {
+			s.logger.Info("websocket write", "err", err)
+			// Unblock the reader
+			s.conn.Close()
+			return
+		}
+	}
+}
+
+func (s *wsSession) send(frame WSFrame) {
+	select {
+	case s.out <- frame:
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *wsSession) subscribe(msg WSRequest) {
+	var subscribe func() *observer.Subscription
+	switch {
+	case msg.Filter != nil:
+		spec := *msg.Filter
+		if msg.Address != "" {
+			spec.Addresses = append(spec.Addresses, msg.Address)
+		}
+		filter, err := spec.Parse()
+		if err != nil {
+			s.send(WSFrame{Type: WSTypeError, ID: msg.ID, Error: err.Error()})
+			return
+		}
+		subscribe = func() *observer.Subscription { return s.parser.SubscribeFilter(filter, s.opts) }
+	case msg.Address != "":
+		addr, err := eth.NormalizeAddress(msg.Address)
+		if err != nil {
+			s.send(WSFrame{Type: WSTypeError, ID: msg.ID, Error: err.Error()})
+			return
+		}
+		subscribe = func() *observer.Subscription { return s.parser.Subscribe(addr, s.opts) }
+	default:
+		s.send(WSFrame{Type: WSTypeError, ID: msg.ID, Error: "address or filter required"})
+		return
+	}
+	if err := s.acquire(); err != nil {
+		s.send(WSFrame{Type: WSTypeError, ID: msg.ID, Error: err.Error()})
+		return
+	}
+	sub := subscribe()
+
+	s.mu.Lock()
+	s.subs[sub.ID] = sub
+	s.mu.Unlock()
+
+	s.send(WSFrame{Type: WSTypeAck, ID: msg.ID, Subscription: sub.ID})
+	go s.forward(sub)
+}
+
+func (s *wsSession) unsubscribe(msg WSRequest) {
+	s.mu.Lock()
+	sub, found := s.subs[msg.Subscription]
+	delete(s.subs, msg.Subscription)
+	s.mu.Unlock()
+
+	if !found {
+		s.send(WSFrame{Type: WSTypeError, ID: msg.ID, Subscription: msg.Subscription, Error: "unknown subscription"})
+		return
+	}
+	sub.Unsubscribe()
+	s.release()
+	s.send(WSFrame{Type: WSTypeAck, ID: msg.ID, Subscription: sub.ID})
+}
+
+// forward relays a subscription to the writer until the
+// subscription is closed
+func (s *wsSession) forward(sub *observer.Subscription) {
+	var dropped uint64
+	for txn := range sub.Ch {
+		if d := sub.Dropped(); d != dropped {
+			dropped = d
+			s.send(WSFrame{Type: WSTypeDropped, Subscription: sub.ID, Dropped: dropped})
+		}
+		frame := WSFrame{Type: WSTypeEvent, Subscription: sub.ID, Data: txn}
+		var t eth.Transaction
+		if err := json.Unmarshal(txn, &t); err == nil {
+			if c, ok := eth.TxCursor(t); ok {
+				frame.EventID = c.String()
+			}
+		}
+		s.send(frame)
+	}
+}
+
+func (s *wsSession) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sub := range s.subs {
+		sub.Unsubscribe()
+		s.release()
+		delete(s.subs, id)
+	}
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/auth"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/store"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWebSocket(t *testing.T) {
+	p, ch := newTestParser(t, eth.BlockTxn{BlockNum: "1"})
+	rest := RestServer{Parser: p}
+	srv := httptest.NewServer(http.HandlerFunc(rest.WebSocket))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	read := func() WSFrame {
+		t.Helper()
+		var f WSFrame
+		if err := conn.ReadJSON(&f); err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	// Two subscriptions over one connection
+	conn.WriteJSON(WSRequest{Op: WSOpSubscribe, ID: "1", Address: testAddrA})
+	ackA := read()
+	conn.WriteJSON(WSRequest{Op: WSOpSubscribe, ID: "2", Filter: &eth.FilterSpec{Addresses: []string{testAddrB}}})
+	ackB := read()
+	if ackA.Type != WSTypeAck || ackA.ID != "1" || ackB.Type != WSTypeAck || ackB.ID != "2" {
+		t.Fatalf("expected acks; got %+v %+v", ackA, ackB)
+	}
+
+	conn.WriteJSON(WSRequest{Op: WSOpSubscribe, ID: "3", Address: "bad"})
+	if f := read(); f.Type != WSTypeError || f.ID != "3" {
+		t.Errorf("expected error frame for id 3; got %+v", f)
+	}
+
+	ch <- eth.BlockTxn{BlockNum: "2", Txns: []eth.Transaction{testTxn(2, 0, testAddrA, testAddrB)}}
+	events := map[string]string{}
+	for range 2 {
+		f := read()
+		if f.Type != WSTypeEvent {
+			t.Fatalf("expected event; got %+v", f)
+		}
+		events[f.Subscription] = f.EventID
+	}
+	if events[ackA.Subscription] != "2-0" || events[ackB.Subscription] != "2-0" {
+		t.Errorf("expected event 2-0 on both subscriptions; got %v", events)
+	}
+
+	conn.WriteJSON(WSRequest{Op: WSOpUnsubscribe, ID: "4", Subscription: ackA.Subscription})
+	if f := read(); f.Type != WSTypeAck || f.ID != "4" {
+		t.Errorf("expected unsubscribe ack; got %+v", f)
+	}
+
+	ch <- eth.BlockTxn{BlockNum: "3", Txns: []eth.Transaction{testTxn(3, 0, testAddrA, testAddrB)}}
+	if f := read(); f.Subscription != ackB.Subscription || f.EventID != "3-0" {
+		t.Errorf("expected only subscription B to receive 3-0; got %+v", f)
+	}
+
+	conn.WriteJSON(WSRequest{Op: WSOpPing, ID: "5"})
+	if f := read(); f.Type != WSTypeHeartbeat || f.ID != "5" {
+		t.Errorf("expected heartbeat reply; got %+v", f)
+	}
+}
+
+func TestWebSocketSubscriptionCap(t *testing.T) {
+	p, _ := newTestParser(t, eth.BlockTxn{BlockNum: "1"})
+	keys := auth.NewKeyStore(store.NewInMemoryStorage())
+	key, secret, _ := keys.Issue("frontend", auth.RoleUser, auth.Limits{MaxSubscriptions: 1})
+	rest := RestServer{Parser: p, Auth: NewAuth(keys, nil, "")}
+	mux := http.NewServeMux()
+	rest.RegisterV1(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + V1Prefix + "/ws?" + AccessTokenParam + "=" + secret
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	read := func() WSFrame {
+		t.Helper()
+		var f WSFrame
+		if err := conn.ReadJSON(&f); err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	conn.WriteJSON(WSRequest{Op: WSOpSubscribe, ID: "1", Address: testAddrA})
+	ack := read()
+	if ack.Type != WSTypeAck {
+		t.Fatalf("expected ack; got %+v", ack)
+	}
+	// The subscription holds the only slot of the client
+	conn.WriteJSON(WSRequest{Op: WSOpSubscribe, ID: "2", Address: testAddrB})
+	if f := read(); f.Type != WSTypeError || f.ID != "2" {
+		t.Errorf("expected an error above the cap; got %+v", f)
+	}
+	if got := rest.Auth.Quotas.Subscriptions(key.ID); got != 1 {
+		t.Errorf("expected 1 subscription counted; got %d", got)
+	}
+	conn.WriteJSON(WSRequest{Op: WSOpUnsubscribe, ID: "3", Subscription: ack.Subscription})
+	read()
+	conn.WriteJSON(WSRequest{Op: WSOpSubscribe, ID: "4", Address: testAddrB})
+	if f := read(); f.Type != WSTypeAck {
+		t.Errorf("expected ack once the slot is freed; got %+v", f)
+	}
+
+	// A message above the read limit closes the connection
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"op":"ping","id":"`+strings.Repeat("x", int(wsReadLimit))+`"}`))
+	if _, _, err := conn.ReadMessage(); err == nil {
+		t.Error("expected the connection closed after an oversized message")
+	}
+	// Closing the connection frees its subscriptions
+	deadline := time.Now().Add(time.Second)
+	for rest.Auth.Quotas.Subscriptions(key.ID) != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := rest.Auth.Quotas.Subscriptions(key.ID); got != 0 {
+		t.Errorf("expected the subscriptions released; got %d", got)
+	}
+}
@@ -0,0 +1,3 @@
+// Package httpstatus records the status code of HTTP responses
+// for the middlewares that report it
+package httpstatus
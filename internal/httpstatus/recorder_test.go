@@ -0,0 +1,36 @@
+package httpstatus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	testcases := []struct {
+		name     string
+		write    func(w http.ResponseWriter)
+		expected int
+	}{
+		{"nothing written", func(w http.ResponseWriter) {}, http.StatusOK},
+		{"body only", func(w http.ResponseWriter) { w.Write([]byte("ok")) }, http.StatusOK},
+		{"status", func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound) }, http.StatusNotFound},
+		{"first status kept", func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.WriteHeader(http.StatusOK)
+		}, http.StatusServiceUnavailable},
+	}
+	for _, tc := range testcases {
+		rec := NewRecorder(httptest.NewRecorder())
+		tc.write(rec)
+		if got := rec.Status(); got != tc.expected {
+			t.Errorf("%s: expected %d; got %d", tc.name, tc.expected, got)
+		}
+	}
+
+	// The underlying writer is reachable for flushing
+	w := httptest.NewRecorder()
+	if err := http.NewResponseController(NewRecorder(w)).Flush(); err != nil || !w.Flushed {
+		t.Errorf("expected the response flushed; got %v", err)
+	}
+}
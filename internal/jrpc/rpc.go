@@ -26,7 +26,14 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"paulwizviz/go-eth-app/internal/metrics"
+	"paulwizviz/go-eth-app/internal/tracing"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -100,6 +107,8 @@ const (
 	contentType = "application/json"
 )
 
+var tracer = otel.Tracer("paulwizviz/go-eth-app/internal/jrpc")
+
 type request struct {
 	JsonRPC string `json:"jsonrpc"`
 	Method  string `json:"method"`
@@ -133,7 +142,21 @@ type response struct {
 	Err     *respError      `json:"error,omitempty"`
 }
 
-func postRPC(ctx context.Context, timeout time.Duration, url string, reqID uint, reqBody []byte) (response, error) {
+func postRPC(ctx context.Context, timeout time.Duration, url string, reqID uint, method string, reqBody []byte) (_ response, err error) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemKey.String("jsonrpc"),
+			semconv.RPCMethod(method),
+			tracing.Endpoint(url),
+		),
+	)
+	defer func() {
+		metrics.ObserveRPC(method, start, err)
+		tracing.End(span, err)
+	}()
+
 	client := http.Client{
 		Timeout: timeout,
 	}
@@ -144,12 +167,14 @@ func postRPC(ctx context.Context, timeout time.Duration, url string, reqID uint,
 	}
 	request.Header.Add("Content-Type", contentType)
 	req := request.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	resp, err := client.Do(req)
 	if err != nil {
 		return response{}, fmt.Errorf("%w-%v", ErrSendingRequest, err)
 	}
 	defer resp.Body.Close()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
 
 	var rpcResp response
 	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
@@ -160,6 +185,7 @@ func postRPC(ctx context.Context, timeout time.Duration, url string, reqID uint,
 	}
 
 	if rpcResp.Err != nil {
+		span.SetAttributes(semconv.RPCJsonrpcErrorCode(rpcResp.Err.Code))
 		errMsg := fmt.Sprintf("Error code: %v message: %v", rpcResp.Err.Code, rpcResp.Err.Message)
 		return response{}, fmt.Errorf("%w-%v", ErrResponse, errMsg)
 	}
@@ -304,7 +330,7 @@ func accounts(ctx context.Context, timeout time.Duration, url string, reqID uint
 		return nil, err
 	}
 
-	response, err := postRPC(ctx, timeout, url, reqID, reqBody)
+	response, err := postRPC(ctx, timeout, url, reqID, "eth_accounts", reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -329,7 +355,7 @@ func blockNumber(ctx context.Context, timeout time.Duration, url string, reqID u
 		return nil, err
 	}
 
-	rpcResp, err := postRPC(ctx, timeout, url, reqID, reqBody)
+	rpcResp, err := postRPC(ctx, timeout, url, reqID, "eth_blockNumber", reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -362,7 +388,7 @@ func call(ctx context.Context, timeout time.Duration, url string, reqID uint, tx
 		return "", err
 	}
 
-	rpcResp, err := postRPC(ctx, timeout, url, reqID, reqBody)
+	rpcResp, err := postRPC(ctx, timeout, url, reqID, "eth_call", reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -385,7 +411,7 @@ func gasPrice(ctx context.Context, timeout time.Duration, url string, reqID uint
 	if err != nil {
 		return nil, err
 	}
-	rpcResp, err := postRPC(ctx, timeout, url, reqID, reqBody)
+	rpcResp, err := postRPC(ctx, timeout, url, reqID, "eth_gasPrice", reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -411,7 +437,7 @@ func getBlockByNumber(ctx context.Context, timeout time.Duration, url string, re
 		return Block{}, err
 	}
 
-	rpcResp, err := postRPC(ctx, timeout, url, reqID, reqBody)
+	rpcResp, err := postRPC(ctx, timeout, url, reqID, "eth_getBlockByNumber", reqBody)
 	if err != nil {
 		return Block{}, err
 	}
@@ -436,7 +462,7 @@ func getBalance(ctx context.Context, timeout time.Duration, url string, reqID ui
 		return "", err
 	}
 
-	rpcResp, err := postRPC(ctx, timeout, url, reqID, reqBody)
+	rpcResp, err := postRPC(ctx, timeout, url, reqID, "eth_getBalance", reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -474,7 +500,7 @@ func getTxnReceipt(ctx context.Context, timeout time.Duration, url string, reqID
 		return TxnReceipt{}, err
 	}
 
-	rpcResp, err := postRPC(ctx, timeout, url, reqID, reqBody)
+	rpcResp, err := postRPC(ctx, timeout, url, reqID, "eth_getTransactionReceipt", reqBody)
 	if err != nil {
 		return TxnReceipt{}, err
 	}
@@ -499,7 +525,7 @@ func networkID(ctx context.Context, timeout time.Duration, url string, reqID uin
 		return nil, err
 	}
 
-	rpcResp, err := postRPC(ctx, timeout, url, reqID, reqBody)
+	rpcResp, err := postRPC(ctx, timeout, url, reqID, "net_version", reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -531,7 +557,7 @@ func sendTransaction(ctx context.Context, timeout time.Duration, url string, req
 		return "", err
 	}
 
-	rpcResp, err := postRPC(ctx, timeout, url, reqID, reqBody)
+	rpcResp, err := postRPC(ctx, timeout, url, reqID, "eth_sendTransaction", reqBody)
 	if err != nil {
 		return "", err
 	}
@@ -555,7 +581,7 @@ func sendRawTransaction(ctx context.Context, timeout time.Duration, url string,
 		return "", err
 	}
 
-	rpcResp, err := postRPC(ctx, timeout, url, reqID, reqBody)
+	rpcResp, err := postRPC(ctx, timeout, url, reqID, "eth_sendRawTransaction", reqBody)
 	if err != nil {
 		return "", err
 	}
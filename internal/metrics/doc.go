@@ -0,0 +1,3 @@
+// Package metrics defines the Prometheus metrics of the
+// transaction parser pipeline and tracks ingestion progress
+package metrics
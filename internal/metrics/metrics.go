@@ -0,0 +1,236 @@
+package metrics
+
+import (
+	"net/http"
+	"paulwizviz/go-eth-app/internal/httpstatus"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "txparser"
+
+// Registry holds every metric of the pipeline alongside the Go
+// runtime and process collectors
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Ingestion, labelled by chain ID
+var (
+	ChainHead = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "chain_head_block",
+		Help:      "Latest block number reported by the node.",
+	}, []string{"chain"})
+	ParsedBlock = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "parsed_block",
+		Help:      "Latest block number processed by the parser.",
+	}, []string{"chain"})
+	IngestionLagBlocks = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "ingestion_lag_blocks",
+		Help:      "Chain head minus the latest parsed block.",
+	}, []string{"chain"})
+	BlocksProcessed = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "blocks_processed_total",
+		Help:      "Blocks processed by the parser.",
+	}, []string{"chain"})
+	TransactionsProcessed = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transactions_processed_total",
+		Help:      "Transactions processed by the parser.",
+	}, []string{"chain"})
+	TokenTransfersProcessed = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "token_transfers_processed_total",
+		Help:      "Token transfers decoded from receipts, by standard.",
+	}, []string{"chain", "standard"})
+	InternalTransfersProcessed = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "internal_transfers_processed_total",
+		Help:      "Value transfers made by contract calls, by whether they reverted.",
+	}, []string{"chain", "failed"})
+	RangeProgress = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "range_indexing_progress_ratio",
+		Help:      "Share of the blocks of the latest range indexing job done.",
+	}, []string{"chain"})
+	PendingTransactions = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pending_transactions",
+		Help:      "Mempool transactions of watched addresses awaiting an outcome.",
+	}, []string{"chain"})
+)
+
+// JSON-RPC
+var (
+	RPCDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_request_duration_seconds",
+		Help:      "Latency of JSON-RPC requests to the node.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+	RPCErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_errors_total",
+		Help:      "Failed JSON-RPC requests to the node.",
+	}, []string{"method"})
+)
+
+// Observer
+var (
+	Subscribers = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "observer_subscribers",
+		Help:      "Open observer subscriptions.",
+	})
+	DroppedMessages = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "observer_dropped_messages_total",
+		Help:      "Messages dropped because a subscriber was slow.",
+	})
+)
+
+// Storage, labelled by chain ID
+var (
+	StorageKeys = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "storage_keys",
+		Help:      "Addresses held in transaction storage.",
+	}, []string{"chain"})
+	StorageValues = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "storage_values",
+		Help:      "Transaction entries held in transaction storage.",
+	}, []string{"chain"})
+)
+
+// HTTP
+var (
+	HTTPDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by route; streams are measured until they close.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "code"})
+	HTTPInFlight = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "HTTP requests being served, including open streams.",
+	})
+)
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// ObserveRPC records the outcome of a JSON-RPC request
+func ObserveRPC(method string, start time.Time, err error) {
+	RPCDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		RPCErrors.WithLabelValues(method).Inc()
+	}
+}
+
+// Chain returns the label value of a chain ID
+func Chain(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+type progress struct {
+	head, parsed int64
+}
+
+var (
+	progressMu sync.Mutex
+	chains     = map[int64]progress{}
+)
+
+func update(chain int64, f func(p *progress)) {
+	progressMu.Lock()
+	p, ok := chains[chain]
+	if !ok {
+		p = progress{head: -1, parsed: -1}
+	}
+	f(&p)
+	chains[chain] = p
+	progressMu.Unlock()
+
+	if lag, ok := IngestionLag(chain); ok {
+		IngestionLagBlocks.WithLabelValues(Chain(chain)).Set(float64(lag))
+	}
+}
+
+// SetChainHead records the latest block reported by the node
+// of a chain
+func SetChainHead(chain, block int64) {
+	update(chain, func(p *progress) { p.head = block })
+	ChainHead.WithLabelValues(Chain(chain)).Set(float64(block))
+}
+
+// SetParsedBlock records the latest block of a chain processed
+func SetParsedBlock(chain, block int64) {
+	update(chain, func(p *progress) { p.parsed = block })
+	ParsedBlock.WithLabelValues(Chain(chain)).Set(float64(block))
+}
+
+// IngestionLag returns the number of blocks the parser of a
+// chain is behind its head, and false until both are known
+func IngestionLag(chain int64) (int64, bool) {
+	h, p := Progress(chain)
+	if h < 0 || p < 0 {
+		return 0, false
+	}
+	if h < p {
+		return 0, true
+	}
+	return h - p, true
+}
+
+// Progress returns the head of a chain and the latest parsed
+// block, -1 while unknown
+func Progress(chain int64) (head, parsed int64) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	p, ok := chains[chain]
+	if !ok {
+		return -1, -1
+	}
+	return p.head, p.parsed
+}
+
+// InstrumentHandler records the latency of requests served by a
+// ServeMux, labelled by the matched route pattern so paths with
+// addresses do not explode the label space
+func InstrumentHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		HTTPInFlight.Inc()
+		defer HTTPInFlight.Dec()
+
+		start := time.Now()
+		rec := httpstatus.NewRecorder(w)
+		next.ServeHTTP(rec, req)
+
+		route := req.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		HTTPDuration.WithLabelValues(req.Method, route, strconv.Itoa(rec.Status())).Observe(time.Since(start).Seconds())
+	})
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestIngestionLag(t *testing.T) {
+	if _, ok := IngestionLag(1); ok {
+		t.Fatal("expected no lag before the head is known")
+	}
+	SetParsedBlock(1, 90)
+	SetChainHead(1, 100)
+	if lag, ok := IngestionLag(1); !ok || lag != 10 {
+		t.Errorf("expected lag 10; got %d %v", lag, ok)
+	}
+	if got := testutil.ToFloat64(IngestionLagBlocks.WithLabelValues("1")); got != 10 {
+		t.Errorf("expected gauge 10; got %v", got)
+	}
+	// A head read before the block is parsed is not negative lag
+	SetParsedBlock(1, 101)
+	if lag, _ := IngestionLag(1); lag != 0 {
+		t.Errorf("expected lag 0; got %d", lag)
+	}
+	// Chains are tracked apart
+	SetChainHead(10, 5)
+	if _, ok := IngestionLag(10); ok {
+		t.Error("expected no lag for a chain without a parsed block")
+	}
+}
+
+func TestInstrumentHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /addresses/{address}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	mux.Handle("GET /metrics", Handler())
+	srv := httptest.NewServer(InstrumentHandler(mux))
+	defer srv.Close()
+
+	for _, addr := range []string{"0xaa", "0xbb"} {
+		resp, err := http.Get(srv.URL + "/addresses/" + addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	want := `txparser_http_request_duration_seconds_count{code="418",method="GET",route="GET /addresses/{address}"} 2`
+	if !strings.Contains(string(body), want) {
+		t.Errorf("expected %s in\n%s", want, body)
+	}
+}
@@ -1,79 +1,288 @@
 package observer
 
 import (
+	"log/slog"
+	"paulwizviz/go-eth-app/internal/metrics"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Policy determines what happens to a message when a
+// subscriber's buffer is full
+type Policy int
+
+const (
+	// PolicyBlock waits for the subscriber to make room. If
+	// Options.Timeout is set, the message is dropped once the
+	// timeout expires, otherwise it waits indefinitely.
+	PolicyBlock Policy = iota
+	// PolicyDropOldest discards the oldest buffered message
+	// to make room for the new one.
+	PolicyDropOldest
+	// PolicyDropNewest discards the new message.
+	PolicyDropNewest
+	// PolicyDisconnect unsubscribes the subscriber and
+	// closes its channel.
+	PolicyDisconnect
+)
+
+// Options configures a single subscription
+type Options struct {
+	// Buffer is the capacity of the subscription channel
+	Buffer int
+	// Policy applied when the buffer is full
+	Policy Policy
+	// Timeout used by PolicyBlock
+	Timeout time.Duration
+}
+
+// DefaultOptions is used by Subscribe
+var DefaultOptions = Options{
+	Buffer: 1,
+	Policy: PolicyBlock,
+}
+
+// Wildcard is a topic that matches every published message
+const Wildcard = "*"
+
+// Filter is a predicate over a published message. A
+// subscription with a filter only receives the messages
+// for which it returns true.
+type Filter func(msg []byte) bool
+
 // Observer tracks subscribers for topics
 type Observer struct {
 	sync.Mutex
 	subscribers map[string][]*Subscription
+	logger      *slog.Logger
 }
 
-// Subscription is a single subscription to a topic.
+// Subscription is a single subscription to one or more topics.
 // It contains a channel that will receive all future messages
-// for the address
+// for the addresses
 type Subscription struct {
 	ID       string
-	Topic    string
+	Topics   []string
 	Observer *Observer
 	Ch       chan []byte
+
+	filter  Filter
+	opts    Options
+	mu      sync.Mutex // guards sends on Ch against close
+	closed  bool
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Uint64
 }
 
-// Unsubscribe unsubscribes the subscription from the observer
+// Dropped returns the number of messages that were not
+// delivered to the subscriber because its buffer was full
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe unsubscribes the subscription from the observer.
+// It is safe to call more than once.
 func (s *Subscription) Unsubscribe() {
-	s.Observer.Lock()
-	defer s.Observer.Unlock()
-	defer close(s.Ch)
-	i := 0
-	for _, sub := range s.Observer.subscribers[s.Topic] {
-		if sub.ID == s.ID {
-			break
-		}
-		i++
+	s.Observer.remove(s)
+	s.once.Do(func() {
+		metrics.Subscribers.Dec()
+		s.Observer.logger.Debug("unsubscribed", "subscription", s.ID, "dropped", s.dropped.Load())
+		// Release any sender blocked on Ch before
+		// waiting for the send lock.
+		close(s.done)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		close(s.Ch)
+	})
+}
+
+func (s *Subscription) drop() {
+	s.dropped.Add(1)
+	metrics.DroppedMessages.Inc()
+}
+
+// deliver sends msg according to the subscription policy
+// and reports whether the subscriber must be disconnected.
+func (s *Subscription) deliver(msg []byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
 	}
 
-	s.Observer.subscribers[s.Topic] = append(s.Observer.subscribers[s.Topic][:i], s.Observer.subscribers[s.Topic][i+1:]...)
+	switch s.opts.Policy {
+	case PolicyDropNewest:
+		select {
+		case s.Ch <- msg:
+		default:
+			s.drop()
+		}
+	case PolicyDropOldest:
+		for {
+			select {
+			case s.Ch <- msg:
+				return false
+			default:
+			}
+			select {
+			case <-s.Ch:
+				s.drop()
+			default:
+			}
+		}
+	case PolicyDisconnect:
+		select {
+		case s.Ch <- msg:
+		default:
+			s.drop()
+			s.Observer.logger.Warn("disconnecting slow subscriber", "subscription", s.ID, "topics", s.Topics)
+			return true
+		}
+	default:
+		if s.opts.Timeout <= 0 {
+			select {
+			case s.Ch <- msg:
+			case <-s.done:
+			}
+			return false
+		}
+		timer := time.NewTimer(s.opts.Timeout)
+		defer timer.Stop()
+		select {
+		case s.Ch <- msg:
+		case <-timer.C:
+			s.drop()
+		case <-s.done:
+		}
+	}
+	return false
 }
 
-// NewObserver returns a new Observer
+// New returns a new Observer that does not log
 func New() *Observer {
+	return NewWithLogger(nil)
+}
+
+// NewWithLogger returns a new Observer logging subscription
+// changes and slow subscribers to logger; nil discards them
+func NewWithLogger(logger *slog.Logger) *Observer {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
 	return &Observer{
 		subscribers: map[string][]*Subscription{},
+		logger:      logger,
 	}
 }
 
-// Subscribe subscribes to an address
+// Subscribe subscribes to an address using DefaultOptions
 func (o *Observer) Subscribe(topic string) *Subscription {
+	return o.SubscribeWithOptions(topic, DefaultOptions)
+}
+
+// SubscribeWithOptions subscribes to an address with a given
+// buffer size and overflow policy
+func (o *Observer) SubscribeWithOptions(topic string, opts Options) *Subscription {
+	return o.SubscribeTopics([]string{topic}, nil, opts)
+}
+
+// SubscribeTopics subscribes to any of the given topics. Use
+// Wildcard to receive every message. If filter is not nil, only
+// messages accepted by it are delivered. A message published
+// under several matching topics is delivered once.
+func (o *Observer) SubscribeTopics(topics []string, filter Filter, opts Options) *Subscription {
+	if opts.Buffer < 1 {
+		opts.Buffer = 1
+	}
+
 	o.Lock()
 	defer o.Unlock()
 
-	_, found := o.subscribers[topic]
-	if !found {
-		o.subscribers[topic] = []*Subscription{}
-	}
 	id, _ := uuid.NewV7()
 	subscription := &Subscription{
 		ID:       id.String(),
-		Topic:    topic,
 		Observer: o,
-		Ch:       make(chan []byte, 1),
+		Ch:       make(chan []byte, opts.Buffer),
+		filter:   filter,
+		opts:     opts,
+		done:     make(chan struct{}),
 	}
-	o.subscribers[topic] = append(o.subscribers[topic], subscription)
+	seen := map[string]bool{}
+	for _, topic := range topics {
+		if seen[topic] {
+			continue
+		}
+		seen[topic] = true
+		subscription.Topics = append(subscription.Topics, topic)
+		o.subscribers[topic] = append(o.subscribers[topic], subscription)
+	}
+	metrics.Subscribers.Inc()
+	o.logger.Debug("subscribed", "subscription", subscription.ID, "topics", subscription.Topics, "buffer", opts.Buffer)
 
 	return subscription
 }
 
-// Notify notifies all subscribers of a particular topic of a message
-func (o *Observer) Notify(topic string, msg []byte) {
+func (o *Observer) remove(s *Subscription) {
+	o.Lock()
+	defer o.Unlock()
+	for _, topic := range s.Topics {
+		subs := o.subscribers[topic]
+		for i, sub := range subs {
+			if sub.ID == s.ID {
+				o.subscribers[topic] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+		if len(o.subscribers[topic]) == 0 {
+			delete(o.subscribers, topic)
+		}
+	}
+}
+
+// Subscribed reports whether a message published under the topic
+// would reach any subscriber, ignoring filters
+func (o *Observer) Subscribed(topic string) bool {
 	o.Lock()
 	defer o.Unlock()
-	if _, found := o.subscribers[topic]; !found {
-		return
+	return len(o.subscribers[topic]) > 0 || len(o.subscribers[Wildcard]) > 0
+}
+
+// Notify notifies all subscribers of a particular topic of a message.
+func (o *Observer) Notify(topic string, msg []byte) {
+	o.Publish([]string{topic}, msg)
+}
+
+// Publish notifies all subscribers of any of the topics, and all
+// wildcard subscribers, of a message. The observer lock is only
+// held while the subscriber list is collected, so a slow
+// subscriber never blocks Subscribe or Unsubscribe.
+func (o *Observer) Publish(topics []string, msg []byte) {
+	o.Lock()
+	seen := map[string]bool{}
+	subs := []*Subscription{}
+	all := append([]string{Wildcard}, topics...)
+	for _, topic := range all {
+		for _, sub := range o.subscribers[topic] {
+			if seen[sub.ID] {
+				continue
+			}
+			seen[sub.ID] = true
+			subs = append(subs, sub)
+		}
 	}
-	for _, sub := range o.subscribers[topic] {
-		sub.Ch <- msg
+	o.Unlock()
+
+	for _, sub := range subs {
+		if sub.filter != nil && !sub.filter(msg) {
+			continue
+		}
+		if sub.deliver(msg) {
+			sub.Unsubscribe()
+		}
 	}
 }
@@ -1,7 +1,10 @@
 package observer
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"testing"
 	"time"
 )
@@ -65,3 +68,163 @@ outer:
 		t.Errorf("results3: expected length of 1; got %d", len(results3))
 	}
 }
+
+func TestObserverPolicies(t *testing.T) {
+	testcases := []struct {
+		name        string
+		opts        Options
+		wantMsgs    []string
+		wantDropped uint64
+		wantClosed  bool
+	}{
+		{
+			name:        "drop newest",
+			opts:        Options{Buffer: 2, Policy: PolicyDropNewest},
+			wantMsgs:    []string{"a", "b"},
+			wantDropped: 2,
+		},
+		{
+			name:        "drop oldest",
+			opts:        Options{Buffer: 2, Policy: PolicyDropOldest},
+			wantMsgs:    []string{"c", "d"},
+			wantDropped: 2,
+		},
+		{
+			name:        "block with timeout",
+			opts:        Options{Buffer: 2, Policy: PolicyBlock, Timeout: time.Millisecond},
+			wantMsgs:    []string{"a", "b"},
+			wantDropped: 2,
+		},
+		{
+			name:        "disconnect",
+			opts:        Options{Buffer: 2, Policy: PolicyDisconnect},
+			wantMsgs:    []string{"a", "b"},
+			wantDropped: 1,
+			wantClosed:  true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := New()
+			sub := o.SubscribeWithOptions("topic", tc.opts)
+			for _, m := range []string{"a", "b", "c", "d"} {
+				o.Notify("topic", []byte(m))
+			}
+
+			got := []string{}
+			closed := false
+		drain:
+			for {
+				select {
+				case m, ok := <-sub.Ch:
+					if !ok {
+						closed = true
+						break drain
+					}
+					got = append(got, string(m))
+				default:
+					break drain
+				}
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tc.wantMsgs) {
+				t.Errorf("expected messages %v; got %v", tc.wantMsgs, got)
+			}
+			if sub.Dropped() != tc.wantDropped {
+				t.Errorf("expected %d dropped; got %d", tc.wantDropped, sub.Dropped())
+			}
+			if closed != tc.wantClosed {
+				t.Errorf("expected closed %v; got %v", tc.wantClosed, closed)
+			}
+			sub.Unsubscribe()
+		})
+	}
+}
+
+func TestObserverSlowSubscriberDoesNotBlockOthers(t *testing.T) {
+	o := New()
+	slow := o.Subscribe("topic")
+	o.Notify("topic", []byte("fill"))
+
+	done := make(chan struct{})
+	go func() {
+		o.Notify("topic", []byte("blocked"))
+		close(done)
+	}()
+
+	other := o.Subscribe("other")
+	o.Notify("other", []byte("x"))
+	if m := <-other.Ch; string(m) != "x" {
+		t.Errorf("expected x; got %s", m)
+	}
+
+	slow.Unsubscribe()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Notify still blocked after Unsubscribe")
+	}
+}
+
+func TestObserverTopicsAndFilter(t *testing.T) {
+	o := New()
+	multi := o.SubscribeTopics([]string{"a", "b"}, nil, Options{Buffer: 10})
+	all := o.SubscribeTopics([]string{Wildcard}, nil, Options{Buffer: 10})
+	long := o.SubscribeTopics([]string{Wildcard}, func(msg []byte) bool {
+		return len(msg) > 1
+	}, Options{Buffer: 10})
+
+	o.Publish([]string{"a", "b"}, []byte("ab"))
+	o.Publish([]string{"c"}, []byte("c"))
+	o.Publish([]string{"b"}, []byte("b"))
+
+	testcases := []struct {
+		name string
+		sub  *Subscription
+		want int
+	}{
+		{"multi-topic delivered once per message", multi, 2},
+		{"wildcard", all, 3},
+		{"filter", long, 1},
+	}
+	for _, tc := range testcases {
+		if got := len(tc.sub.Ch); got != tc.want {
+			t.Errorf("%s: expected %d messages; got %d", tc.name, tc.want, got)
+		}
+	}
+
+	multi.Unsubscribe()
+	o.Publish([]string{"a"}, []byte("a"))
+	if _, found := o.subscribers["a"]; found {
+		t.Errorf("expected topic a to be removed after unsubscribe")
+	}
+}
+
+func TestObserverLogger(t *testing.T) {
+	var buf bytes.Buffer
+	o := NewWithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	s := o.SubscribeWithOptions("topic-a", Options{Buffer: 1, Policy: PolicyDisconnect})
+	o.Notify("topic-a", []byte("1"))
+	o.Notify("topic-a", []byte("2"))
+
+	var records []map[string]any
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var r map[string]any
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, r)
+	}
+	want := []string{"subscribed", "disconnecting slow subscriber", "unsubscribed"}
+	if len(records) != len(want) {
+		t.Fatalf("expected %d records; got %v", len(want), records)
+	}
+	for i, r := range records {
+		if r["msg"] != want[i] || r["subscription"] != s.ID {
+			t.Errorf("expected %q for %s; got %v", want[i], s.ID, r)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package sink publishes parsed blocks and transactions to
+// external systems such as message brokers and append logs
+package sink
@@ -0,0 +1,59 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var (
+	ErrOpenFile  = errors.New("open file error")
+	ErrWriteFile = errors.New("write file error")
+)
+
+// FileSink is an append-only log of JSON-lines records
+type FileSink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewFileSink opens, or creates, the log at path
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("%w-%v", ErrOpenFile, err)
+	}
+	return &FileSink{f: f}, nil
+}
+
+// Write appends the records and syncs the file
+func (s *FileSink) Write(ctx context.Context, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := bufio.NewWriter(s.f)
+	enc := json.NewEncoder(w)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("%w-%v", ErrWriteFile, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("%w-%v", ErrWriteFile, err)
+	}
+	if err := s.f.Sync(); err != nil {
+		return fmt.Errorf("%w-%v", ErrWriteFile, err)
+	}
+	return nil
+}
+
+// Close closes the log
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
@@ -0,0 +1,140 @@
+package sink
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+var (
+	ErrNATSConnect = errors.New("nats connect error")
+	ErrNATSPublish = errors.New("nats publish error")
+)
+
+// NATSOptions are the connection settings of a NATSSink
+type NATSOptions struct {
+	Timeout time.Duration
+	// User and Password, or Token, authenticate the connection
+	User     string
+	Password string
+	Token    string
+	// TLS secures the connection. It is required when the
+	// server requires TLS.
+	TLS *tls.Config
+}
+
+// NATSSink publishes records to a NATS JetStream stream. Each
+// record is sent to "<prefix>.<kind>" with its key as the message
+// ID, so the stream discards duplicates. A write succeeds only
+// once every record is acknowledged by the stream; a subject no
+// stream captures fails the write.
+type NATSSink struct {
+	addr   string
+	prefix string
+	opts   NATSOptions
+
+	mu sync.Mutex
+	nc *nats.Conn
+	js jetstream.JetStream
+}
+
+// NewNATSSink instantiate a sink for the server at addr, e.g.
+// localhost:4222. The connection is established on first write
+// and re-established by the client when lost.
+func NewNATSSink(addr, subjectPrefix string, opts NATSOptions) *NATSSink {
+	return &NATSSink{
+		addr:   addr,
+		prefix: subjectPrefix,
+		opts:   opts,
+	}
+}
+
+// Write publishes the records and waits for the stream to
+// acknowledge them
+func (s *NATSSink) Write(ctx context.Context, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.nc == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+	if s.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.Timeout)
+		defer cancel()
+	}
+
+	acks := make([]jetstream.PubAckFuture, len(records))
+	for i, rec := range records {
+		payload, err := json.Marshal(rec)
+		if err != nil {
+			return fmt.Errorf("%w-%s: %v", ErrNATSPublish, rec.Key, err)
+		}
+		acks[i], err = s.js.PublishAsync(s.prefix+"."+rec.Kind, payload, jetstream.WithMsgID(rec.Key))
+		if err != nil {
+			return fmt.Errorf("%w-%s: %v", ErrNATSPublish, rec.Key, err)
+		}
+	}
+	for i, ack := range acks {
+		select {
+		case <-ack.Ok():
+		case err := <-ack.Err():
+			return fmt.Errorf("%w-%s: %v", ErrNATSPublish, records[i].Key, err)
+		case <-ctx.Done():
+			return fmt.Errorf("%w-%s: %v", ErrNATSPublish, records[i].Key, ctx.Err())
+		}
+	}
+	return nil
+}
+
+// Close closes the connection
+func (s *NATSSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.nc == nil {
+		return nil
+	}
+	s.nc.Close()
+	s.nc, s.js = nil, nil
+	return nil
+}
+
+func (s *NATSSink) connect() error {
+	opts := []nats.Option{
+		nats.Name("go-eth-app"),
+		// Reconnect for as long as the sink is open
+		nats.MaxReconnects(-1),
+	}
+	if s.opts.Timeout > 0 {
+		opts = append(opts, nats.Timeout(s.opts.Timeout))
+	}
+	if s.opts.User != "" {
+		opts = append(opts, nats.UserInfo(s.opts.User, s.opts.Password))
+	}
+	if s.opts.Token != "" {
+		opts = append(opts, nats.Token(s.opts.Token))
+	}
+	if s.opts.TLS != nil {
+		opts = append(opts, nats.Secure(s.opts.TLS))
+	}
+	nc, err := nats.Connect(s.addr, opts...)
+	if err != nil {
+		return fmt.Errorf("%w-%v", ErrNATSConnect, err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return fmt.Errorf("%w-%v", ErrNATSConnect, err)
+	}
+	s.nc, s.js = nc, js
+	return nil
+}
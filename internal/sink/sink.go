@@ -0,0 +1,234 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"paulwizviz/go-eth-app/internal/eth"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record kinds
+const (
+	KindBlock       = "block"
+	KindTransaction = "transaction"
+)
+
+var (
+	ErrInvalidBlockNumber = errors.New("invalid block number")
+	ErrLoadCheckpoint     = errors.New("load checkpoint error")
+	ErrSaveCheckpoint     = errors.New("save checkpoint error")
+	ErrRelayClosed        = errors.New("relay closed")
+)
+
+// Record is a single message written to a sink. Key is an
+// idempotency key that is stable across redeliveries, so
+// consumers can discard duplicates.
+type Record struct {
+	Key  string `json:"key"`
+	Kind string `json:"kind"`
+	// Chain is the chain ID of the block, omitted if unknown
+	Chain int64           `json:"chain,omitempty"`
+	Block uint64          `json:"block"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// BlockSummary is the data of a block record
+type BlockSummary struct {
+	Number       uint64   `json:"number"`
+	Transactions []string `json:"transactions"`
+}
+
+// Sink is a destination for records. Write must only return
+// nil once every record is durably accepted.
+type Sink interface {
+	Write(ctx context.Context, records []Record) error
+	Close() error
+}
+
+// Checkpoint persists the last block fully written to a sink
+type Checkpoint interface {
+	// Load returns the checkpoint and false if none is saved
+	Load() (uint64, bool, error)
+	Save(block uint64) error
+}
+
+// NewFileCheckpoint instantiate a checkpoint stored in a file
+func NewFileCheckpoint(path string) Checkpoint {
+	return &fileCheckpoint{path: path}
+}
+
+type fileCheckpoint struct {
+	path string
+}
+
+func (f *fileCheckpoint) Load() (uint64, bool, error) {
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("%w-%v", ErrLoadCheckpoint, err)
+	}
+	block, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("%w-%v", ErrLoadCheckpoint, err)
+	}
+	return block, true, nil
+}
+
+// Save writes to a temporary file and renames it so a crash
+// never leaves a partial checkpoint
+func (f *fileCheckpoint) Save(block uint64) error {
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(block, 10)), 0o644); err != nil {
+		return fmt.Errorf("%w-%v", ErrSaveCheckpoint, err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("%w-%v", ErrSaveCheckpoint, err)
+	}
+	return nil
+}
+
+// Relay implements eth.BlockSink. It converts each block to
+// records, writes them to a sink with at-least-once delivery
+// and checkpoints the block number once the write succeeds.
+// Blocks at or below the checkpoint are skipped.
+type Relay struct {
+	ctx        context.Context
+	sink       Sink
+	checkpoint Checkpoint
+
+	// InitialBackoff and MaxBackoff bound the delay between
+	// attempts to write a block
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Logger receives failed writes; nil discards them
+	Logger *slog.Logger
+
+	mu   sync.Mutex
+	last uint64
+	seen bool
+}
+
+// NewRelay instantiate a relay. Writes are retried until they
+// succeed or ctx is cancelled.
+func NewRelay(ctx context.Context, s Sink, cp Checkpoint) (*Relay, error) {
+	last, seen, err := cp.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Relay{
+		ctx:            ctx,
+		sink:           s,
+		checkpoint:     cp,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		last:           last,
+		seen:           seen,
+	}, nil
+}
+
+// Checkpoint returns the last block written and false if no
+// block has been written
+func (r *Relay) Checkpoint() (uint64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last, r.seen
+}
+
+func (r *Relay) logger() *slog.Logger {
+	if r.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return r.Logger
+}
+
+// PublishBlock blocks until the block is written to the sink
+func (r *Relay) PublishBlock(b eth.BlockTxn) error {
+	block, err := strconv.ParseUint(b.BlockNum, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w-%s", ErrInvalidBlockNumber, b.BlockNum)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.seen && block <= r.last {
+		return nil
+	}
+
+	records, err := Records(block, b.Txns)
+	if err != nil {
+		return err
+	}
+	// Keys stay unique across the chains sharing a sink
+	if b.ChainID != 0 {
+		for i := range records {
+			records[i].Chain = b.ChainID
+			records[i].Key = fmt.Sprintf("%d/%s", b.ChainID, records[i].Key)
+		}
+	}
+
+	backoff := r.InitialBackoff
+	for {
+		err := r.sink.Write(r.ctx, records)
+		if err == nil {
+			break
+		}
+		r.logger().Warn("sink write failed", "block", block, "backoff", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-r.ctx.Done():
+			return fmt.Errorf("%w-%v", ErrRelayClosed, r.ctx.Err())
+		}
+		backoff *= 2
+		if backoff > r.MaxBackoff {
+			backoff = r.MaxBackoff
+		}
+	}
+
+	if err := r.checkpoint.Save(block); err != nil {
+		return err
+	}
+	r.last = block
+	r.seen = true
+	return nil
+}
+
+// Records returns the records of a block: one per transaction
+// followed by a block record. Transactions are keyed by hash and
+// blocks by number.
+func Records(block uint64, txns []eth.Transaction) ([]Record, error) {
+	records := make([]Record, 0, len(txns)+1)
+	summary := BlockSummary{Number: block, Transactions: []string{}}
+	for _, tx := range txns {
+		data, err := json.Marshal(tx)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, Record{
+			Key:   "tx-" + tx.Hash,
+			Kind:  KindTransaction,
+			Block: block,
+			Data:  data,
+		})
+		summary.Transactions = append(summary.Transactions, tx.Hash)
+	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return nil, err
+	}
+	records = append(records, Record{
+		Key:   "block-" + strconv.FormatUint(block, 10),
+		Kind:  KindBlock,
+		Block: block,
+		Data:  data,
+	})
+	return records, nil
+}
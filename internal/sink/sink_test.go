@@ -0,0 +1,227 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"paulwizviz/go-eth-app/internal/eth"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type flakySink struct {
+	failures int
+	writes   [][]Record
+}
+
+func (f *flakySink) Write(ctx context.Context, records []Record) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("broker unavailable")
+	}
+	f.writes = append(f.writes, records)
+	return nil
+}
+
+func (f *flakySink) Close() error { return nil }
+
+func TestRelayAtLeastOnceWithCheckpoint(t *testing.T) {
+	cpPath := filepath.Join(t.TempDir(), "checkpoint")
+	s := &flakySink{failures: 2}
+	r, err := NewRelay(context.Background(), s, NewFileCheckpoint(cpPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.InitialBackoff = time.Millisecond
+
+	block := eth.BlockTxn{BlockNum: "10", Txns: []eth.Transaction{{Hash: "0xa"}, {Hash: "0xb"}}}
+	if err := r.PublishBlock(block); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.writes) != 1 || len(s.writes[0]) != 3 {
+		t.Fatalf("expected one write of 3 records; got %v", s.writes)
+	}
+	keys := []string{}
+	for _, rec := range s.writes[0] {
+		keys = append(keys, rec.Key)
+	}
+	if strings.Join(keys, ",") != "tx-0xa,tx-0xb,block-10" {
+		t.Errorf("unexpected keys %v", keys)
+	}
+
+	// A restarted relay skips blocks up to the checkpoint
+	s2 := &flakySink{}
+	r2, err := NewRelay(context.Background(), s2, NewFileCheckpoint(cpPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2.PublishBlock(block)
+	r2.PublishBlock(eth.BlockTxn{BlockNum: "11"})
+	if len(s2.writes) != 1 || s2.writes[0][0].Key != "block-11" {
+		t.Errorf("expected only block 11 to be written; got %v", s2.writes)
+	}
+	if last, ok := r2.Checkpoint(); !ok || last != 11 {
+		t.Errorf("expected checkpoint 11; got %d %v", last, ok)
+	}
+}
+
+func TestRelayChainKeys(t *testing.T) {
+	s := &flakySink{}
+	r, _ := NewRelay(context.Background(), s, NewFileCheckpoint(filepath.Join(t.TempDir(), "cp")))
+	r.PublishBlock(eth.BlockTxn{ChainID: 8453, BlockNum: "7", Txns: []eth.Transaction{{Hash: "0xa"}}})
+	if len(s.writes) != 1 {
+		t.Fatalf("expected one write; got %v", s.writes)
+	}
+	for i, want := range []string{"8453/tx-0xa", "8453/block-7"} {
+		if rec := s.writes[0][i]; rec.Key != want || rec.Chain != 8453 {
+			t.Errorf("expected %s of chain 8453; got %+v", want, rec)
+		}
+	}
+}
+
+func TestRelayCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r, _ := NewRelay(ctx, &flakySink{failures: 1}, NewFileCheckpoint(filepath.Join(t.TempDir(), "cp")))
+	if err := r.PublishBlock(eth.BlockTxn{BlockNum: "1"}); !errors.Is(err, ErrRelayClosed) {
+		t.Errorf("expected ErrRelayClosed; got %v", err)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.jsonl")
+	s, err := NewFileSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, _ := Records(1, []eth.Transaction{{Hash: "0xa"}})
+	s.Write(context.Background(), records)
+	s.Write(context.Background(), records)
+	s.Close()
+
+	b, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines; got %d", len(lines))
+	}
+	var rec Record
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil || rec.Key != "tx-0xa" {
+		t.Errorf("unexpected first record %s", lines[0])
+	}
+}
+
+// fakeNATS accepts one connection, records published subjects
+// and message IDs and replies to each publish with ack
+func fakeNATS(t *testing.T, ack func(seq int) string) (string, func() []string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	var mu sync.Mutex
+	msgs := []string{}
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte(`INFO {"headers":true,"max_payload":1048576}` + "\r\n"))
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			switch fields[0] {
+			case "PING":
+				conn.Write([]byte("PONG\r\n"))
+			case "HPUB":
+				hdrLen, _ := strconv.Atoi(fields[3])
+				total, _ := strconv.Atoi(fields[4])
+				buf := make([]byte, total+2)
+				io.ReadFull(r, buf)
+				hdr := string(buf[:hdrLen])
+				_, id, _ := strings.Cut(hdr, jetstream.MsgIDHeader+": ")
+				id, _, _ = strings.Cut(id, "\r\n")
+				mu.Lock()
+				msgs = append(msgs, fields[1]+" "+id)
+				reply := ack(len(msgs))
+				mu.Unlock()
+				if strings.HasPrefix(reply, "NATS/1.0") {
+					fmt.Fprintf(conn, "HMSG %s 1 %d %d\r\n%s\r\n", fields[2], len(reply), len(reply), reply)
+				} else {
+					fmt.Fprintf(conn, "MSG %s 1 %d\r\n%s\r\n", fields[2], len(reply), reply)
+				}
+			}
+		}
+	}()
+	return l.Addr().String(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, msgs...)
+	}
+}
+
+func TestNATSSink(t *testing.T) {
+	addr, published := fakeNATS(t, func(seq int) string {
+		return fmt.Sprintf(`{"stream":"ETH","seq":%d}`, seq)
+	})
+	s := NewNATSSink(addr, "eth", NATSOptions{Timeout: time.Second})
+	defer s.Close()
+
+	records, _ := Records(7, []eth.Transaction{{Hash: "0xa"}})
+	if err := s.Write(context.Background(), records); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(published(), ",")
+	if got != "eth.transaction tx-0xa,eth.block block-7" {
+		t.Errorf("unexpected publishes %s", got)
+	}
+}
+
+func TestNATSSinkNotAcknowledged(t *testing.T) {
+	testcases := []struct {
+		name string
+		ack  string
+	}{
+		{"error", `{"error":{"code":503,"description":"insufficient resources"}}`},
+		{"no stream", "NATS/1.0 503\r\n\r\n"},
+		{"no stream name", `{"seq":1}`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, _ := fakeNATS(t, func(seq int) string {
+				// Publishes without responders are retried
+				if seq >= 2 {
+					return tc.ack
+				}
+				return fmt.Sprintf(`{"stream":"ETH","seq":%d}`, seq)
+			})
+			s := NewNATSSink(addr, "eth", NATSOptions{Timeout: time.Second})
+			defer s.Close()
+
+			records, _ := Records(7, []eth.Transaction{{Hash: "0xa"}})
+			err := s.Write(context.Background(), records)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), "block-7") {
+				t.Errorf("expected the error to name the record; got %v", err)
+			}
+		})
+	}
+}
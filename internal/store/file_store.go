@@ -0,0 +1,107 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var (
+	ErrLoadStorage = errors.New("load storage error")
+	ErrSaveStorage = errors.New("save storage error")
+)
+
+// NewFileStorage instantiates a storage kept in the file at path,
+// loading it if the file exists
+func NewFileStorage(path string) (Storage, error) {
+	s := &FileStorage{mem: &InMemoryStorage{data: map[string][][]byte{}}, path: path}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w-%v", ErrLoadStorage, err)
+	}
+	if err := json.Unmarshal(b, &s.mem.data); err != nil {
+		return nil, fmt.Errorf("%w-%s: %v", ErrLoadStorage, path, err)
+	}
+	return s, nil
+}
+
+// FileStorage is an in-memory storage rewritten to a file on every
+// change, for small data such as API keys
+type FileStorage struct {
+	mem  *InMemoryStorage
+	path string
+	mu   sync.Mutex // serialises changes with their save
+}
+
+// Append appends a new byte slice to "key" and saves the storage.
+// The change is undone when the save fails.
+func (s *FileStorage) Append(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, err := s.mem.Get(key)
+	s.mem.Append(key, value)
+	if serr := s.save(); serr != nil {
+		s.restore(key, prev, err == nil)
+		return serr
+	}
+	return nil
+}
+
+// Get gets the slice of byte slices for a given key
+func (s *FileStorage) Get(key string) ([][]byte, error) {
+	return s.mem.Get(key)
+}
+
+// Set sets the value of "key" and saves the storage. The change
+// is undone when the save fails.
+func (s *FileStorage) Set(key string, value [][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, err := s.mem.Get(key)
+	s.mem.Set(key, value)
+	if serr := s.save(); serr != nil {
+		s.restore(key, prev, err == nil)
+		return serr
+	}
+	return nil
+}
+
+// Keys returns a list of all keys in the store
+func (s *FileStorage) Keys() []string {
+	return s.mem.Keys()
+}
+
+// restore sets "key" back to its value before a change, removing
+// it if it was not found
+func (s *FileStorage) restore(key string, prev [][]byte, found bool) {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+	if found {
+		s.mem.data[key] = prev
+		return
+	}
+	delete(s.mem.data, key)
+}
+
+// save replaces the file with the storage
+func (s *FileStorage) save() error {
+	s.mem.mu.RLock()
+	b, err := json.Marshal(s.mem.data)
+	s.mem.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("%w-%v", ErrSaveStorage, err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("%w-%v", ErrSaveStorage, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("%w-%v", ErrSaveStorage, err)
+	}
+	return nil
+}
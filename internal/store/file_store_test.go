@@ -0,0 +1,67 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFileStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	s, err := NewFileStorage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Append("abc", []byte("Hello"))
+	s.Append("abc", []byte("eth"))
+	s.Set("def", [][]byte{[]byte("Bye!")})
+
+	// The storage is reloaded from its file
+	reloaded, err := NewFileStorage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Keys(); !slices.Equal(got, []string{"abc", "def"}) {
+		t.Errorf("expected abc and def reloaded; got %v", got)
+	}
+	if v, _ := reloaded.Get("abc"); len(v) != 2 || string(v[1]) != "eth" {
+		t.Errorf("expected the values of abc reloaded; got %q", v)
+	}
+	if _, err := reloaded.Get("foo"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected %v; got %v", ErrKeyNotFound, err)
+	}
+
+	os.WriteFile(path, []byte("not json"), 0o600)
+	if _, err := NewFileStorage(path); !errors.Is(err, ErrLoadStorage) {
+		t.Errorf("expected %v; got %v", ErrLoadStorage, err)
+	}
+}
+
+func TestFileStorageRollback(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+	os.Mkdir(dir, 0o700)
+	s, err := NewFileStorage(filepath.Join(dir, "keys.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Append("abc", []byte("Hello")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Saves fail once the directory is gone
+	os.RemoveAll(dir)
+	if err := s.Append("abc", []byte("eth")); !errors.Is(err, ErrSaveStorage) {
+		t.Errorf("expected %v; got %v", ErrSaveStorage, err)
+	}
+	if err := s.Set("def", [][]byte{[]byte("Bye!")}); !errors.Is(err, ErrSaveStorage) {
+		t.Errorf("expected %v; got %v", ErrSaveStorage, err)
+	}
+	if v, _ := s.Get("abc"); len(v) != 1 || string(v[0]) != "Hello" {
+		t.Errorf("expected abc rolled back; got %q", v)
+	}
+	if _, err := s.Get("def"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected def rolled back; got %v", err)
+	}
+}
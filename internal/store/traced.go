@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("paulwizviz/go-eth-app/internal/store")
+
+// Traced returns a view of s that records each operation as a
+// span of the trace carried by ctx
+func Traced(ctx context.Context, s Storage) Storage {
+	return tracedStorage{ctx: ctx, s: s}
+}
+
+type tracedStorage struct {
+	ctx context.Context
+	s   Storage
+}
+
+func (t tracedStorage) start(op string, attrs ...attribute.KeyValue) trace.Span {
+	_, span := tracer.Start(t.ctx, "store."+op, trace.WithAttributes(attrs...))
+	return span
+}
+
+func end(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t tracedStorage) Append(key string, value []byte) error {
+	span := t.start("Append", attribute.String("store.key", key))
+	err := t.s.Append(key, value)
+	end(span, err)
+	return err
+}
+
+func (t tracedStorage) Get(key string) ([][]byte, error) {
+	span := t.start("Get", attribute.String("store.key", key))
+	v, err := t.s.Get(key)
+	span.SetAttributes(attribute.Int("store.values", len(v)))
+	end(span, err)
+	return v, err
+}
+
+func (t tracedStorage) Set(key string, value [][]byte) error {
+	span := t.start("Set", attribute.String("store.key", key), attribute.Int("store.values", len(value)))
+	err := t.s.Set(key, value)
+	end(span, err)
+	return err
+}
+
+func (t tracedStorage) Keys() []string {
+	span := t.start("Keys")
+	keys := t.s.Keys()
+	span.SetAttributes(attribute.Int("store.keys", len(keys)))
+	span.End()
+	return keys
+}
@@ -0,0 +1,4 @@
+// Package tracing configures OpenTelemetry tracing of the
+// transaction parser pipeline and provides the helpers shared by
+// the instrumented packages
+package tracing
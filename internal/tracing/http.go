@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"net/http"
+	"paulwizviz/go-eth-app/internal/httpstatus"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const httpTracer = "paulwizviz/go-eth-app/internal/http"
+
+// Handler starts a server span for each request served by a
+// ServeMux, continuing any trace propagated by the client. The
+// span is named after the matched route pattern once the mux has
+// routed the request.
+func Handler(next http.Handler) http.Handler {
+	tracer := otel.Tracer(httpTracer)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		ctx, span := tracer.Start(ctx, req.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(req.Method),
+				semconv.URLPath(req.URL.Path),
+			),
+		)
+		defer span.End()
+
+		rec := httpstatus.NewRecorder(w)
+		req = req.WithContext(ctx)
+		next.ServeHTTP(rec, req)
+
+		if req.Pattern != "" {
+			span.SetName(req.Pattern)
+			span.SetAttributes(semconv.HTTPRoute(req.Pattern))
+		}
+		status := rec.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Supported exporters
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// DefaultServiceName is the service name reported with spans
+const DefaultServiceName = "txparser"
+
+var (
+	// ErrUnknownExporter is returned for an exporter other than
+	// none, stdout or otlp
+	ErrUnknownExporter = errors.New("unknown trace exporter")
+	// ErrExporter is returned when the exporter cannot be created
+	ErrExporter = errors.New("create trace exporter")
+)
+
+// Config selects where spans are exported
+type Config struct {
+	// Exporter is one of ExporterNone, ExporterStdout or ExporterOTLP
+	Exporter string
+	// Endpoint is the host:port of the OTLP gRPC collector. If
+	// empty, the OTEL_EXPORTER_OTLP_ENDPOINT variable or the
+	// exporter default is used.
+	Endpoint string
+	// Insecure disables TLS to the OTLP collector
+	Insecure bool
+	// ServiceName defaults to DefaultServiceName
+	ServiceName string
+	// SampleRatio is the fraction of new traces sampled, 0 for all
+	SampleRatio float64
+	// Output receives the stdout exporter spans, os.Stdout if nil
+	Output io.Writer
+}
+
+// Setup installs the global tracer provider and W3C trace context
+// propagator described by cfg. The returned function flushes and
+// stops the exporter.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		out := cfg.Output
+		if out == nil {
+			out = os.Stdout
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(out), stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("%w-%v", ErrUnknownExporter, cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w-%v", ErrExporter, err)
+	}
+
+	name := cfg.ServiceName
+	if name == "" {
+		name = DefaultServiceName
+	}
+	sampler := sdktrace.AlwaysSample()
+	if cfg.SampleRatio > 0 && cfg.SampleRatio < 1 {
+		sampler = sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(name))),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// End records err, if any, on span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Endpoint returns the scheme and host of rawURL so credentials
+// in the path or query of node URLs are not recorded
+func Endpoint(rawURL string) attribute.KeyValue {
+	var endpoint string
+	if u, err := url.Parse(rawURL); err == nil {
+		endpoint = u.Scheme + "://" + u.Host
+	}
+	return attribute.String("rpc.endpoint", endpoint)
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup(t *testing.T) {
+	if _, err := Setup(context.Background(), Config{Exporter: "zipkin"}); !errors.Is(err, ErrUnknownExporter) {
+		t.Errorf("expected ErrUnknownExporter; got %v", err)
+	}
+
+	var out bytes.Buffer
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterStdout, Output: &out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := otel.Tracer("test").Start(context.Background(), "lookup")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(out.Bytes(), []byte(`"Name": "lookup"`)) {
+		t.Errorf("expected the span on stdout; got %s", out.String())
+	}
+}
+
+func TestHandler(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(tp)
+	defer tp.Shutdown(context.Background())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /addresses/{address}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	rec := httptest.NewRecorder()
+	Handler(mux).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/addresses/0xaa", nil))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span; got %d", len(spans))
+	}
+	if spans[0].Name != "GET /addresses/{address}" {
+		t.Errorf("expected span named by route; got %s", spans[0].Name)
+	}
+	want := attribute.Int("http.response.status_code", http.StatusNotFound)
+	found := false
+	for _, a := range spans[0].Attributes {
+		found = found || a == want
+	}
+	if !found {
+		t.Errorf("expected %v in %v", want, spans[0].Attributes)
+	}
+}
@@ -0,0 +1,187 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"paulwizviz/go-eth-app/internal/eth"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Headers set on every webhook request
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderID        = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+)
+
+var (
+	ErrUnexpectedStatus = errors.New("unexpected status")
+)
+
+// reservedAddrs are not routable on the internet, besides those
+// netip reports as private or local
+var reservedAddrs = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// publicAddr reports whether ip may receive webhooks
+func publicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, p := range reservedAddrs {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// publicHost reports whether host, a name or an address, may
+// receive webhooks. Names are checked once resolved, on dial.
+func publicHost(host string) bool {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return publicAddr(ip)
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	return host != "localhost" && !strings.HasSuffix(host, ".localhost")
+}
+
+// newClient returns the client of the deliveries. Unless
+// cfg.AllowPrivate is set it refuses to connect to an address
+// that is not public, whatever the name resolved to, so neither
+// DNS nor redirects reach the internal network.
+func newClient(cfg Config) *http.Client {
+	if cfg.AllowPrivate {
+		return &http.Client{Timeout: cfg.Timeout}
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip, err := netip.ParseAddr(host)
+			if err != nil || !publicAddr(ip) {
+				return fmt.Errorf("%w-%s", ErrPrivateTarget, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the endpoint
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: cfg.Timeout, Transport: transport}
+}
+
+func hmacSHA256(key, body []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// deliver POSTs a transaction to the worker's endpoint, retrying
+// with exponential backoff until it succeeds, the attempts are
+// exhausted or the endpoint is removed.
+func (d *Dispatcher) deliver(ctx context.Context, w *worker, txn []byte) {
+	id, _ := uuid.NewV7()
+	p := Payload{
+		ID:          id.String(),
+		Endpoint:    w.endpoint.ID,
+		Timestamp:   time.Now().UTC(),
+		Transaction: txn,
+	}
+	var t eth.Transaction
+	if err := json.Unmarshal(txn, &t); err == nil {
+		if c, ok := eth.TxCursor(t); ok {
+			p.EventID = c.String()
+		}
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		d.logger.Error("encode webhook payload", "webhook", w.endpoint.ID, "err", err)
+		return
+	}
+
+	dl := Delivery{
+		ID:         p.ID,
+		EndpointID: w.endpoint.ID,
+		EventID:    p.EventID,
+	}
+	backoff := d.cfg.InitialBackoff
+	for {
+		dl.Attempts++
+		dl.StatusCode, err = d.post(ctx, w.endpoint, p, body)
+		dl.Time = time.Now().UTC()
+		if err == nil {
+			dl.Status = StatusDelivered
+			dl.Error = ""
+			w.record(dl, d.cfg.LogSize)
+			return
+		}
+		dl.Error = err.Error()
+
+		if dl.Attempts >= d.cfg.MaxAttempts {
+			d.logger.Warn("webhook delivery failed", "webhook", w.endpoint.ID, "delivery", dl.ID, "attempts", dl.Attempts, "err", err)
+			dl.Status = StatusFailed
+			w.record(dl, d.cfg.LogSize)
+			d.deadLetter(w.endpoint.Owner, dl, body)
+			return
+		}
+		dl.Status = StatusRetrying
+		w.record(dl, d.cfg.LogSize)
+		d.logger.Debug("retrying webhook delivery", "webhook", w.endpoint.ID, "delivery", dl.ID, "attempt", dl.Attempts, "backoff", backoff, "err", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			dl.Status = StatusFailed
+			dl.Error = ctx.Err().Error()
+			w.record(dl, d.cfg.LogSize)
+			return
+		}
+		backoff *= 2
+		if backoff > d.cfg.MaxBackoff {
+			backoff = d.cfg.MaxBackoff
+		}
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, ep Endpoint, p Payload, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, p.ID)
+	req.Header.Set(HeaderEvent, p.EventID)
+	req.Header.Set(HeaderSignature, Sign(ep.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("%w-%d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
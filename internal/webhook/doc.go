@@ -0,0 +1,4 @@
+// Package webhook delivers transactions matching a
+// registered filter to HTTP endpoints. A dispatcher delivers
+// those of one chain, the primary one in the txparser example.
+package webhook
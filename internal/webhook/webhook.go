@@ -0,0 +1,366 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/observer"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Delivery status
+const (
+	StatusDelivered = "delivered"
+	StatusRetrying  = "retrying"
+	StatusFailed    = "failed"
+)
+
+var (
+	ErrEndpointNotFound = errors.New("endpoint not found")
+	ErrInvalidURL       = errors.New("invalid endpoint url")
+	ErrPrivateTarget    = errors.New("private endpoint address")
+	ErrEmptyFilter      = errors.New("endpoint filter requires an address or a condition")
+	ErrGenerateSecret   = errors.New("unable to generate secret")
+	ErrConcurrency      = errors.New("endpoint concurrency above the maximum")
+	ErrTooManyEndpoints = errors.New("too many endpoints")
+)
+
+// Subscriber is the source of transactions. It is
+// satisfied by eth.Parser.
+type Subscriber interface {
+	SubscribeFilter(filter eth.Filter, opts observer.Options) *observer.Subscription
+}
+
+// Config controls delivery behaviour
+type Config struct {
+	// MaxAttempts before a delivery is dead-lettered
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry;
+	// it doubles on every attempt up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Timeout of a single POST
+	Timeout time.Duration
+	// Concurrency is the default number of in-flight
+	// deliveries per endpoint
+	Concurrency int
+	// MaxConcurrency bounds the concurrency an endpoint asks for
+	MaxConcurrency int
+	// MaxEndpoints bounds the endpoints of an owner
+	MaxEndpoints int
+	// Buffer is the number of transactions queued per
+	// endpoint before the oldest are dropped
+	Buffer int
+	// LogSize is the number of deliveries kept per endpoint
+	LogSize int
+	// DeadLetterSize is the number of dead letters kept
+	DeadLetterSize int
+	// AllowPrivate lets endpoints on loopback and private
+	// networks receive webhooks, e.g. in development
+	AllowPrivate bool
+	// Logger receives the delivery logs; nil discards them
+	Logger *slog.Logger
+}
+
+// DefaultConfig is used by NewDefaultDispatcher
+var DefaultConfig = Config{
+	MaxAttempts:    5,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Timeout:        10 * time.Second,
+	Concurrency:    4,
+	MaxConcurrency: 32,
+	MaxEndpoints:   100,
+	Buffer:         1024,
+	LogSize:        100,
+	DeadLetterSize: 1000,
+}
+
+// Endpoint is a registered webhook receiver
+type Endpoint struct {
+	ID string `json:"id"`
+	// Owner is the client that registered the endpoint, the only
+	// one to see and remove it besides an admin
+	Owner       string     `json:"owner,omitempty"`
+	URL         string     `json:"url"`
+	Secret      string     `json:"-"`
+	Filter      eth.Filter `json:"filter"`
+	Concurrency int        `json:"concurrency"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// Payload is the JSON body POSTed to an endpoint
+type Payload struct {
+	ID          string          `json:"id"`
+	Endpoint    string          `json:"endpoint"`
+	EventID     string          `json:"eventId,omitempty"`
+	Timestamp   time.Time       `json:"timestamp"`
+	Transaction json.RawMessage `json:"transaction"`
+}
+
+// Delivery is a log entry of a payload sent to an endpoint
+type Delivery struct {
+	ID         string    `json:"id"`
+	EndpointID string    `json:"endpoint"`
+	EventID    string    `json:"eventId,omitempty"`
+	Status     string    `json:"status"`
+	Attempts   int       `json:"attempts"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// DeadLetter is a payload that could not be delivered
+type DeadLetter struct {
+	Delivery Delivery        `json:"delivery"`
+	Payload  json.RawMessage `json:"payload"`
+	owner    string
+}
+
+// Dispatcher subscribes to transactions for every registered
+// endpoint and delivers them with retries
+type Dispatcher struct {
+	source Subscriber
+	cfg    Config
+	client *http.Client
+	logger *slog.Logger
+
+	mu          sync.RWMutex
+	endpoints   map[string]*worker
+	deadLetters []DeadLetter
+}
+
+type worker struct {
+	endpoint Endpoint
+	sub      *observer.Subscription
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+
+	mu   sync.Mutex
+	logs []Delivery
+}
+
+// NewDefaultDispatcher instantiate a dispatcher with DefaultConfig
+func NewDefaultDispatcher(source Subscriber) *Dispatcher {
+	return NewDispatcher(source, DefaultConfig)
+}
+
+// NewDispatcher instantiate a dispatcher. A zero MaxConcurrency
+// or MaxEndpoints is taken from DefaultConfig.
+func NewDispatcher(source Subscriber, cfg Config) *Dispatcher {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	if cfg.MaxConcurrency <= 0 {
+		cfg.MaxConcurrency = DefaultConfig.MaxConcurrency
+	}
+	if cfg.MaxEndpoints <= 0 {
+		cfg.MaxEndpoints = DefaultConfig.MaxEndpoints
+	}
+	return &Dispatcher{
+		source:    source,
+		cfg:       cfg,
+		client:    newClient(cfg),
+		logger:    logger,
+		endpoints: map[string]*worker{},
+	}
+}
+
+// Register starts delivering to an endpoint. A secret and ID
+// are generated if not set. The filter must not match every
+// transaction, and unless AllowPrivate is set the endpoint must
+// not be on a loopback or private network, which is checked again
+// on every connection. The concurrency and the endpoints of the
+// owner are bounded by MaxConcurrency and MaxEndpoints.
+func (d *Dispatcher) Register(ep Endpoint) (Endpoint, error) {
+	u, err := url.Parse(ep.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return Endpoint{}, fmt.Errorf("%w-%s", ErrInvalidURL, ep.URL)
+	}
+	if !d.cfg.AllowPrivate && !publicHost(u.Hostname()) {
+		return Endpoint{}, fmt.Errorf("%w-%s", ErrPrivateTarget, u.Hostname())
+	}
+	if emptyFilter(ep.Filter) {
+		return Endpoint{}, ErrEmptyFilter
+	}
+	if ep.Secret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return Endpoint{}, fmt.Errorf("%w-%v", ErrGenerateSecret, err)
+		}
+		ep.Secret = hex.EncodeToString(b)
+	}
+	if ep.Concurrency < 1 {
+		ep.Concurrency = d.cfg.Concurrency
+	}
+	if ep.Concurrency > d.cfg.MaxConcurrency {
+		return Endpoint{}, fmt.Errorf("%w-%d above %d", ErrConcurrency, ep.Concurrency, d.cfg.MaxConcurrency)
+	}
+	id, _ := uuid.NewV7()
+	ep.ID = id.String()
+	ep.CreatedAt = time.Now().UTC()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &worker{
+		endpoint: ep,
+		sub: d.source.SubscribeFilter(ep.Filter, observer.Options{
+			Buffer: d.cfg.Buffer,
+			Policy: observer.PolicyDropOldest,
+		}),
+		cancel: cancel,
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	owned := 0
+	for _, o := range d.endpoints {
+		if o.endpoint.Owner == ep.Owner {
+			owned++
+		}
+	}
+	if owned >= d.cfg.MaxEndpoints {
+		w.stop()
+		return Endpoint{}, fmt.Errorf("%w-%d registered", ErrTooManyEndpoints, owned)
+	}
+	for range ep.Concurrency {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			for msg := range w.sub.Ch {
+				d.deliver(ctx, w, msg)
+			}
+		}()
+	}
+	d.endpoints[ep.ID] = w
+
+	return ep, nil
+}
+
+// Remove stops delivering to an endpoint of owner, any if owner
+// is empty. In-flight retries are abandoned.
+func (d *Dispatcher) Remove(owner, id string) error {
+	d.mu.Lock()
+	w, found := d.endpoints[id]
+	found = found && owns(owner, w.endpoint.Owner)
+	if found {
+		delete(d.endpoints, id)
+	}
+	d.mu.Unlock()
+	if !found {
+		return ErrEndpointNotFound
+	}
+	w.stop()
+	return nil
+}
+
+// Close removes every endpoint
+func (d *Dispatcher) Close() {
+	d.mu.Lock()
+	workers := d.endpoints
+	d.endpoints = map[string]*worker{}
+	d.mu.Unlock()
+	for _, w := range workers {
+		w.stop()
+	}
+}
+
+func (w *worker) stop() {
+	w.cancel()
+	w.sub.Unsubscribe()
+	w.wg.Wait()
+}
+
+// Endpoints returns the endpoints of owner, all if owner is
+// empty, ordered by registration time
+func (d *Dispatcher) Endpoints(owner string) []Endpoint {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	eps := []Endpoint{}
+	for _, w := range d.endpoints {
+		if owns(owner, w.endpoint.Owner) {
+			eps = append(eps, w.endpoint)
+		}
+	}
+	sort.Slice(eps, func(i, j int) bool {
+		return eps[i].ID < eps[j].ID
+	})
+	return eps
+}
+
+// Deliveries returns the most recent deliveries to an endpoint
+// of owner, any if owner is empty, oldest first
+func (d *Dispatcher) Deliveries(owner, id string) ([]Delivery, error) {
+	d.mu.RLock()
+	w, found := d.endpoints[id]
+	d.mu.RUnlock()
+	if !found || !owns(owner, w.endpoint.Owner) {
+		return nil, ErrEndpointNotFound
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	logs := make([]Delivery, len(w.logs))
+	copy(logs, w.logs)
+	return logs, nil
+}
+
+// DeadLetters returns the payloads to the endpoints of owner,
+// all if owner is empty, that exhausted their attempts, oldest
+// first
+func (d *Dispatcher) DeadLetters(owner string) []DeadLetter {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	dls := []DeadLetter{}
+	for _, dl := range d.deadLetters {
+		if owns(owner, dl.owner) {
+			dls = append(dls, dl)
+		}
+	}
+	return dls
+}
+
+// owns reports whether owner, empty for any, owns an endpoint of
+// endpointOwner
+func owns(owner, endpointOwner string) bool {
+	return owner == "" || owner == endpointOwner
+}
+
+// emptyFilter reports whether f matches every transaction
+func emptyFilter(f eth.Filter) bool {
+	return len(f.Addresses) == 0 && f.MinValue == nil && f.Selector == "" && !f.ContractCreation && f.Type == ""
+}
+
+// Sign returns the value of the X-Webhook-Signature header
+// for a body
+func Sign(secret string, body []byte) string {
+	return "sha256=" + hex.EncodeToString(hmacSHA256([]byte(secret), body))
+}
+
+func (w *worker) record(dl Delivery, size int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.logs = append(w.logs, dl)
+	if len(w.logs) > size {
+		w.logs = w.logs[len(w.logs)-size:]
+	}
+}
+
+func (d *Dispatcher) deadLetter(owner string, dl Delivery, body []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deadLetters = append(d.deadLetters, DeadLetter{Delivery: dl, Payload: body, owner: owner})
+	if len(d.deadLetters) > d.cfg.DeadLetterSize {
+		d.deadLetters = d.deadLetters[len(d.deadLetters)-d.cfg.DeadLetterSize:]
+	}
+}
@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"paulwizviz/go-eth-app/internal/eth"
+	"paulwizviz/go-eth-app/internal/observer"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testSource struct {
+	o *observer.Observer
+}
+
+func (s testSource) SubscribeFilter(filter eth.Filter, opts observer.Options) *observer.Subscription {
+	return s.o.SubscribeTopics([]string{observer.Wildcard}, nil, opts)
+}
+
+var testFilter = eth.Filter{Addresses: []string{"0xa"}}
+
+func testConfig() Config {
+	cfg := DefaultConfig
+	cfg.InitialBackoff = time.Millisecond
+	cfg.MaxBackoff = 4 * time.Millisecond
+	cfg.MaxAttempts = 3
+	// The receivers listen on loopback
+	cfg.AllowPrivate = true
+	return cfg
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDeliverySignedWithRetry(t *testing.T) {
+	var calls atomic.Int32
+	received := make(chan Payload, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(HeaderSignature) != Sign("s3cret", body) {
+			t.Errorf("invalid signature %s", r.Header.Get(HeaderSignature))
+		}
+		var p Payload
+		json.Unmarshal(body, &p)
+		received <- p
+	}))
+	defer receiver.Close()
+
+	o := observer.New()
+	d := NewDispatcher(testSource{o}, testConfig())
+	defer d.Close()
+	ep, err := d.Register(Endpoint{URL: receiver.URL, Secret: "s3cret", Filter: testFilter})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txn, _ := json.Marshal(eth.Transaction{Hash: "0x1", Block: "0x2", TransactionIndex: "0x3"})
+	o.Publish([]string{"0xa"}, txn)
+
+	select {
+	case p := <-received:
+		if p.EventID != "2-3" || p.Endpoint != ep.ID {
+			t.Errorf("unexpected payload %+v", p)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("payload not received")
+	}
+
+	waitFor(t, func() bool {
+		logs, _ := d.Deliveries("", ep.ID)
+		return len(logs) == 2
+	})
+	logs, _ := d.Deliveries("", ep.ID)
+	if logs[0].Status != StatusRetrying || logs[0].StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected first attempt to be retried; got %+v", logs[0])
+	}
+	if logs[1].Status != StatusDelivered || logs[1].Attempts != 2 {
+		t.Errorf("expected delivery on second attempt; got %+v", logs[1])
+	}
+}
+
+func TestDeliveryDeadLetter(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	o := observer.New()
+	d := NewDispatcher(testSource{o}, testConfig())
+	defer d.Close()
+	if _, err := d.Register(Endpoint{URL: receiver.URL, Owner: "alice", Filter: testFilter}); err != nil {
+		t.Fatal(err)
+	}
+
+	o.Publish([]string{"0xa"}, []byte(`{"hash":"0x1"}`))
+
+	waitFor(t, func() bool { return len(d.DeadLetters("alice")) == 1 })
+	if dls := d.DeadLetters("bob"); len(dls) != 0 {
+		t.Errorf("expected no dead letter of bob; got %+v", dls)
+	}
+	dl := d.DeadLetters("")[0]
+	if dl.Delivery.Status != StatusFailed || dl.Delivery.Attempts != 3 {
+		t.Errorf("unexpected dead letter %+v", dl.Delivery)
+	}
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	d := NewDefaultDispatcher(testSource{observer.New()})
+	defer d.Close()
+	testcases := []struct {
+		ep   Endpoint
+		want error
+	}{
+		{Endpoint{URL: "ftp://example.com", Filter: testFilter}, ErrInvalidURL},
+		{Endpoint{URL: "https://example.com"}, ErrEmptyFilter},
+		{Endpoint{URL: "http://127.0.0.1:8080", Filter: testFilter}, ErrPrivateTarget},
+		{Endpoint{URL: "http://[::1]/hook", Filter: testFilter}, ErrPrivateTarget},
+		{Endpoint{URL: "http://10.0.0.8/hook", Filter: testFilter}, ErrPrivateTarget},
+		{Endpoint{URL: "http://169.254.169.254/latest", Filter: testFilter}, ErrPrivateTarget},
+		{Endpoint{URL: "http://localhost:9000", Filter: testFilter}, ErrPrivateTarget},
+		{Endpoint{URL: "https://example.com", Filter: testFilter, Concurrency: 100000000}, ErrConcurrency},
+	}
+	for _, tc := range testcases {
+		if _, err := d.Register(tc.ep); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v; got %v", tc.ep.URL, tc.want, err)
+		}
+	}
+	if err := d.Remove("", "missing"); err != ErrEndpointNotFound {
+		t.Errorf("expected ErrEndpointNotFound; got %v", err)
+	}
+}
+
+func TestMaxEndpoints(t *testing.T) {
+	cfg := testConfig()
+	cfg.MaxEndpoints = 2
+	d := NewDispatcher(testSource{observer.New()}, cfg)
+	defer d.Close()
+	for range 2 {
+		if _, err := d.Register(Endpoint{URL: "http://127.0.0.1:1/a", Owner: "alice", Filter: testFilter}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := d.Register(Endpoint{URL: "http://127.0.0.1:1/a", Owner: "alice", Filter: testFilter}); !errors.Is(err, ErrTooManyEndpoints) {
+		t.Errorf("expected %v; got %v", ErrTooManyEndpoints, err)
+	}
+	// The cap is per owner
+	if _, err := d.Register(Endpoint{URL: "http://127.0.0.1:1/b", Owner: "bob", Filter: testFilter}); err != nil {
+		t.Errorf("expected bob to register; got %v", err)
+	}
+	if got := len(d.Endpoints("alice")); got != 2 {
+		t.Errorf("expected 2 endpoints of alice; got %d", got)
+	}
+}
+
+func TestDialPrivateTarget(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer receiver.Close()
+
+	// A name resolving to a private address is only caught on dial
+	client := newClient(DefaultConfig)
+	_, err := client.Get(strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1))
+	if !errors.Is(err, ErrPrivateTarget) {
+		t.Errorf("expected %v; got %v", ErrPrivateTarget, err)
+	}
+}
+
+func TestEndpointOwners(t *testing.T) {
+	d := NewDispatcher(testSource{observer.New()}, testConfig())
+	defer d.Close()
+	alice, _ := d.Register(Endpoint{URL: "http://127.0.0.1:1/a", Owner: "alice", Filter: testFilter})
+	bob, _ := d.Register(Endpoint{URL: "http://127.0.0.1:1/b", Owner: "bob", Filter: testFilter})
+
+	if eps := d.Endpoints("alice"); len(eps) != 1 || eps[0].ID != alice.ID {
+		t.Errorf("expected the endpoint of alice only; got %+v", eps)
+	}
+	if eps := d.Endpoints(""); len(eps) != 2 {
+		t.Errorf("expected every endpoint; got %+v", eps)
+	}
+	if _, err := d.Deliveries("alice", bob.ID); err != ErrEndpointNotFound {
+		t.Errorf("expected the deliveries of bob hidden from alice; got %v", err)
+	}
+	if err := d.Remove("alice", bob.ID); err != ErrEndpointNotFound {
+		t.Errorf("expected alice not to remove the endpoint of bob; got %v", err)
+	}
+	if err := d.Remove("bob", bob.ID); err != nil {
+		t.Error(err)
+	}
+}
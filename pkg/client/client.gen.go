@@ -0,0 +1,8020 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	AccessTokenScopes = "AccessToken.Scopes"
+	ApiKeyAuthScopes  = "ApiKeyAuth.Scopes"
+	BearerAuthScopes  = "BearerAuth.Scopes"
+)
+
+// Defines values for AddressStatsInterval.
+const (
+	AddressStatsIntervalDay  AddressStatsInterval = "day"
+	AddressStatsIntervalHour AddressStatsInterval = "hour"
+)
+
+// Defines values for DeliveryStatus.
+const (
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+	DeliveryStatusRetrying  DeliveryStatus = "retrying"
+)
+
+// Defines values for ErrorResponseErrorCode.
+const (
+	ErrorResponseErrorCodeBackfillRunning      ErrorResponseErrorCode = "backfill_running"
+	ErrorResponseErrorCodeCursorExpired        ErrorResponseErrorCode = "cursor_expired"
+	ErrorResponseErrorCodeForbidden            ErrorResponseErrorCode = "forbidden"
+	ErrorResponseErrorCodeInternalError        ErrorResponseErrorCode = "internal_error"
+	ErrorResponseErrorCodeInvalidAddress       ErrorResponseErrorCode = "invalid_address"
+	ErrorResponseErrorCodeInvalidCursor        ErrorResponseErrorCode = "invalid_cursor"
+	ErrorResponseErrorCodeInvalidFilter        ErrorResponseErrorCode = "invalid_filter"
+	ErrorResponseErrorCodeInvalidRequest       ErrorResponseErrorCode = "invalid_request"
+	ErrorResponseErrorCodeNotFound             ErrorResponseErrorCode = "not_found"
+	ErrorResponseErrorCodeRateLimited          ErrorResponseErrorCode = "rate_limited"
+	ErrorResponseErrorCodeSyncing              ErrorResponseErrorCode = "syncing"
+	ErrorResponseErrorCodeTooManySubscriptions ErrorResponseErrorCode = "too_many_subscriptions"
+	ErrorResponseErrorCodeUnauthenticated      ErrorResponseErrorCode = "unauthenticated"
+)
+
+// Defines values for IndexingProgressState.
+const (
+	IndexingProgressStateDone    IndexingProgressState = "done"
+	IndexingProgressStateFailed  IndexingProgressState = "failed"
+	IndexingProgressStateRunning IndexingProgressState = "running"
+)
+
+// Defines values for IssueKeyRequestRole.
+const (
+	IssueKeyRequestRoleAdmin IssueKeyRequestRole = "admin"
+	IssueKeyRequestRoleUser  IssueKeyRequestRole = "user"
+)
+
+// Defines values for IssuedKeyRole.
+const (
+	IssuedKeyRoleAdmin IssuedKeyRole = "admin"
+	IssuedKeyRoleUser  IssuedKeyRole = "user"
+)
+
+// Defines values for KeyRole.
+const (
+	Admin KeyRole = "admin"
+	User  KeyRole = "user"
+)
+
+// Defines values for PendingTransactionStatus.
+const (
+	Dropped  PendingTransactionStatus = "dropped"
+	Mined    PendingTransactionStatus = "mined"
+	Pending  PendingTransactionStatus = "pending"
+	Replaced PendingTransactionStatus = "replaced"
+)
+
+// Defines values for TokenTransferStandard.
+const (
+	Erc1155 TokenTransferStandard = "erc1155"
+	Erc20   TokenTransferStandard = "erc20"
+	Erc721  TokenTransferStandard = "erc721"
+)
+
+// Defines values for GetAddressStatsParamsInterval.
+const (
+	GetAddressStatsParamsIntervalDay  GetAddressStatsParamsInterval = "day"
+	GetAddressStatsParamsIntervalHour GetAddressStatsParamsInterval = "hour"
+)
+
+// Defines values for GetChainAddressStatsParamsInterval.
+const (
+	Day  GetChainAddressStatsParamsInterval = "day"
+	Hour GetChainAddressStatsParamsInterval = "hour"
+)
+
+// ActiveAddress defines model for ActiveAddress.
+type ActiveAddress struct {
+	Address string `json:"address"`
+
+	// Error Most the transactions are overestimated by
+	Error        int64 `json:"error"`
+	Transactions int64 `json:"transactions"`
+}
+
+// ActivityBucket defines model for ActivityBucket.
+type ActivityBucket struct {
+	In           string    `json:"in"`
+	Out          string    `json:"out"`
+	Start        time.Time `json:"start"`
+	Transactions int64     `json:"transactions"`
+}
+
+// AddWatchlistRequest defines model for AddWatchlistRequest.
+type AddWatchlistRequest struct {
+	Addresses []string `json:"addresses"`
+
+	// Backfill Inclusive block range
+	Backfill *BackfillRange `json:"backfill,omitempty"`
+}
+
+// Address defines model for Address.
+type Address struct {
+	// Address EIP-55 checksum address
+	Address      string `json:"address"`
+	Count        int64  `json:"count"`
+	Transactions string `json:"transactions"`
+}
+
+// AddressStats Amounts are hex quantities in wei
+type AddressStats struct {
+	Activity []ActivityBucket `json:"activity"`
+	Address  string           `json:"address"`
+
+	// Counterparties Counterparties with the most transactions. Past 256, the one
+	// with the fewest transactions makes room for a new one.
+	Counterparties []Counterparty `json:"counterparties"`
+
+	// Fees Fees of the transactions sent, when receipts are fetched
+	Fees       string `json:"fees"`
+	FirstBlock int64  `json:"firstBlock"`
+
+	// In Value received, with internal transfers when blocks are traced
+	In        string               `json:"in"`
+	Interval  AddressStatsInterval `json:"interval"`
+	LastBlock int64                `json:"lastBlock"`
+
+	// Net In minus out, with a leading minus when negative
+	Net string `json:"net"`
+
+	// Out Value sent, with internal transfers when blocks are traced
+	Out string `json:"out"`
+
+	// Recent Transactions in the last hour, day and week, by block time
+	Recent       RecentActivity `json:"recent"`
+	Transactions int64          `json:"transactions"`
+
+	// UniqueCounterparties Approximate number of addresses transacted with
+	UniqueCounterparties int64 `json:"uniqueCounterparties"`
+}
+
+// AddressStatsInterval defines model for AddressStats.Interval.
+type AddressStatsInterval string
+
+// AddressesResponse defines model for AddressesResponse.
+type AddressesResponse struct {
+	Addresses []Address `json:"addresses"`
+}
+
+// BackfillRange Inclusive block range
+type BackfillRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// Chain defines model for Chain.
+type Chain struct {
+	Addresses string `json:"addresses"`
+
+	// Block Latest parsed block number, -1 while syncing
+	Block   string `json:"block"`
+	ChainId int64  `json:"chainId"`
+	Syncing bool   `json:"syncing"`
+}
+
+// ChainsResponse defines model for ChainsResponse.
+type ChainsResponse struct {
+	Chains []Chain `json:"chains"`
+}
+
+// Counterparty defines model for Counterparty.
+type Counterparty struct {
+	Address string `json:"address"`
+
+	// In Value received from the counterparty
+	In string `json:"in"`
+
+	// Out Value sent to the counterparty
+	Out          string `json:"out"`
+	Transactions int64  `json:"transactions"`
+}
+
+// DeadLetter defines model for DeadLetter.
+type DeadLetter struct {
+	Delivery Delivery               `json:"delivery"`
+	Payload  map[string]interface{} `json:"payload"`
+}
+
+// DeadLettersResponse defines model for DeadLettersResponse.
+type DeadLettersResponse struct {
+	DeadLetters []DeadLetter `json:"deadLetters"`
+}
+
+// DeliveriesResponse defines model for DeliveriesResponse.
+type DeliveriesResponse struct {
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+// Delivery defines model for Delivery.
+type Delivery struct {
+	Attempts   int            `json:"attempts"`
+	Endpoint   string         `json:"endpoint"`
+	Error      *string        `json:"error,omitempty"`
+	EventId    *string        `json:"eventId,omitempty"`
+	Id         string         `json:"id"`
+	Status     DeliveryStatus `json:"status"`
+	StatusCode *int           `json:"statusCode,omitempty"`
+	Time       time.Time      `json:"time"`
+}
+
+// DeliveryStatus defines model for Delivery.Status.
+type DeliveryStatus string
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error struct {
+		Code    ErrorResponseErrorCode `json:"code"`
+		Message string                 `json:"message"`
+	} `json:"error"`
+}
+
+// ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
+type ErrorResponseErrorCode string
+
+// Filter defines model for Filter.
+type Filter struct {
+	Addresses        *[]string `json:"addresses,omitempty"`
+	ContractCreation *bool     `json:"contractCreation,omitempty"`
+	MinValue         *float32  `json:"minValue,omitempty"`
+	Selector         *string   `json:"selector,omitempty"`
+	Type             *string   `json:"type,omitempty"`
+}
+
+// FilterSpec defines model for FilterSpec.
+type FilterSpec struct {
+	Addresses *[]string `json:"addresses,omitempty"`
+	Creation  *bool     `json:"creation,omitempty"`
+	MinValue  *string   `json:"minValue,omitempty"`
+	Selector  *string   `json:"selector,omitempty"`
+	Type      *string   `json:"type,omitempty"`
+}
+
+// IndexingProgress defines model for IndexingProgress.
+type IndexingProgress struct {
+	Error      *string `json:"error,omitempty"`
+	EtaSeconds int64   `json:"etaSeconds"`
+	From       int64   `json:"from"`
+	Indexed    int64   `json:"indexed"`
+
+	// Next Lowest block not yet indexed; the job resumes from it
+	Next    int64   `json:"next"`
+	Percent float32 `json:"percent"`
+
+	// Rate Blocks per second since the job started
+	Rate float32 `json:"rate"`
+
+	// Skipped Blocks already indexed by ingestion
+	Skipped   int64                 `json:"skipped"`
+	StartedAt time.Time             `json:"startedAt"`
+	State     IndexingProgressState `json:"state"`
+	To        int64                 `json:"to"`
+	Total     int64                 `json:"total"`
+	UpdatedAt time.Time             `json:"updatedAt"`
+}
+
+// IndexingProgressState defines model for IndexingProgress.State.
+type IndexingProgressState string
+
+// InternalTransfer defines model for InternalTransfer.
+type InternalTransfer struct {
+	BlockNumber string `json:"blockNumber"`
+
+	// Depth One for a call made by the transaction's recipient
+	Depth int     `json:"depth"`
+	Error *string `json:"error,omitempty"`
+
+	// Failed The call or one it was made from reverted
+	Failed          *bool  `json:"failed,omitempty"`
+	From            string `json:"from"`
+	Index           int    `json:"index"`
+	To              string `json:"to"`
+	TransactionHash string `json:"transactionHash"`
+
+	// Type Call type, e.g. CALL, CREATE or SELFDESTRUCT
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// InternalTransfersResponse defines model for InternalTransfersResponse.
+type InternalTransfersResponse struct {
+	Address   string             `json:"address"`
+	Transfers []InternalTransfer `json:"transfers"`
+}
+
+// IssueKeyRequest defines model for IssueKeyRequest.
+type IssueKeyRequest struct {
+	// Limits Quotas of a client; unset or zero values are unlimited
+	Limits *Limits              `json:"limits,omitempty"`
+	Name   string               `json:"name"`
+	Role   *IssueKeyRequestRole `json:"role,omitempty"`
+}
+
+// IssueKeyRequestRole defines model for IssueKeyRequest.Role.
+type IssueKeyRequestRole string
+
+// IssuedKey defines model for IssuedKey.
+type IssuedKey struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+
+	// Limits Quotas of a client; unset or zero values are unlimited
+	Limits    Limits        `json:"limits"`
+	Name      string        `json:"name"`
+	RevokedAt *time.Time    `json:"revokedAt,omitempty"`
+	Role      IssuedKeyRole `json:"role"`
+
+	// Secret Key secret; it is not retrievable afterwards
+	Secret string `json:"secret"`
+}
+
+// IssuedKeyRole defines model for IssuedKey.Role.
+type IssuedKeyRole string
+
+// Key defines model for Key.
+type Key struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+
+	// Limits Quotas of a client; unset or zero values are unlimited
+	Limits    Limits     `json:"limits"`
+	Name      string     `json:"name"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+	Role      KeyRole    `json:"role"`
+}
+
+// KeyRole defines model for Key.Role.
+type KeyRole string
+
+// KeysResponse defines model for KeysResponse.
+type KeysResponse struct {
+	Keys []Key `json:"keys"`
+}
+
+// Limits Quotas of a client; unset or zero values are unlimited
+type Limits struct {
+	// Burst Requests allowed above the rate
+	Burst *int `json:"burst,omitempty"`
+
+	// MaxSubscriptions Concurrent SSE and WebSocket subscriptions
+	MaxSubscriptions *int `json:"maxSubscriptions,omitempty"`
+
+	// RateLimit Sustained requests per second
+	RateLimit *float32 `json:"rateLimit,omitempty"`
+}
+
+// Log defines model for Log.
+type Log struct {
+	Address  string   `json:"address"`
+	Data     string   `json:"data"`
+	LogIndex string   `json:"logIndex"`
+	Topics   []string `json:"topics"`
+}
+
+// PendingResponse defines model for PendingResponse.
+type PendingResponse struct {
+	Address      string               `json:"address"`
+	Transactions []PendingTransaction `json:"transactions"`
+}
+
+// PendingTransaction defines model for PendingTransaction.
+type PendingTransaction struct {
+	BlockHash   *string `json:"blockHash,omitempty"`
+	BlockNumber *string `json:"blockNumber,omitempty"`
+	From        *string `json:"from,omitempty"`
+	Gas         *string `json:"gas,omitempty"`
+	GasPrice    *string `json:"gasPrice,omitempty"`
+	Hash        *string `json:"hash,omitempty"`
+	Input       *string `json:"input,omitempty"`
+
+	// Internal Set when the parser traces blocks
+	Internal             *[]InternalTransfer `json:"internal,omitempty"`
+	MaxFeePerGas         *string             `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *string             `json:"maxPriorityFeePerGas,omitempty"`
+	Nonce                *string             `json:"nonce,omitempty"`
+
+	// Receipt Set when the parser fetches receipts
+	Receipt *Receipt `json:"receipt,omitempty"`
+
+	// ReplacedBy Hash of the mined transaction that used its nonce
+	ReplacedBy       *string                  `json:"replacedBy,omitempty"`
+	SeenAt           time.Time                `json:"seenAt"`
+	Status           PendingTransactionStatus `json:"status"`
+	To               *string                  `json:"to,omitempty"`
+	TransactionIndex *string                  `json:"transactionIndex,omitempty"`
+	Type             *string                  `json:"type,omitempty"`
+	Value            *string                  `json:"value,omitempty"`
+}
+
+// PendingTransactionStatus defines model for PendingTransaction.Status.
+type PendingTransactionStatus string
+
+// Receipt Set when the parser fetches receipts
+type Receipt struct {
+	// ContractAddress Contract deployed by a creation
+	ContractAddress   *string `json:"contractAddress,omitempty"`
+	EffectiveGasPrice string  `json:"effectiveGasPrice"`
+
+	// Fee gasUsed times effectiveGasPrice, in wei
+	Fee     string `json:"fee"`
+	GasUsed string `json:"gasUsed"`
+	Logs    []Log  `json:"logs"`
+
+	// Status 0x1 on success, 0x0 on failure
+	Status string `json:"status"`
+}
+
+// RecentActivity Transactions in the last hour, day and week, by block time
+type RecentActivity struct {
+	LastDay  int64 `json:"lastDay"`
+	LastHour int64 `json:"lastHour"`
+	LastWeek int64 `json:"lastWeek"`
+}
+
+// RegisterWebhookRequest defines model for RegisterWebhookRequest.
+type RegisterWebhookRequest struct {
+	Address *string `json:"address,omitempty"`
+
+	// Concurrency In-flight deliveries, 4 by default. A value above the
+	// maximum of the server, 32 by default, is refused.
+	Concurrency *int        `json:"concurrency,omitempty"`
+	Filter      *FilterSpec `json:"filter,omitempty"`
+
+	// Secret HMAC-SHA256 key; generated if omitted
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// RegisteredWebhook defines model for RegisteredWebhook.
+type RegisteredWebhook struct {
+	Concurrency int       `json:"concurrency"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Filter      Filter    `json:"filter"`
+	Id          string    `json:"id"`
+
+	// Owner ID of the client that registered the webhook
+	Owner  *string `json:"owner,omitempty"`
+	Secret string  `json:"secret"`
+	Url    string  `json:"url"`
+}
+
+// StatusResponse defines model for StatusResponse.
+type StatusResponse struct {
+	Addresses string `json:"addresses"`
+
+	// Block Latest parsed block number, -1 while syncing
+	Block   string `json:"block"`
+	Syncing bool   `json:"syncing"`
+}
+
+// TokenTransfer defines model for TokenTransfer.
+type TokenTransfer struct {
+	// Amount Units moved, 0x1 for ERC-721
+	Amount string `json:"amount"`
+
+	// BatchIndex Position in an ERC-1155 batch
+	BatchIndex  *int                  `json:"batchIndex,omitempty"`
+	BlockNumber string                `json:"blockNumber"`
+	Decimals    *int                  `json:"decimals,omitempty"`
+	From        string                `json:"from"`
+	LogIndex    string                `json:"logIndex"`
+	Name        *string               `json:"name,omitempty"`
+	Standard    TokenTransferStandard `json:"standard"`
+	Symbol      *string               `json:"symbol,omitempty"`
+	To          string                `json:"to"`
+
+	// Token Token contract
+	Token string `json:"token"`
+
+	// TokenId Set for ERC-721 and ERC-1155
+	TokenId         *string `json:"tokenId,omitempty"`
+	TransactionHash string  `json:"transactionHash"`
+}
+
+// TokenTransferStandard defines model for TokenTransfer.Standard.
+type TokenTransferStandard string
+
+// TokenTransfersResponse defines model for TokenTransfersResponse.
+type TokenTransfersResponse struct {
+	Address   string          `json:"address"`
+	Transfers []TokenTransfer `json:"transfers"`
+}
+
+// TopAddressesResponse defines model for TopAddressesResponse.
+type TopAddressesResponse struct {
+	Addresses []ActiveAddress `json:"addresses"`
+}
+
+// Transaction defines model for Transaction.
+type Transaction struct {
+	BlockHash   *string `json:"blockHash,omitempty"`
+	BlockNumber *string `json:"blockNumber,omitempty"`
+	From        *string `json:"from,omitempty"`
+	Gas         *string `json:"gas,omitempty"`
+	GasPrice    *string `json:"gasPrice,omitempty"`
+	Hash        *string `json:"hash,omitempty"`
+	Input       *string `json:"input,omitempty"`
+
+	// Internal Set when the parser traces blocks
+	Internal             *[]InternalTransfer `json:"internal,omitempty"`
+	MaxFeePerGas         *string             `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *string             `json:"maxPriorityFeePerGas,omitempty"`
+	Nonce                *string             `json:"nonce,omitempty"`
+
+	// Receipt Set when the parser fetches receipts
+	Receipt          *Receipt `json:"receipt,omitempty"`
+	To               *string  `json:"to,omitempty"`
+	TransactionIndex *string  `json:"transactionIndex,omitempty"`
+	Type             *string  `json:"type,omitempty"`
+	Value            *string  `json:"value,omitempty"`
+}
+
+// TransactionsResponse defines model for TransactionsResponse.
+type TransactionsResponse struct {
+	Address string `json:"address"`
+	Count   int64  `json:"count"`
+	Links   struct {
+		Addresses string `json:"addresses"`
+		Subscribe string `json:"subscribe"`
+	} `json:"links"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+// WatchlistAdded defines model for WatchlistAdded.
+type WatchlistAdded struct {
+	// Added Addresses not already watched
+	Added []string `json:"added"`
+
+	// Backfill Inclusive block range
+	Backfill *BackfillRange `json:"backfill,omitempty"`
+}
+
+// WatchlistResponse defines model for WatchlistResponse.
+type WatchlistResponse struct {
+	Addresses []string `json:"addresses"`
+	Count     int      `json:"count"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	Concurrency int       `json:"concurrency"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Filter      Filter    `json:"filter"`
+	Id          string    `json:"id"`
+
+	// Owner ID of the client that registered the webhook
+	Owner *string `json:"owner,omitempty"`
+	Url   string  `json:"url"`
+}
+
+// WebhooksResponse defines model for WebhooksResponse.
+type WebhooksResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// AddressPath defines model for AddressPath.
+type AddressPath = string
+
+// ChainID defines model for ChainID.
+type ChainID = int64
+
+// FilterAddress defines model for FilterAddress.
+type FilterAddress = []string
+
+// FilterCreation defines model for FilterCreation.
+type FilterCreation = bool
+
+// FilterMinValue defines model for FilterMinValue.
+type FilterMinValue = string
+
+// FilterSelector defines model for FilterSelector.
+type FilterSelector = string
+
+// FilterType defines model for FilterType.
+type FilterType = string
+
+// KeyID defines model for KeyID.
+type KeyID = string
+
+// LastEventID defines model for LastEventID.
+type LastEventID = string
+
+// Since defines model for Since.
+type Since = string
+
+// TopLimit defines model for TopLimit.
+type TopLimit = int
+
+// WebhookID defines model for WebhookID.
+type WebhookID = string
+
+// BadRequest defines model for BadRequest.
+type BadRequest = ErrorResponse
+
+// Conflict defines model for Conflict.
+type Conflict = ErrorResponse
+
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
+// Gone defines model for Gone.
+type Gone = ErrorResponse
+
+// NotFound defines model for NotFound.
+type NotFound = ErrorResponse
+
+// Syncing defines model for Syncing.
+type Syncing = ErrorResponse
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = ErrorResponse
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = ErrorResponse
+
+// GetTopAddressesParams defines parameters for GetTopAddresses.
+type GetTopAddressesParams struct {
+	// Limit Number of addresses
+	Limit *TopLimit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetAddressStatsParams defines parameters for GetAddressStats.
+type GetAddressStatsParams struct {
+	// Interval Buckets the activity by hour, over a week, or by day, over a year
+	Interval *GetAddressStatsParamsInterval `form:"interval,omitempty" json:"interval,omitempty"`
+
+	// Top Number of counterparties
+	Top *int `form:"top,omitempty" json:"top,omitempty"`
+}
+
+// GetAddressStatsParamsInterval defines parameters for GetAddressStats.
+type GetAddressStatsParamsInterval string
+
+// SubscribeAddressParams defines parameters for SubscribeAddress.
+type SubscribeAddressParams struct {
+	// Since Event ID or block number to replay from, inclusive
+	Since *Since `form:"since,omitempty" json:"since,omitempty"`
+
+	// LastEventID Event ID to replay after
+	LastEventID *LastEventID `json:"Last-Event-ID,omitempty"`
+}
+
+// GetChainTopAddressesParams defines parameters for GetChainTopAddresses.
+type GetChainTopAddressesParams struct {
+	// Limit Number of addresses
+	Limit *TopLimit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetChainAddressStatsParams defines parameters for GetChainAddressStats.
+type GetChainAddressStatsParams struct {
+	// Interval Buckets the activity by hour, over a week, or by day, over a year
+	Interval *GetChainAddressStatsParamsInterval `form:"interval,omitempty" json:"interval,omitempty"`
+
+	// Top Number of counterparties
+	Top *int `form:"top,omitempty" json:"top,omitempty"`
+}
+
+// GetChainAddressStatsParamsInterval defines parameters for GetChainAddressStats.
+type GetChainAddressStatsParamsInterval string
+
+// SubscribeChainAddressParams defines parameters for SubscribeChainAddress.
+type SubscribeChainAddressParams struct {
+	// Since Event ID or block number to replay from, inclusive
+	Since *Since `form:"since,omitempty" json:"since,omitempty"`
+
+	// LastEventID Event ID to replay after
+	LastEventID *LastEventID `json:"Last-Event-ID,omitempty"`
+}
+
+// SubscribeChainFilterParams defines parameters for SubscribeChainFilter.
+type SubscribeChainFilterParams struct {
+	// Address Sender or recipient; repeatable or comma separated
+	Address *FilterAddress `form:"address,omitempty" json:"address,omitempty"`
+
+	// MinValue Matches values above it, in wei, decimal or 0x hex
+	MinValue *FilterMinValue `form:"minValue,omitempty" json:"minValue,omitempty"`
+
+	// Selector 4-byte function selector
+	Selector *FilterSelector `form:"selector,omitempty" json:"selector,omitempty"`
+
+	// Creation Match contract creations only
+	Creation *FilterCreation `form:"creation,omitempty" json:"creation,omitempty"`
+
+	// Type Transaction type
+	Type *FilterType `form:"type,omitempty" json:"type,omitempty"`
+
+	// Since Event ID or block number to replay from, inclusive
+	Since *Since `form:"since,omitempty" json:"since,omitempty"`
+
+	// LastEventID Event ID to replay after
+	LastEventID *LastEventID `json:"Last-Event-ID,omitempty"`
+}
+
+// ImportChainWatchlistTextBody defines parameters for ImportChainWatchlist.
+type ImportChainWatchlistTextBody = string
+
+// ImportChainWatchlistParams defines parameters for ImportChainWatchlist.
+type ImportChainWatchlistParams struct {
+	From *int64 `form:"from,omitempty" json:"from,omitempty"`
+	To   *int64 `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// SubscribeFilterParams defines parameters for SubscribeFilter.
+type SubscribeFilterParams struct {
+	// Address Sender or recipient; repeatable or comma separated
+	Address *FilterAddress `form:"address,omitempty" json:"address,omitempty"`
+
+	// MinValue Matches values above it, in wei, decimal or 0x hex
+	MinValue *FilterMinValue `form:"minValue,omitempty" json:"minValue,omitempty"`
+
+	// Selector 4-byte function selector
+	Selector *FilterSelector `form:"selector,omitempty" json:"selector,omitempty"`
+
+	// Creation Match contract creations only
+	Creation *FilterCreation `form:"creation,omitempty" json:"creation,omitempty"`
+
+	// Type Transaction type
+	Type *FilterType `form:"type,omitempty" json:"type,omitempty"`
+
+	// Since Event ID or block number to replay from, inclusive
+	Since *Since `form:"since,omitempty" json:"since,omitempty"`
+
+	// LastEventID Event ID to replay after
+	LastEventID *LastEventID `json:"Last-Event-ID,omitempty"`
+}
+
+// ImportWatchlistTextBody defines parameters for ImportWatchlist.
+type ImportWatchlistTextBody = string
+
+// ImportWatchlistParams defines parameters for ImportWatchlist.
+type ImportWatchlistParams struct {
+	From *int64 `form:"from,omitempty" json:"from,omitempty"`
+	To   *int64 `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// IssueKeyJSONRequestBody defines body for IssueKey for application/json ContentType.
+type IssueKeyJSONRequestBody = IssueKeyRequest
+
+// AddChainWatchlistJSONRequestBody defines body for AddChainWatchlist for application/json ContentType.
+type AddChainWatchlistJSONRequestBody = AddWatchlistRequest
+
+// ImportChainWatchlistTextRequestBody defines body for ImportChainWatchlist for text/plain ContentType.
+type ImportChainWatchlistTextRequestBody = ImportChainWatchlistTextBody
+
+// AddWatchlistJSONRequestBody defines body for AddWatchlist for application/json ContentType.
+type AddWatchlistJSONRequestBody = AddWatchlistRequest
+
+// ImportWatchlistTextRequestBody defines body for ImportWatchlist for text/plain ContentType.
+type ImportWatchlistTextRequestBody = ImportWatchlistTextBody
+
+// RegisterWebhookJSONRequestBody defines body for RegisterWebhook for application/json ContentType.
+type RegisterWebhookJSONRequestBody = RegisterWebhookRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetStatus request
+	GetStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAddresses request
+	ListAddresses(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTopAddresses request
+	GetTopAddresses(ctx context.Context, params *GetTopAddressesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAddress request
+	GetAddress(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInternalTransfers request
+	GetInternalTransfers(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeInternalTransfers request
+	SubscribeInternalTransfers(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPending request
+	GetPending(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribePending request
+	SubscribePending(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAddressStats request
+	GetAddressStats(ctx context.Context, address AddressPath, params *GetAddressStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeAddress request
+	SubscribeAddress(ctx context.Context, address AddressPath, params *SubscribeAddressParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTokenTransfers request
+	GetTokenTransfers(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeTokenTransfers request
+	SubscribeTokenTransfers(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListKeys request
+	ListKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// IssueKeyWithBody request with any body
+	IssueKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	IssueKey(ctx context.Context, body IssueKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeKey request
+	RevokeKey(ctx context.Context, id KeyID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListChains request
+	ListChains(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChainStatus request
+	GetChainStatus(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListChainAddresses request
+	ListChainAddresses(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChainTopAddresses request
+	GetChainTopAddresses(ctx context.Context, chainId ChainID, params *GetChainTopAddressesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChainAddress request
+	GetChainAddress(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChainInternalTransfers request
+	GetChainInternalTransfers(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeChainInternalTransfers request
+	SubscribeChainInternalTransfers(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChainPending request
+	GetChainPending(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeChainPending request
+	SubscribeChainPending(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChainAddressStats request
+	GetChainAddressStats(ctx context.Context, chainId ChainID, address AddressPath, params *GetChainAddressStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeChainAddress request
+	SubscribeChainAddress(ctx context.Context, chainId ChainID, address AddressPath, params *SubscribeChainAddressParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChainTokenTransfers request
+	GetChainTokenTransfers(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeChainTokenTransfers request
+	SubscribeChainTokenTransfers(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChainIndexing request
+	GetChainIndexing(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeChainFilter request
+	SubscribeChainFilter(ctx context.Context, chainId ChainID, params *SubscribeChainFilterParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChainWatchlist request
+	GetChainWatchlist(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddChainWatchlistWithBody request with any body
+	AddChainWatchlistWithBody(ctx context.Context, chainId ChainID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddChainWatchlist(ctx context.Context, chainId ChainID, body AddChainWatchlistJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportChainWatchlistWithBody request with any body
+	ImportChainWatchlistWithBody(ctx context.Context, chainId ChainID, params *ImportChainWatchlistParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ImportChainWatchlistWithTextBody(ctx context.Context, chainId ChainID, params *ImportChainWatchlistParams, body ImportChainWatchlistTextRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveChainWatchlist request
+	RemoveChainWatchlist(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ChainWebSocket request
+	ChainWebSocket(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetIndexing request
+	GetIndexing(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOpenAPI request
+	GetOpenAPI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubscribeFilter request
+	SubscribeFilter(ctx context.Context, params *SubscribeFilterParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWatchlist request
+	GetWatchlist(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddWatchlistWithBody request with any body
+	AddWatchlistWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddWatchlist(ctx context.Context, body AddWatchlistJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportWatchlistWithBody request with any body
+	ImportWatchlistWithBody(ctx context.Context, params *ImportWatchlistParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ImportWatchlistWithTextBody(ctx context.Context, params *ImportWatchlistParams, body ImportWatchlistTextRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveWatchlist request
+	RemoveWatchlist(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListWebhooks request
+	ListWebhooks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterWebhookWithBody request with any body
+	RegisterWebhookWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterWebhook(ctx context.Context, body RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListDeadLetters request
+	ListDeadLetters(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteWebhook request
+	DeleteWebhook(ctx context.Context, id WebhookID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListWebhookDeliveries request
+	ListWebhookDeliveries(ctx context.Context, id WebhookID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// WebSocket request
+	WebSocket(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStatusRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListAddresses(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAddressesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTopAddresses(ctx context.Context, params *GetTopAddressesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTopAddressesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAddress(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAddressRequest(c.Server, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetInternalTransfers(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInternalTransfersRequest(c.Server, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeInternalTransfers(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeInternalTransfersRequest(c.Server, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPending(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPendingRequest(c.Server, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribePending(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribePendingRequest(c.Server, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAddressStats(ctx context.Context, address AddressPath, params *GetAddressStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAddressStatsRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeAddress(ctx context.Context, address AddressPath, params *SubscribeAddressParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeAddressRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTokenTransfers(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTokenTransfersRequest(c.Server, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeTokenTransfers(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeTokenTransfersRequest(c.Server, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListKeysRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) IssueKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewIssueKeyRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) IssueKey(ctx context.Context, body IssueKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewIssueKeyRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeKey(ctx context.Context, id KeyID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeKeyRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListChains(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListChainsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChainStatus(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChainStatusRequest(c.Server, chainId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListChainAddresses(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListChainAddressesRequest(c.Server, chainId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChainTopAddresses(ctx context.Context, chainId ChainID, params *GetChainTopAddressesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChainTopAddressesRequest(c.Server, chainId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChainAddress(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChainAddressRequest(c.Server, chainId, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChainInternalTransfers(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChainInternalTransfersRequest(c.Server, chainId, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeChainInternalTransfers(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeChainInternalTransfersRequest(c.Server, chainId, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChainPending(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChainPendingRequest(c.Server, chainId, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeChainPending(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeChainPendingRequest(c.Server, chainId, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChainAddressStats(ctx context.Context, chainId ChainID, address AddressPath, params *GetChainAddressStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChainAddressStatsRequest(c.Server, chainId, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeChainAddress(ctx context.Context, chainId ChainID, address AddressPath, params *SubscribeChainAddressParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeChainAddressRequest(c.Server, chainId, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChainTokenTransfers(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChainTokenTransfersRequest(c.Server, chainId, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeChainTokenTransfers(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeChainTokenTransfersRequest(c.Server, chainId, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChainIndexing(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChainIndexingRequest(c.Server, chainId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeChainFilter(ctx context.Context, chainId ChainID, params *SubscribeChainFilterParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeChainFilterRequest(c.Server, chainId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChainWatchlist(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChainWatchlistRequest(c.Server, chainId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddChainWatchlistWithBody(ctx context.Context, chainId ChainID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddChainWatchlistRequestWithBody(c.Server, chainId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddChainWatchlist(ctx context.Context, chainId ChainID, body AddChainWatchlistJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddChainWatchlistRequest(c.Server, chainId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportChainWatchlistWithBody(ctx context.Context, chainId ChainID, params *ImportChainWatchlistParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportChainWatchlistRequestWithBody(c.Server, chainId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportChainWatchlistWithTextBody(ctx context.Context, chainId ChainID, params *ImportChainWatchlistParams, body ImportChainWatchlistTextRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportChainWatchlistRequestWithTextBody(c.Server, chainId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveChainWatchlist(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveChainWatchlistRequest(c.Server, chainId, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ChainWebSocket(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewChainWebSocketRequest(c.Server, chainId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetIndexing(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIndexingRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOpenAPI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOpenAPIRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubscribeFilter(ctx context.Context, params *SubscribeFilterParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeFilterRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWatchlist(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWatchlistRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddWatchlistWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddWatchlistRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddWatchlist(ctx context.Context, body AddWatchlistJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddWatchlistRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportWatchlistWithBody(ctx context.Context, params *ImportWatchlistParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportWatchlistRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportWatchlistWithTextBody(ctx context.Context, params *ImportWatchlistParams, body ImportWatchlistTextRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportWatchlistRequestWithTextBody(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveWatchlist(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveWatchlistRequest(c.Server, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListWebhooks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListWebhooksRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterWebhookWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterWebhookRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterWebhook(ctx context.Context, body RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterWebhookRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListDeadLetters(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListDeadLettersRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, id WebhookID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteWebhookRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id WebhookID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListWebhookDeliveriesRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) WebSocket(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewWebSocketRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetStatusRequest generates requests for GetStatus
+func NewGetStatusRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListAddressesRequest generates requests for ListAddresses
+func NewListAddressesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTopAddressesRequest generates requests for GetTopAddresses
+func NewGetTopAddressesRequest(server string, params *GetTopAddressesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses/top")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAddressRequest generates requests for GetAddress
+func NewGetAddressRequest(server string, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInternalTransfersRequest generates requests for GetInternalTransfers
+func NewGetInternalTransfersRequest(server string, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses/%s/internal", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeInternalTransfersRequest generates requests for SubscribeInternalTransfers
+func NewSubscribeInternalTransfersRequest(server string, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses/%s/internal/subscribe", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPendingRequest generates requests for GetPending
+func NewGetPendingRequest(server string, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses/%s/pending", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribePendingRequest generates requests for SubscribePending
+func NewSubscribePendingRequest(server string, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses/%s/pending/subscribe", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAddressStatsRequest generates requests for GetAddressStats
+func NewGetAddressStatsRequest(server string, address AddressPath, params *GetAddressStatsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses/%s/stats", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Interval != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "interval", runtime.ParamLocationQuery, *params.Interval); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Top != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "top", runtime.ParamLocationQuery, *params.Top); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeAddressRequest generates requests for SubscribeAddress
+func NewSubscribeAddressRequest(server string, address AddressPath, params *SubscribeAddressParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses/%s/subscribe", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetTokenTransfersRequest generates requests for GetTokenTransfers
+func NewGetTokenTransfersRequest(server string, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses/%s/tokens", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeTokenTransfersRequest generates requests for SubscribeTokenTransfers
+func NewSubscribeTokenTransfersRequest(server string, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/addresses/%s/tokens/subscribe", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListKeysRequest generates requests for ListKeys
+func NewListKeysRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/keys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewIssueKeyRequest calls the generic IssueKey builder with application/json body
+func NewIssueKeyRequest(server string, body IssueKeyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewIssueKeyRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewIssueKeyRequestWithBody generates requests for IssueKey with any type of body
+func NewIssueKeyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/keys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRevokeKeyRequest generates requests for RevokeKey
+func NewRevokeKeyRequest(server string, id KeyID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/keys/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListChainsRequest generates requests for ListChains
+func NewListChainsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChainStatusRequest generates requests for GetChainStatus
+func NewGetChainStatusRequest(server string, chainId ChainID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListChainAddressesRequest generates requests for ListChainAddresses
+func NewListChainAddressesRequest(server string, chainId ChainID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChainTopAddressesRequest generates requests for GetChainTopAddresses
+func NewGetChainTopAddressesRequest(server string, chainId ChainID, params *GetChainTopAddressesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses/top", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChainAddressRequest generates requests for GetChainAddress
+func NewGetChainAddressRequest(server string, chainId ChainID, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChainInternalTransfersRequest generates requests for GetChainInternalTransfers
+func NewGetChainInternalTransfersRequest(server string, chainId ChainID, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses/%s/internal", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeChainInternalTransfersRequest generates requests for SubscribeChainInternalTransfers
+func NewSubscribeChainInternalTransfersRequest(server string, chainId ChainID, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses/%s/internal/subscribe", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChainPendingRequest generates requests for GetChainPending
+func NewGetChainPendingRequest(server string, chainId ChainID, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses/%s/pending", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeChainPendingRequest generates requests for SubscribeChainPending
+func NewSubscribeChainPendingRequest(server string, chainId ChainID, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses/%s/pending/subscribe", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChainAddressStatsRequest generates requests for GetChainAddressStats
+func NewGetChainAddressStatsRequest(server string, chainId ChainID, address AddressPath, params *GetChainAddressStatsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses/%s/stats", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Interval != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "interval", runtime.ParamLocationQuery, *params.Interval); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Top != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "top", runtime.ParamLocationQuery, *params.Top); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeChainAddressRequest generates requests for SubscribeChainAddress
+func NewSubscribeChainAddressRequest(server string, chainId ChainID, address AddressPath, params *SubscribeChainAddressParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses/%s/subscribe", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetChainTokenTransfersRequest generates requests for GetChainTokenTransfers
+func NewGetChainTokenTransfersRequest(server string, chainId ChainID, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses/%s/tokens", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeChainTokenTransfersRequest generates requests for SubscribeChainTokenTransfers
+func NewSubscribeChainTokenTransfersRequest(server string, chainId ChainID, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/addresses/%s/tokens/subscribe", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChainIndexingRequest generates requests for GetChainIndexing
+func NewGetChainIndexingRequest(server string, chainId ChainID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/indexing", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeChainFilterRequest generates requests for SubscribeChainFilter
+func NewSubscribeChainFilterRequest(server string, chainId ChainID, params *SubscribeChainFilterParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/subscribe", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Address != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "address", runtime.ParamLocationQuery, *params.Address); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MinValue != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "minValue", runtime.ParamLocationQuery, *params.MinValue); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Selector != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "selector", runtime.ParamLocationQuery, *params.Selector); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Creation != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "creation", runtime.ParamLocationQuery, *params.Creation); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Type != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetChainWatchlistRequest generates requests for GetChainWatchlist
+func NewGetChainWatchlistRequest(server string, chainId ChainID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/watchlist", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddChainWatchlistRequest calls the generic AddChainWatchlist builder with application/json body
+func NewAddChainWatchlistRequest(server string, chainId ChainID, body AddChainWatchlistJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddChainWatchlistRequestWithBody(server, chainId, "application/json", bodyReader)
+}
+
+// NewAddChainWatchlistRequestWithBody generates requests for AddChainWatchlist with any type of body
+func NewAddChainWatchlistRequestWithBody(server string, chainId ChainID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/watchlist", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewImportChainWatchlistRequestWithTextBody calls the generic ImportChainWatchlist builder with text/plain body
+func NewImportChainWatchlistRequestWithTextBody(server string, chainId ChainID, params *ImportChainWatchlistParams, body ImportChainWatchlistTextRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	bodyReader = strings.NewReader(string(body))
+	return NewImportChainWatchlistRequestWithBody(server, chainId, params, "text/plain", bodyReader)
+}
+
+// NewImportChainWatchlistRequestWithBody generates requests for ImportChainWatchlist with any type of body
+func NewImportChainWatchlistRequestWithBody(server string, chainId ChainID, params *ImportChainWatchlistParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/watchlist/import", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRemoveChainWatchlistRequest generates requests for RemoveChainWatchlist
+func NewRemoveChainWatchlistRequest(server string, chainId ChainID, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/watchlist/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewChainWebSocketRequest generates requests for ChainWebSocket
+func NewChainWebSocketRequest(server string, chainId ChainID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "chainId", runtime.ParamLocationPath, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains/%s/ws", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIndexingRequest generates requests for GetIndexing
+func NewGetIndexingRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/indexing")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOpenAPIRequest generates requests for GetOpenAPI
+func NewGetOpenAPIRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/openapi.yaml")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeFilterRequest generates requests for SubscribeFilter
+func NewSubscribeFilterRequest(server string, params *SubscribeFilterParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subscribe")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Address != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "address", runtime.ParamLocationQuery, *params.Address); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MinValue != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "minValue", runtime.ParamLocationQuery, *params.MinValue); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Selector != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "selector", runtime.ParamLocationQuery, *params.Selector); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Creation != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "creation", runtime.ParamLocationQuery, *params.Creation); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Type != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetWatchlistRequest generates requests for GetWatchlist
+func NewGetWatchlistRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/watchlist")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddWatchlistRequest calls the generic AddWatchlist builder with application/json body
+func NewAddWatchlistRequest(server string, body AddWatchlistJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddWatchlistRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddWatchlistRequestWithBody generates requests for AddWatchlist with any type of body
+func NewAddWatchlistRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/watchlist")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewImportWatchlistRequestWithTextBody calls the generic ImportWatchlist builder with text/plain body
+func NewImportWatchlistRequestWithTextBody(server string, params *ImportWatchlistParams, body ImportWatchlistTextRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	bodyReader = strings.NewReader(string(body))
+	return NewImportWatchlistRequestWithBody(server, params, "text/plain", bodyReader)
+}
+
+// NewImportWatchlistRequestWithBody generates requests for ImportWatchlist with any type of body
+func NewImportWatchlistRequestWithBody(server string, params *ImportWatchlistParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/watchlist/import")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRemoveWatchlistRequest generates requests for RemoveWatchlist
+func NewRemoveWatchlistRequest(server string, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/watchlist/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListWebhooksRequest generates requests for ListWebhooks
+func NewListWebhooksRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegisterWebhookRequest calls the generic RegisterWebhook builder with application/json body
+func NewRegisterWebhookRequest(server string, body RegisterWebhookJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterWebhookRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRegisterWebhookRequestWithBody generates requests for RegisterWebhook with any type of body
+func NewRegisterWebhookRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListDeadLettersRequest generates requests for ListDeadLetters
+func NewListDeadLettersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/deadletters")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteWebhookRequest generates requests for DeleteWebhook
+func NewDeleteWebhookRequest(server string, id WebhookID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListWebhookDeliveriesRequest generates requests for ListWebhookDeliveries
+func NewListWebhookDeliveriesRequest(server string, id WebhookID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s/deliveries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewWebSocketRequest generates requests for WebSocket
+func NewWebSocketRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ws")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetStatusWithResponse request
+	GetStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetStatusResponse, error)
+
+	// ListAddressesWithResponse request
+	ListAddressesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAddressesResponse, error)
+
+	// GetTopAddressesWithResponse request
+	GetTopAddressesWithResponse(ctx context.Context, params *GetTopAddressesParams, reqEditors ...RequestEditorFn) (*GetTopAddressesResponse, error)
+
+	// GetAddressWithResponse request
+	GetAddressWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*GetAddressResponse, error)
+
+	// GetInternalTransfersWithResponse request
+	GetInternalTransfersWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*GetInternalTransfersResponse, error)
+
+	// SubscribeInternalTransfersWithResponse request
+	SubscribeInternalTransfersWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribeInternalTransfersResponse, error)
+
+	// GetPendingWithResponse request
+	GetPendingWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*GetPendingResponse, error)
+
+	// SubscribePendingWithResponse request
+	SubscribePendingWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribePendingResponse, error)
+
+	// GetAddressStatsWithResponse request
+	GetAddressStatsWithResponse(ctx context.Context, address AddressPath, params *GetAddressStatsParams, reqEditors ...RequestEditorFn) (*GetAddressStatsResponse, error)
+
+	// SubscribeAddressWithResponse request
+	SubscribeAddressWithResponse(ctx context.Context, address AddressPath, params *SubscribeAddressParams, reqEditors ...RequestEditorFn) (*SubscribeAddressResponse, error)
+
+	// GetTokenTransfersWithResponse request
+	GetTokenTransfersWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*GetTokenTransfersResponse, error)
+
+	// SubscribeTokenTransfersWithResponse request
+	SubscribeTokenTransfersWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribeTokenTransfersResponse, error)
+
+	// ListKeysWithResponse request
+	ListKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListKeysResponse, error)
+
+	// IssueKeyWithBodyWithResponse request with any body
+	IssueKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueKeyResponse, error)
+
+	IssueKeyWithResponse(ctx context.Context, body IssueKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueKeyResponse, error)
+
+	// RevokeKeyWithResponse request
+	RevokeKeyWithResponse(ctx context.Context, id KeyID, reqEditors ...RequestEditorFn) (*RevokeKeyResponse, error)
+
+	// ListChainsWithResponse request
+	ListChainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListChainsResponse, error)
+
+	// GetChainStatusWithResponse request
+	GetChainStatusWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetChainStatusResponse, error)
+
+	// ListChainAddressesWithResponse request
+	ListChainAddressesWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*ListChainAddressesResponse, error)
+
+	// GetChainTopAddressesWithResponse request
+	GetChainTopAddressesWithResponse(ctx context.Context, chainId ChainID, params *GetChainTopAddressesParams, reqEditors ...RequestEditorFn) (*GetChainTopAddressesResponse, error)
+
+	// GetChainAddressWithResponse request
+	GetChainAddressWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*GetChainAddressResponse, error)
+
+	// GetChainInternalTransfersWithResponse request
+	GetChainInternalTransfersWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*GetChainInternalTransfersResponse, error)
+
+	// SubscribeChainInternalTransfersWithResponse request
+	SubscribeChainInternalTransfersWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribeChainInternalTransfersResponse, error)
+
+	// GetChainPendingWithResponse request
+	GetChainPendingWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*GetChainPendingResponse, error)
+
+	// SubscribeChainPendingWithResponse request
+	SubscribeChainPendingWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribeChainPendingResponse, error)
+
+	// GetChainAddressStatsWithResponse request
+	GetChainAddressStatsWithResponse(ctx context.Context, chainId ChainID, address AddressPath, params *GetChainAddressStatsParams, reqEditors ...RequestEditorFn) (*GetChainAddressStatsResponse, error)
+
+	// SubscribeChainAddressWithResponse request
+	SubscribeChainAddressWithResponse(ctx context.Context, chainId ChainID, address AddressPath, params *SubscribeChainAddressParams, reqEditors ...RequestEditorFn) (*SubscribeChainAddressResponse, error)
+
+	// GetChainTokenTransfersWithResponse request
+	GetChainTokenTransfersWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*GetChainTokenTransfersResponse, error)
+
+	// SubscribeChainTokenTransfersWithResponse request
+	SubscribeChainTokenTransfersWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribeChainTokenTransfersResponse, error)
+
+	// GetChainIndexingWithResponse request
+	GetChainIndexingWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetChainIndexingResponse, error)
+
+	// SubscribeChainFilterWithResponse request
+	SubscribeChainFilterWithResponse(ctx context.Context, chainId ChainID, params *SubscribeChainFilterParams, reqEditors ...RequestEditorFn) (*SubscribeChainFilterResponse, error)
+
+	// GetChainWatchlistWithResponse request
+	GetChainWatchlistWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetChainWatchlistResponse, error)
+
+	// AddChainWatchlistWithBodyWithResponse request with any body
+	AddChainWatchlistWithBodyWithResponse(ctx context.Context, chainId ChainID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddChainWatchlistResponse, error)
+
+	AddChainWatchlistWithResponse(ctx context.Context, chainId ChainID, body AddChainWatchlistJSONRequestBody, reqEditors ...RequestEditorFn) (*AddChainWatchlistResponse, error)
+
+	// ImportChainWatchlistWithBodyWithResponse request with any body
+	ImportChainWatchlistWithBodyWithResponse(ctx context.Context, chainId ChainID, params *ImportChainWatchlistParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportChainWatchlistResponse, error)
+
+	ImportChainWatchlistWithTextBodyWithResponse(ctx context.Context, chainId ChainID, params *ImportChainWatchlistParams, body ImportChainWatchlistTextRequestBody, reqEditors ...RequestEditorFn) (*ImportChainWatchlistResponse, error)
+
+	// RemoveChainWatchlistWithResponse request
+	RemoveChainWatchlistWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*RemoveChainWatchlistResponse, error)
+
+	// ChainWebSocketWithResponse request
+	ChainWebSocketWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*ChainWebSocketResponse, error)
+
+	// GetIndexingWithResponse request
+	GetIndexingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetIndexingResponse, error)
+
+	// GetOpenAPIWithResponse request
+	GetOpenAPIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPIResponse, error)
+
+	// SubscribeFilterWithResponse request
+	SubscribeFilterWithResponse(ctx context.Context, params *SubscribeFilterParams, reqEditors ...RequestEditorFn) (*SubscribeFilterResponse, error)
+
+	// GetWatchlistWithResponse request
+	GetWatchlistWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWatchlistResponse, error)
+
+	// AddWatchlistWithBodyWithResponse request with any body
+	AddWatchlistWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddWatchlistResponse, error)
+
+	AddWatchlistWithResponse(ctx context.Context, body AddWatchlistJSONRequestBody, reqEditors ...RequestEditorFn) (*AddWatchlistResponse, error)
+
+	// ImportWatchlistWithBodyWithResponse request with any body
+	ImportWatchlistWithBodyWithResponse(ctx context.Context, params *ImportWatchlistParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportWatchlistResponse, error)
+
+	ImportWatchlistWithTextBodyWithResponse(ctx context.Context, params *ImportWatchlistParams, body ImportWatchlistTextRequestBody, reqEditors ...RequestEditorFn) (*ImportWatchlistResponse, error)
+
+	// RemoveWatchlistWithResponse request
+	RemoveWatchlistWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*RemoveWatchlistResponse, error)
+
+	// ListWebhooksWithResponse request
+	ListWebhooksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListWebhooksResponse, error)
+
+	// RegisterWebhookWithBodyWithResponse request with any body
+	RegisterWebhookWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error)
+
+	RegisterWebhookWithResponse(ctx context.Context, body RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error)
+
+	// ListDeadLettersWithResponse request
+	ListDeadLettersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDeadLettersResponse, error)
+
+	// DeleteWebhookWithResponse request
+	DeleteWebhookWithResponse(ctx context.Context, id WebhookID, reqEditors ...RequestEditorFn) (*DeleteWebhookResponse, error)
+
+	// ListWebhookDeliveriesWithResponse request
+	ListWebhookDeliveriesWithResponse(ctx context.Context, id WebhookID, reqEditors ...RequestEditorFn) (*ListWebhookDeliveriesResponse, error)
+
+	// WebSocketWithResponse request
+	WebSocketWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*WebSocketResponse, error)
+}
+
+type GetStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StatusResponse
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListAddressesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AddressesResponse
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+	JSON503      *Syncing
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAddressesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAddressesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTopAddressesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TopAddressesResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTopAddressesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTopAddressesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAddressResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionsResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+	JSON503      *Syncing
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAddressResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAddressResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInternalTransfersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InternalTransfersResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInternalTransfersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInternalTransfersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeInternalTransfersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeInternalTransfersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeInternalTransfersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPendingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PendingResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPendingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPendingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribePendingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribePendingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribePendingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAddressStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AddressStats
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAddressStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAddressStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeAddressResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON410      *Gone
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeAddressResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeAddressResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTokenTransfersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TokenTransfersResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTokenTransfersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTokenTransfersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeTokenTransfersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeTokenTransfersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeTokenTransfersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *KeysResponse
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r ListKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type IssueKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *IssuedKey
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r IssueKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r IssueKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListChainsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChainsResponse
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r ListChainsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListChainsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChainStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StatusResponse
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChainStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChainStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListChainAddressesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AddressesResponse
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+	JSON503      *Syncing
+}
+
+// Status returns HTTPResponse.Status
+func (r ListChainAddressesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListChainAddressesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChainTopAddressesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TopAddressesResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChainTopAddressesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChainTopAddressesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChainAddressResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionsResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+	JSON503      *Syncing
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChainAddressResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChainAddressResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChainInternalTransfersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InternalTransfersResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChainInternalTransfersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChainInternalTransfersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeChainInternalTransfersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeChainInternalTransfersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeChainInternalTransfersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChainPendingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PendingResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChainPendingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChainPendingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeChainPendingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeChainPendingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeChainPendingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChainAddressStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AddressStats
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChainAddressStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChainAddressStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeChainAddressResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON410      *Gone
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeChainAddressResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeChainAddressResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChainTokenTransfersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TokenTransfersResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChainTokenTransfersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChainTokenTransfersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeChainTokenTransfersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeChainTokenTransfersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeChainTokenTransfersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChainIndexingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IndexingProgress
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChainIndexingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChainIndexingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeChainFilterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON410      *Gone
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeChainFilterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeChainFilterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChainWatchlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WatchlistResponse
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChainWatchlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChainWatchlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddChainWatchlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WatchlistAdded
+	JSON202      *WatchlistAdded
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON409      *Conflict
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r AddChainWatchlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddChainWatchlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportChainWatchlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WatchlistAdded
+	JSON202      *WatchlistAdded
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON409      *Conflict
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportChainWatchlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportChainWatchlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveChainWatchlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveChainWatchlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveChainWatchlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ChainWebSocketResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r ChainWebSocketResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ChainWebSocketResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIndexingResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IndexingProgress
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIndexingResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIndexingResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOpenAPIResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	YAML200      *string
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOpenAPIResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOpenAPIResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeFilterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON410      *Gone
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeFilterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeFilterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWatchlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WatchlistResponse
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWatchlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWatchlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddWatchlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WatchlistAdded
+	JSON202      *WatchlistAdded
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON409      *Conflict
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r AddWatchlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddWatchlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportWatchlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WatchlistAdded
+	JSON202      *WatchlistAdded
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON409      *Conflict
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportWatchlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportWatchlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveWatchlistResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveWatchlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveWatchlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListWebhooksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhooksResponse
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r ListWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *RegisteredWebhook
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListDeadLettersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DeadLettersResponse
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r ListDeadLettersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListDeadLettersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DeliveriesResponse
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r ListWebhookDeliveriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListWebhookDeliveriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type WebSocketResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+}
+
+// Status returns HTTPResponse.Status
+func (r WebSocketResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r WebSocketResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetStatusWithResponse request returning *GetStatusResponse
+func (c *ClientWithResponses) GetStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetStatusResponse, error) {
+	rsp, err := c.GetStatus(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStatusResponse(rsp)
+}
+
+// ListAddressesWithResponse request returning *ListAddressesResponse
+func (c *ClientWithResponses) ListAddressesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAddressesResponse, error) {
+	rsp, err := c.ListAddresses(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAddressesResponse(rsp)
+}
+
+// GetTopAddressesWithResponse request returning *GetTopAddressesResponse
+func (c *ClientWithResponses) GetTopAddressesWithResponse(ctx context.Context, params *GetTopAddressesParams, reqEditors ...RequestEditorFn) (*GetTopAddressesResponse, error) {
+	rsp, err := c.GetTopAddresses(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTopAddressesResponse(rsp)
+}
+
+// GetAddressWithResponse request returning *GetAddressResponse
+func (c *ClientWithResponses) GetAddressWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*GetAddressResponse, error) {
+	rsp, err := c.GetAddress(ctx, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAddressResponse(rsp)
+}
+
+// GetInternalTransfersWithResponse request returning *GetInternalTransfersResponse
+func (c *ClientWithResponses) GetInternalTransfersWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*GetInternalTransfersResponse, error) {
+	rsp, err := c.GetInternalTransfers(ctx, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInternalTransfersResponse(rsp)
+}
+
+// SubscribeInternalTransfersWithResponse request returning *SubscribeInternalTransfersResponse
+func (c *ClientWithResponses) SubscribeInternalTransfersWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribeInternalTransfersResponse, error) {
+	rsp, err := c.SubscribeInternalTransfers(ctx, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeInternalTransfersResponse(rsp)
+}
+
+// GetPendingWithResponse request returning *GetPendingResponse
+func (c *ClientWithResponses) GetPendingWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*GetPendingResponse, error) {
+	rsp, err := c.GetPending(ctx, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPendingResponse(rsp)
+}
+
+// SubscribePendingWithResponse request returning *SubscribePendingResponse
+func (c *ClientWithResponses) SubscribePendingWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribePendingResponse, error) {
+	rsp, err := c.SubscribePending(ctx, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribePendingResponse(rsp)
+}
+
+// GetAddressStatsWithResponse request returning *GetAddressStatsResponse
+func (c *ClientWithResponses) GetAddressStatsWithResponse(ctx context.Context, address AddressPath, params *GetAddressStatsParams, reqEditors ...RequestEditorFn) (*GetAddressStatsResponse, error) {
+	rsp, err := c.GetAddressStats(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAddressStatsResponse(rsp)
+}
+
+// SubscribeAddressWithResponse request returning *SubscribeAddressResponse
+func (c *ClientWithResponses) SubscribeAddressWithResponse(ctx context.Context, address AddressPath, params *SubscribeAddressParams, reqEditors ...RequestEditorFn) (*SubscribeAddressResponse, error) {
+	rsp, err := c.SubscribeAddress(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeAddressResponse(rsp)
+}
+
+// GetTokenTransfersWithResponse request returning *GetTokenTransfersResponse
+func (c *ClientWithResponses) GetTokenTransfersWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*GetTokenTransfersResponse, error) {
+	rsp, err := c.GetTokenTransfers(ctx, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTokenTransfersResponse(rsp)
+}
+
+// SubscribeTokenTransfersWithResponse request returning *SubscribeTokenTransfersResponse
+func (c *ClientWithResponses) SubscribeTokenTransfersWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribeTokenTransfersResponse, error) {
+	rsp, err := c.SubscribeTokenTransfers(ctx, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeTokenTransfersResponse(rsp)
+}
+
+// ListKeysWithResponse request returning *ListKeysResponse
+func (c *ClientWithResponses) ListKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListKeysResponse, error) {
+	rsp, err := c.ListKeys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListKeysResponse(rsp)
+}
+
+// IssueKeyWithBodyWithResponse request with arbitrary body returning *IssueKeyResponse
+func (c *ClientWithResponses) IssueKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*IssueKeyResponse, error) {
+	rsp, err := c.IssueKeyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueKeyResponse(rsp)
+}
+
+func (c *ClientWithResponses) IssueKeyWithResponse(ctx context.Context, body IssueKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*IssueKeyResponse, error) {
+	rsp, err := c.IssueKey(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssueKeyResponse(rsp)
+}
+
+// RevokeKeyWithResponse request returning *RevokeKeyResponse
+func (c *ClientWithResponses) RevokeKeyWithResponse(ctx context.Context, id KeyID, reqEditors ...RequestEditorFn) (*RevokeKeyResponse, error) {
+	rsp, err := c.RevokeKey(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeKeyResponse(rsp)
+}
+
+// ListChainsWithResponse request returning *ListChainsResponse
+func (c *ClientWithResponses) ListChainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListChainsResponse, error) {
+	rsp, err := c.ListChains(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListChainsResponse(rsp)
+}
+
+// GetChainStatusWithResponse request returning *GetChainStatusResponse
+func (c *ClientWithResponses) GetChainStatusWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetChainStatusResponse, error) {
+	rsp, err := c.GetChainStatus(ctx, chainId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChainStatusResponse(rsp)
+}
+
+// ListChainAddressesWithResponse request returning *ListChainAddressesResponse
+func (c *ClientWithResponses) ListChainAddressesWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*ListChainAddressesResponse, error) {
+	rsp, err := c.ListChainAddresses(ctx, chainId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListChainAddressesResponse(rsp)
+}
+
+// GetChainTopAddressesWithResponse request returning *GetChainTopAddressesResponse
+func (c *ClientWithResponses) GetChainTopAddressesWithResponse(ctx context.Context, chainId ChainID, params *GetChainTopAddressesParams, reqEditors ...RequestEditorFn) (*GetChainTopAddressesResponse, error) {
+	rsp, err := c.GetChainTopAddresses(ctx, chainId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChainTopAddressesResponse(rsp)
+}
+
+// GetChainAddressWithResponse request returning *GetChainAddressResponse
+func (c *ClientWithResponses) GetChainAddressWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*GetChainAddressResponse, error) {
+	rsp, err := c.GetChainAddress(ctx, chainId, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChainAddressResponse(rsp)
+}
+
+// GetChainInternalTransfersWithResponse request returning *GetChainInternalTransfersResponse
+func (c *ClientWithResponses) GetChainInternalTransfersWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*GetChainInternalTransfersResponse, error) {
+	rsp, err := c.GetChainInternalTransfers(ctx, chainId, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChainInternalTransfersResponse(rsp)
+}
+
+// SubscribeChainInternalTransfersWithResponse request returning *SubscribeChainInternalTransfersResponse
+func (c *ClientWithResponses) SubscribeChainInternalTransfersWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribeChainInternalTransfersResponse, error) {
+	rsp, err := c.SubscribeChainInternalTransfers(ctx, chainId, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeChainInternalTransfersResponse(rsp)
+}
+
+// GetChainPendingWithResponse request returning *GetChainPendingResponse
+func (c *ClientWithResponses) GetChainPendingWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*GetChainPendingResponse, error) {
+	rsp, err := c.GetChainPending(ctx, chainId, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChainPendingResponse(rsp)
+}
+
+// SubscribeChainPendingWithResponse request returning *SubscribeChainPendingResponse
+func (c *ClientWithResponses) SubscribeChainPendingWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribeChainPendingResponse, error) {
+	rsp, err := c.SubscribeChainPending(ctx, chainId, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeChainPendingResponse(rsp)
+}
+
+// GetChainAddressStatsWithResponse request returning *GetChainAddressStatsResponse
+func (c *ClientWithResponses) GetChainAddressStatsWithResponse(ctx context.Context, chainId ChainID, address AddressPath, params *GetChainAddressStatsParams, reqEditors ...RequestEditorFn) (*GetChainAddressStatsResponse, error) {
+	rsp, err := c.GetChainAddressStats(ctx, chainId, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChainAddressStatsResponse(rsp)
+}
+
+// SubscribeChainAddressWithResponse request returning *SubscribeChainAddressResponse
+func (c *ClientWithResponses) SubscribeChainAddressWithResponse(ctx context.Context, chainId ChainID, address AddressPath, params *SubscribeChainAddressParams, reqEditors ...RequestEditorFn) (*SubscribeChainAddressResponse, error) {
+	rsp, err := c.SubscribeChainAddress(ctx, chainId, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeChainAddressResponse(rsp)
+}
+
+// GetChainTokenTransfersWithResponse request returning *GetChainTokenTransfersResponse
+func (c *ClientWithResponses) GetChainTokenTransfersWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*GetChainTokenTransfersResponse, error) {
+	rsp, err := c.GetChainTokenTransfers(ctx, chainId, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChainTokenTransfersResponse(rsp)
+}
+
+// SubscribeChainTokenTransfersWithResponse request returning *SubscribeChainTokenTransfersResponse
+func (c *ClientWithResponses) SubscribeChainTokenTransfersWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*SubscribeChainTokenTransfersResponse, error) {
+	rsp, err := c.SubscribeChainTokenTransfers(ctx, chainId, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeChainTokenTransfersResponse(rsp)
+}
+
+// GetChainIndexingWithResponse request returning *GetChainIndexingResponse
+func (c *ClientWithResponses) GetChainIndexingWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetChainIndexingResponse, error) {
+	rsp, err := c.GetChainIndexing(ctx, chainId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChainIndexingResponse(rsp)
+}
+
+// SubscribeChainFilterWithResponse request returning *SubscribeChainFilterResponse
+func (c *ClientWithResponses) SubscribeChainFilterWithResponse(ctx context.Context, chainId ChainID, params *SubscribeChainFilterParams, reqEditors ...RequestEditorFn) (*SubscribeChainFilterResponse, error) {
+	rsp, err := c.SubscribeChainFilter(ctx, chainId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeChainFilterResponse(rsp)
+}
+
+// GetChainWatchlistWithResponse request returning *GetChainWatchlistResponse
+func (c *ClientWithResponses) GetChainWatchlistWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*GetChainWatchlistResponse, error) {
+	rsp, err := c.GetChainWatchlist(ctx, chainId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChainWatchlistResponse(rsp)
+}
+
+// AddChainWatchlistWithBodyWithResponse request with arbitrary body returning *AddChainWatchlistResponse
+func (c *ClientWithResponses) AddChainWatchlistWithBodyWithResponse(ctx context.Context, chainId ChainID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddChainWatchlistResponse, error) {
+	rsp, err := c.AddChainWatchlistWithBody(ctx, chainId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddChainWatchlistResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddChainWatchlistWithResponse(ctx context.Context, chainId ChainID, body AddChainWatchlistJSONRequestBody, reqEditors ...RequestEditorFn) (*AddChainWatchlistResponse, error) {
+	rsp, err := c.AddChainWatchlist(ctx, chainId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddChainWatchlistResponse(rsp)
+}
+
+// ImportChainWatchlistWithBodyWithResponse request with arbitrary body returning *ImportChainWatchlistResponse
+func (c *ClientWithResponses) ImportChainWatchlistWithBodyWithResponse(ctx context.Context, chainId ChainID, params *ImportChainWatchlistParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportChainWatchlistResponse, error) {
+	rsp, err := c.ImportChainWatchlistWithBody(ctx, chainId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportChainWatchlistResponse(rsp)
+}
+
+func (c *ClientWithResponses) ImportChainWatchlistWithTextBodyWithResponse(ctx context.Context, chainId ChainID, params *ImportChainWatchlistParams, body ImportChainWatchlistTextRequestBody, reqEditors ...RequestEditorFn) (*ImportChainWatchlistResponse, error) {
+	rsp, err := c.ImportChainWatchlistWithTextBody(ctx, chainId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportChainWatchlistResponse(rsp)
+}
+
+// RemoveChainWatchlistWithResponse request returning *RemoveChainWatchlistResponse
+func (c *ClientWithResponses) RemoveChainWatchlistWithResponse(ctx context.Context, chainId ChainID, address AddressPath, reqEditors ...RequestEditorFn) (*RemoveChainWatchlistResponse, error) {
+	rsp, err := c.RemoveChainWatchlist(ctx, chainId, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveChainWatchlistResponse(rsp)
+}
+
+// ChainWebSocketWithResponse request returning *ChainWebSocketResponse
+func (c *ClientWithResponses) ChainWebSocketWithResponse(ctx context.Context, chainId ChainID, reqEditors ...RequestEditorFn) (*ChainWebSocketResponse, error) {
+	rsp, err := c.ChainWebSocket(ctx, chainId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseChainWebSocketResponse(rsp)
+}
+
+// GetIndexingWithResponse request returning *GetIndexingResponse
+func (c *ClientWithResponses) GetIndexingWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetIndexingResponse, error) {
+	rsp, err := c.GetIndexing(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIndexingResponse(rsp)
+}
+
+// GetOpenAPIWithResponse request returning *GetOpenAPIResponse
+func (c *ClientWithResponses) GetOpenAPIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPIResponse, error) {
+	rsp, err := c.GetOpenAPI(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOpenAPIResponse(rsp)
+}
+
+// SubscribeFilterWithResponse request returning *SubscribeFilterResponse
+func (c *ClientWithResponses) SubscribeFilterWithResponse(ctx context.Context, params *SubscribeFilterParams, reqEditors ...RequestEditorFn) (*SubscribeFilterResponse, error) {
+	rsp, err := c.SubscribeFilter(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeFilterResponse(rsp)
+}
+
+// GetWatchlistWithResponse request returning *GetWatchlistResponse
+func (c *ClientWithResponses) GetWatchlistWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWatchlistResponse, error) {
+	rsp, err := c.GetWatchlist(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWatchlistResponse(rsp)
+}
+
+// AddWatchlistWithBodyWithResponse request with arbitrary body returning *AddWatchlistResponse
+func (c *ClientWithResponses) AddWatchlistWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddWatchlistResponse, error) {
+	rsp, err := c.AddWatchlistWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddWatchlistResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddWatchlistWithResponse(ctx context.Context, body AddWatchlistJSONRequestBody, reqEditors ...RequestEditorFn) (*AddWatchlistResponse, error) {
+	rsp, err := c.AddWatchlist(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddWatchlistResponse(rsp)
+}
+
+// ImportWatchlistWithBodyWithResponse request with arbitrary body returning *ImportWatchlistResponse
+func (c *ClientWithResponses) ImportWatchlistWithBodyWithResponse(ctx context.Context, params *ImportWatchlistParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportWatchlistResponse, error) {
+	rsp, err := c.ImportWatchlistWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportWatchlistResponse(rsp)
+}
+
+func (c *ClientWithResponses) ImportWatchlistWithTextBodyWithResponse(ctx context.Context, params *ImportWatchlistParams, body ImportWatchlistTextRequestBody, reqEditors ...RequestEditorFn) (*ImportWatchlistResponse, error) {
+	rsp, err := c.ImportWatchlistWithTextBody(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportWatchlistResponse(rsp)
+}
+
+// RemoveWatchlistWithResponse request returning *RemoveWatchlistResponse
+func (c *ClientWithResponses) RemoveWatchlistWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*RemoveWatchlistResponse, error) {
+	rsp, err := c.RemoveWatchlist(ctx, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveWatchlistResponse(rsp)
+}
+
+// ListWebhooksWithResponse request returning *ListWebhooksResponse
+func (c *ClientWithResponses) ListWebhooksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListWebhooksResponse, error) {
+	rsp, err := c.ListWebhooks(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListWebhooksResponse(rsp)
+}
+
+// RegisterWebhookWithBodyWithResponse request with arbitrary body returning *RegisterWebhookResponse
+func (c *ClientWithResponses) RegisterWebhookWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error) {
+	rsp, err := c.RegisterWebhookWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterWebhookResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterWebhookWithResponse(ctx context.Context, body RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error) {
+	rsp, err := c.RegisterWebhook(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterWebhookResponse(rsp)
+}
+
+// ListDeadLettersWithResponse request returning *ListDeadLettersResponse
+func (c *ClientWithResponses) ListDeadLettersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDeadLettersResponse, error) {
+	rsp, err := c.ListDeadLetters(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListDeadLettersResponse(rsp)
+}
+
+// DeleteWebhookWithResponse request returning *DeleteWebhookResponse
+func (c *ClientWithResponses) DeleteWebhookWithResponse(ctx context.Context, id WebhookID, reqEditors ...RequestEditorFn) (*DeleteWebhookResponse, error) {
+	rsp, err := c.DeleteWebhook(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteWebhookResponse(rsp)
+}
+
+// ListWebhookDeliveriesWithResponse request returning *ListWebhookDeliveriesResponse
+func (c *ClientWithResponses) ListWebhookDeliveriesWithResponse(ctx context.Context, id WebhookID, reqEditors ...RequestEditorFn) (*ListWebhookDeliveriesResponse, error) {
+	rsp, err := c.ListWebhookDeliveries(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListWebhookDeliveriesResponse(rsp)
+}
+
+// WebSocketWithResponse request returning *WebSocketResponse
+func (c *ClientWithResponses) WebSocketWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*WebSocketResponse, error) {
+	rsp, err := c.WebSocket(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWebSocketResponse(rsp)
+}
+
+// ParseGetStatusResponse parses an HTTP response from a GetStatusWithResponse call
+func ParseGetStatusResponse(rsp *http.Response) (*GetStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StatusResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListAddressesResponse parses an HTTP response from a ListAddressesWithResponse call
+func ParseListAddressesResponse(rsp *http.Response) (*ListAddressesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAddressesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AddressesResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Syncing
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTopAddressesResponse parses an HTTP response from a GetTopAddressesWithResponse call
+func ParseGetTopAddressesResponse(rsp *http.Response) (*GetTopAddressesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTopAddressesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TopAddressesResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAddressResponse parses an HTTP response from a GetAddressWithResponse call
+func ParseGetAddressResponse(rsp *http.Response) (*GetAddressResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAddressResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransactionsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Syncing
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInternalTransfersResponse parses an HTTP response from a GetInternalTransfersWithResponse call
+func ParseGetInternalTransfersResponse(rsp *http.Response) (*GetInternalTransfersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInternalTransfersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InternalTransfersResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeInternalTransfersResponse parses an HTTP response from a SubscribeInternalTransfersWithResponse call
+func ParseSubscribeInternalTransfersResponse(rsp *http.Response) (*SubscribeInternalTransfersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeInternalTransfersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPendingResponse parses an HTTP response from a GetPendingWithResponse call
+func ParseGetPendingResponse(rsp *http.Response) (*GetPendingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPendingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PendingResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribePendingResponse parses an HTTP response from a SubscribePendingWithResponse call
+func ParseSubscribePendingResponse(rsp *http.Response) (*SubscribePendingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribePendingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAddressStatsResponse parses an HTTP response from a GetAddressStatsWithResponse call
+func ParseGetAddressStatsResponse(rsp *http.Response) (*GetAddressStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAddressStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AddressStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeAddressResponse parses an HTTP response from a SubscribeAddressWithResponse call
+func ParseSubscribeAddressResponse(rsp *http.Response) (*SubscribeAddressResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeAddressResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest Gone
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTokenTransfersResponse parses an HTTP response from a GetTokenTransfersWithResponse call
+func ParseGetTokenTransfersResponse(rsp *http.Response) (*GetTokenTransfersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTokenTransfersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TokenTransfersResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeTokenTransfersResponse parses an HTTP response from a SubscribeTokenTransfersWithResponse call
+func ParseSubscribeTokenTransfersResponse(rsp *http.Response) (*SubscribeTokenTransfersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeTokenTransfersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListKeysResponse parses an HTTP response from a ListKeysWithResponse call
+func ParseListKeysResponse(rsp *http.Response) (*ListKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest KeysResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseIssueKeyResponse parses an HTTP response from a IssueKeyWithResponse call
+func ParseIssueKeyResponse(rsp *http.Response) (*IssueKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &IssueKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest IssuedKey
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRevokeKeyResponse parses an HTTP response from a RevokeKeyWithResponse call
+func ParseRevokeKeyResponse(rsp *http.Response) (*RevokeKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListChainsResponse parses an HTTP response from a ListChainsWithResponse call
+func ParseListChainsResponse(rsp *http.Response) (*ListChainsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListChainsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChainsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChainStatusResponse parses an HTTP response from a GetChainStatusWithResponse call
+func ParseGetChainStatusResponse(rsp *http.Response) (*GetChainStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChainStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StatusResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListChainAddressesResponse parses an HTTP response from a ListChainAddressesWithResponse call
+func ParseListChainAddressesResponse(rsp *http.Response) (*ListChainAddressesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListChainAddressesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AddressesResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Syncing
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChainTopAddressesResponse parses an HTTP response from a GetChainTopAddressesWithResponse call
+func ParseGetChainTopAddressesResponse(rsp *http.Response) (*GetChainTopAddressesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChainTopAddressesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TopAddressesResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChainAddressResponse parses an HTTP response from a GetChainAddressWithResponse call
+func ParseGetChainAddressResponse(rsp *http.Response) (*GetChainAddressResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChainAddressResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransactionsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Syncing
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChainInternalTransfersResponse parses an HTTP response from a GetChainInternalTransfersWithResponse call
+func ParseGetChainInternalTransfersResponse(rsp *http.Response) (*GetChainInternalTransfersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChainInternalTransfersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InternalTransfersResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeChainInternalTransfersResponse parses an HTTP response from a SubscribeChainInternalTransfersWithResponse call
+func ParseSubscribeChainInternalTransfersResponse(rsp *http.Response) (*SubscribeChainInternalTransfersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeChainInternalTransfersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChainPendingResponse parses an HTTP response from a GetChainPendingWithResponse call
+func ParseGetChainPendingResponse(rsp *http.Response) (*GetChainPendingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChainPendingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PendingResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeChainPendingResponse parses an HTTP response from a SubscribeChainPendingWithResponse call
+func ParseSubscribeChainPendingResponse(rsp *http.Response) (*SubscribeChainPendingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeChainPendingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChainAddressStatsResponse parses an HTTP response from a GetChainAddressStatsWithResponse call
+func ParseGetChainAddressStatsResponse(rsp *http.Response) (*GetChainAddressStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChainAddressStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AddressStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeChainAddressResponse parses an HTTP response from a SubscribeChainAddressWithResponse call
+func ParseSubscribeChainAddressResponse(rsp *http.Response) (*SubscribeChainAddressResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeChainAddressResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest Gone
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChainTokenTransfersResponse parses an HTTP response from a GetChainTokenTransfersWithResponse call
+func ParseGetChainTokenTransfersResponse(rsp *http.Response) (*GetChainTokenTransfersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChainTokenTransfersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TokenTransfersResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeChainTokenTransfersResponse parses an HTTP response from a SubscribeChainTokenTransfersWithResponse call
+func ParseSubscribeChainTokenTransfersResponse(rsp *http.Response) (*SubscribeChainTokenTransfersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeChainTokenTransfersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChainIndexingResponse parses an HTTP response from a GetChainIndexingWithResponse call
+func ParseGetChainIndexingResponse(rsp *http.Response) (*GetChainIndexingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChainIndexingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest IndexingProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeChainFilterResponse parses an HTTP response from a SubscribeChainFilterWithResponse call
+func ParseSubscribeChainFilterResponse(rsp *http.Response) (*SubscribeChainFilterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeChainFilterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest Gone
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChainWatchlistResponse parses an HTTP response from a GetChainWatchlistWithResponse call
+func ParseGetChainWatchlistResponse(rsp *http.Response) (*GetChainWatchlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChainWatchlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WatchlistResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddChainWatchlistResponse parses an HTTP response from a AddChainWatchlistWithResponse call
+func ParseAddChainWatchlistResponse(rsp *http.Response) (*AddChainWatchlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddChainWatchlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WatchlistAdded
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest WatchlistAdded
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseImportChainWatchlistResponse parses an HTTP response from a ImportChainWatchlistWithResponse call
+func ParseImportChainWatchlistResponse(rsp *http.Response) (*ImportChainWatchlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportChainWatchlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WatchlistAdded
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest WatchlistAdded
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveChainWatchlistResponse parses an HTTP response from a RemoveChainWatchlistWithResponse call
+func ParseRemoveChainWatchlistResponse(rsp *http.Response) (*RemoveChainWatchlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveChainWatchlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseChainWebSocketResponse parses an HTTP response from a ChainWebSocketWithResponse call
+func ParseChainWebSocketResponse(rsp *http.Response) (*ChainWebSocketResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ChainWebSocketResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetIndexingResponse parses an HTTP response from a GetIndexingWithResponse call
+func ParseGetIndexingResponse(rsp *http.Response) (*GetIndexingResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetIndexingResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest IndexingProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOpenAPIResponse parses an HTTP response from a GetOpenAPIWithResponse call
+func ParseGetOpenAPIResponse(rsp *http.Response) (*GetOpenAPIResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOpenAPIResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "yaml") && rsp.StatusCode == 200:
+		var dest string
+		if err := yaml.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.YAML200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeFilterResponse parses an HTTP response from a SubscribeFilterWithResponse call
+func ParseSubscribeFilterResponse(rsp *http.Response) (*SubscribeFilterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeFilterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest Gone
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWatchlistResponse parses an HTTP response from a GetWatchlistWithResponse call
+func ParseGetWatchlistResponse(rsp *http.Response) (*GetWatchlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWatchlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WatchlistResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddWatchlistResponse parses an HTTP response from a AddWatchlistWithResponse call
+func ParseAddWatchlistResponse(rsp *http.Response) (*AddWatchlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddWatchlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WatchlistAdded
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest WatchlistAdded
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseImportWatchlistResponse parses an HTTP response from a ImportWatchlistWithResponse call
+func ParseImportWatchlistResponse(rsp *http.Response) (*ImportWatchlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportWatchlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WatchlistAdded
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest WatchlistAdded
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveWatchlistResponse parses an HTTP response from a RemoveWatchlistWithResponse call
+func ParseRemoveWatchlistResponse(rsp *http.Response) (*RemoveWatchlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveWatchlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListWebhooksResponse parses an HTTP response from a ListWebhooksWithResponse call
+func ParseListWebhooksResponse(rsp *http.Response) (*ListWebhooksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWebhooksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhooksResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterWebhookResponse parses an HTTP response from a RegisterWebhookWithResponse call
+func ParseRegisterWebhookResponse(rsp *http.Response) (*RegisterWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest RegisteredWebhook
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListDeadLettersResponse parses an HTTP response from a ListDeadLettersWithResponse call
+func ParseListDeadLettersResponse(rsp *http.Response) (*ListDeadLettersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListDeadLettersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DeadLettersResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteWebhookResponse parses an HTTP response from a DeleteWebhookWithResponse call
+func ParseDeleteWebhookResponse(rsp *http.Response) (*DeleteWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListWebhookDeliveriesResponse parses an HTTP response from a ListWebhookDeliveriesWithResponse call
+func ParseListWebhookDeliveriesResponse(rsp *http.Response) (*ListWebhookDeliveriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWebhookDeliveriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DeliveriesResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseWebSocketResponse parses an HTTP response from a WebSocketWithResponse call
+func ParseWebSocketResponse(rsp *http.Response) (*WebSocketResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &WebSocketResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
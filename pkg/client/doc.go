@@ -0,0 +1,6 @@
+// Package client is a typed Go client of the transaction parser
+// REST API. The client is generated from api/openapi.yaml; run
+// go generate after changing the document.
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.4.1 -config oapi-codegen.yaml ../../api/openapi.yaml
@@ -0,0 +1,7 @@
+// Package parserpb holds the protobuf messages and gRPC stubs of
+// the transaction parser service. The code is generated from
+// api/proto; run go generate after changing the definitions.
+// protoc-gen-go and protoc-gen-go-grpc must be on the PATH.
+package parserpb
+
+//go:generate go run github.com/bufbuild/buf/cmd/buf@v1.50.0 generate ../../api/proto --template buf.gen.yaml